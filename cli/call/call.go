@@ -0,0 +1,77 @@
+package call
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/formatter"
+)
+
+// CallCtx contains information for calling a stored function.
+type CallCtx struct {
+	// FuncName is a name of the function to call.
+	FuncName string
+	// Args contains JSON or YAML encoded arguments of the function.
+	Args []string
+	// Format is an output format.
+	Format formatter.Format
+	// FormatOpts contains output formatting options.
+	FormatOpts formatter.Opts
+}
+
+// ParseArgs decodes JSON or YAML encoded arguments. A value that is not
+// a valid YAML is an error, a plain word is decoded as a string. YAML 1.2
+// rules are used, so yes/no/on/off are strings.
+func ParseArgs(args []string) ([]interface{}, error) {
+	decoded := make([]interface{}, 0, len(args))
+	for _, arg := range args {
+		var value interface{}
+		if err := yamlv3.Unmarshal([]byte(arg), &value); err != nil {
+			return nil, fmt.Errorf("failed to decode argument %q: %w", arg, err)
+		}
+		decoded = append(decoded, value)
+	}
+	return decoded, nil
+}
+
+// EncodeResult encodes the result of a call into the YAML document in the same
+// way as the console does.
+func EncodeResult(result []interface{}) (string, error) {
+	if len(result) == 0 {
+		return "---\n...\n", nil
+	}
+
+	encoded, err := yaml.Marshal(result)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode the result: %w", err)
+	}
+	return "---\n" + string(encoded) + "...\n", nil
+}
+
+// Call calls the stored function on the instance and returns the formatted
+// result.
+func Call(callCtx CallCtx, conn connector.Caller) (string, error) {
+	args, err := ParseArgs(callCtx.Args)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := conn.Call(callCtx.FuncName, args, connector.RequestOpts{})
+	if err != nil {
+		return "", fmt.Errorf("failed to call %q: %w", callCtx.FuncName, err)
+	}
+
+	data, err := EncodeResult(result)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := formatter.MakeOutput(callCtx.Format, data, callCtx.FormatOpts)
+	if err != nil {
+		return "", fmt.Errorf("unable to format output: %w", err)
+	}
+	return output, nil
+}
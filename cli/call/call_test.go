@@ -0,0 +1,114 @@
+package call_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/tt/cli/call"
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/formatter"
+)
+
+type callerMock struct {
+	funcName string
+	args     []interface{}
+	ret      []interface{}
+	err      error
+}
+
+func (caller *callerMock) Call(funcName string, args []interface{},
+	opts connector.RequestOpts) ([]interface{}, error) {
+	caller.funcName = funcName
+	caller.args = args
+	return caller.ret, caller.err
+}
+
+func TestParseArgs(t *testing.T) {
+	args, err := ParseArgs([]string{
+		"1",
+		"-2.5",
+		"foo",
+		`"1"`,
+		"true",
+		"yes",
+		"off",
+		"null",
+		`{"a": 1}`,
+		"[1, 2]",
+		"{a: [b]}",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		1,
+		-2.5,
+		"foo",
+		"1",
+		true,
+		"yes",
+		"off",
+		nil,
+		map[string]interface{}{"a": 1},
+		[]interface{}{1, 2},
+		map[string]interface{}{"a": []interface{}{"b"}},
+	}, args)
+
+	_, err = ParseArgs([]string{"{a: 1"})
+	assert.ErrorContains(t, err, `failed to decode argument "{a: 1"`)
+}
+
+func TestEncodeResult(t *testing.T) {
+	cases := []struct {
+		name     string
+		result   []interface{}
+		expected string
+	}{
+		{"empty", nil, "---\n...\n"},
+		{"scalars", []interface{}{1, "foo", nil}, "---\n- 1\n- foo\n- null\n...\n"},
+		{"map", []interface{}{map[string]interface{}{"a": 1}}, "---\n- a: 1\n...\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			encoded, err := EncodeResult(c.result)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, encoded)
+		})
+	}
+}
+
+func TestCall(t *testing.T) {
+	caller := &callerMock{
+		ret: []interface{}{[]interface{}{1, "foo"}},
+	}
+	callCtx := CallCtx{
+		FuncName: "box.space.test:select",
+		Args:     []string{"[1]"},
+		Format:   formatter.YamlFormat,
+	}
+
+	output, err := Call(callCtx, caller)
+	require.NoError(t, err)
+	assert.Equal(t, "---\n- - 1\n  - foo\n...\n\n", output)
+	assert.Equal(t, "box.space.test:select", caller.funcName)
+	assert.Equal(t, []interface{}{[]interface{}{1}}, caller.args)
+
+	callCtx.Format = formatter.LuaFormat
+	output, err = Call(callCtx, caller)
+	require.NoError(t, err)
+	assert.Equal(t, "{1, \"foo\"};\n", output)
+}
+
+func TestCall_error(t *testing.T) {
+	caller := &callerMock{err: errors.New("access denied")}
+	callCtx := CallCtx{FuncName: "foo"}
+
+	_, err := Call(callCtx, caller)
+	assert.EqualError(t, err, `failed to call "foo": access denied`)
+
+	callCtx.Args = []string{"[1"}
+	_, err = Call(callCtx, caller)
+	assert.ErrorContains(t, err, "failed to decode argument")
+}
@@ -107,6 +107,8 @@ templates:
 repo:
   rocks: ""
   distfiles: %[1]s/distfiles
+console:
+  pager: ""
 `, configDir),
 			wantErr: false,
 		},
@@ -144,6 +146,8 @@ templates:
 repo:
   rocks: ""
   distfiles: %[1]s/distfiles
+console:
+  pager: ""
 `, configDir),
 			wantErr: false,
 		},
@@ -180,6 +184,8 @@ templates:
 repo:
   rocks: ""
   distfiles: %[1]s/distfiles
+console:
+  pager: ""
 `, filepath.Join(cwd, "testdata", "app_dir")),
 			wantErr: false,
 		},
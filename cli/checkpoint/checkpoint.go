@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"time"
 
 	"github.com/tarantool/tt/cli/cmdcontext"
 )
@@ -13,15 +14,36 @@ import (
 // Opts contains flags for managing checkpoint files commands.
 // Used for commands tt cat and tt play, which are checkpoint files commands.
 type Opts struct {
-	To         uint64
-	From       uint64
-	Space      []int
-	Format     string
-	Replica    []int
-	ShowSystem bool
+	To           uint64
+	From         uint64
+	Space        []int
+	Format       string
+	Replica      []int
+	ShowSystem   bool
+	UseTarantool bool
+	Since        time.Time
+	Until        time.Time
+	Type         []string
+	Key          []interface{}
+	Decode       bool
+	// Schema is used to decode tuples and to get primary keys. It is
+	// updated with _space and _index rows of the files.
+	Schema *Schema
+	// Stats enables printing of summary statistics instead of the records.
+	Stats bool
+	// DryRun enables counting of records to play without sending them.
+	DryRun bool
+	// Rate is a maximum number of operations per second to play, zero means
+	// no limit.
+	Rate float64
+	// ResumeFile is a file to persist a vector clock of applied records.
+	ResumeFile string
+	// SpaceMap maps source space identifiers to target space identifiers or
+	// names to play.
+	SpaceMap map[uint64]interface{}
 }
 
-// Cat print the contents of .snap/.xlog files.
+// Cat print the contents of .snap/.xlog files with a Tarantool executable.
 // Returns an error if such occur during reading files.
 func Cat(tntCli cmdcontext.TarantoolCli) error {
 	cmd := exec.Command(tntCli.Executable, "-")
@@ -42,7 +64,8 @@ func Cat(tntCli cmdcontext.TarantoolCli) error {
 	return nil
 }
 
-// Play is playing the contents of .snap/.xlog files to another Tarantool instance.
+// Play is playing the contents of .snap/.xlog files to another Tarantool instance
+// with a Tarantool executable.
 // Returns an error if such occur during playing.
 func Play(tntCli cmdcontext.TarantoolCli) error {
 	var errbuff bytes.Buffer
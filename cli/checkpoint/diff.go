@@ -0,0 +1,160 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// DiffOpts contains options of a comparison of two states.
+type DiffOpts struct {
+	// Space contains identifiers of spaces to compare. All user spaces are
+	// compared if it is empty.
+	Space []int
+	// ShowSystem enables a comparison of system spaces.
+	ShowSystem bool
+	// Dump is a writer of the differing tuples as JSON Lines, if not nil.
+	Dump io.Writer
+}
+
+// SpaceDiff contains counts of tuples of a space.
+type SpaceDiff struct {
+	SpaceID   uint64 `json:"space_id"`
+	Name      string `json:"name"`
+	Equal     int    `json:"equal"`
+	OnlyA     int    `json:"only_a"`
+	OnlyB     int    `json:"only_b"`
+	Different int    `json:"different"`
+}
+
+// tupleDiff is a differing tuple to dump.
+type tupleDiff struct {
+	SpaceID uint64          `json:"space_id"`
+	Space   string          `json:"space,omitempty"`
+	Status  string          `json:"status"`
+	Key     json.RawMessage `json:"key"`
+	A       interface{}     `json:"a,omitempty"`
+	B       interface{}     `json:"b,omitempty"`
+}
+
+// spaceName returns a name of the space from one of the states.
+func spaceName(spaceID uint64, states ...*dataState) string {
+	for _, state := range states {
+		if def, ok := state.schema.spaces[spaceID]; ok && def.name != "" {
+			return def.name
+		}
+	}
+	return ""
+}
+
+// Diff compares states of two .snap files or data directories space by
+// space. A state of a directory is its newest snapshot with the following
+// xlogs applied in memory. Tuples are matched by primary keys.
+func Diff(a, b string, opts DiffOpts) ([]SpaceDiff, error) {
+	stateA, err := loadState(a)
+	if err != nil {
+		return nil, err
+	}
+	stateB, err := loadState(b)
+	if err != nil {
+		return nil, err
+	}
+
+	found := map[uint64]bool{}
+	ids := []uint64{}
+	for _, state := range []*dataState{stateA, stateB} {
+		for id, data := range state.spaces {
+			if len(data) == 0 || found[id] {
+				continue
+			}
+			if opts.Space == nil && id <= systemSpaceIDMax && !opts.ShowSystem {
+				continue
+			}
+			if opts.Space != nil && !containsID(opts.Space, id) {
+				continue
+			}
+			found[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	diffs := make([]SpaceDiff, 0, len(ids))
+	for _, id := range ids {
+		diff, err := diffSpace(id, stateA, stateB, opts)
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs, nil
+}
+
+// diffSpace compares tuples of the space.
+func diffSpace(spaceID uint64, stateA, stateB *dataState, opts DiffOpts) (SpaceDiff, error) {
+	diff := SpaceDiff{SpaceID: spaceID, Name: spaceName(spaceID, stateA, stateB)}
+	dataA, dataB := stateA.spaces[spaceID], stateB.spaces[spaceID]
+	keys := make([]string, 0, len(dataA)+len(dataB))
+	for key := range dataA {
+		keys = append(keys, key)
+	}
+	for key := range dataB {
+		if _, ok := dataA[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		tupleA, inA := dataA[key]
+		tupleB, inB := dataB[key]
+		dump := tupleDiff{SpaceID: spaceID, Space: diff.Name}
+		switch {
+		case inA && inB && encodeKey(tupleA) == encodeKey(tupleB):
+			diff.Equal++
+			continue
+		case inA && inB:
+			diff.Different++
+			dump.Status, dump.A, dump.B = "different", plainValue(tupleA), plainValue(tupleB)
+		case inA:
+			diff.OnlyA++
+			dump.Status, dump.A = "only_a", plainValue(tupleA)
+		default:
+			diff.OnlyB++
+			dump.Status, dump.B = "only_b", plainValue(tupleB)
+		}
+		if opts.Dump == nil {
+			continue
+		}
+		dump.Key = json.RawMessage(key)
+		data, err := json.Marshal(dump)
+		if err != nil {
+			return diff, err
+		}
+		if _, err := fmt.Fprintf(opts.Dump, "%s\n", data); err != nil {
+			return diff, err
+		}
+	}
+	return diff, nil
+}
+
+// WriteDiff writes counts of tuples per space as a table and a summary.
+func WriteDiff(w io.Writer, diffs []SpaceDiff) {
+	tw := newStatsTable(w, table.Row{"SPACE", "NAME", "EQUAL", "ONLY A", "ONLY B",
+		"DIFFERENT"})
+	total := 0
+	for _, diff := range diffs {
+		tw.AppendRow(table.Row{diff.SpaceID, diff.Name, diff.Equal, diff.OnlyA, diff.OnlyB,
+			diff.Different})
+		total += diff.OnlyA + diff.OnlyB + diff.Different
+	}
+	tw.Render()
+	if total == 0 {
+		fmt.Fprintln(w, "\n• The data are identical •")
+	} else {
+		fmt.Fprintf(w, "\n• Differing tuples: %d •\n", total)
+	}
+}
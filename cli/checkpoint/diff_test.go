@@ -0,0 +1,94 @@
+package checkpoint
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/vmihailenco/msgpack.v2"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/util"
+)
+
+// makeRawRequest makes a single row transaction with raw data.
+func makeRawRequest(t *testing.T, lsn uint64, rowType xlog.RequestType,
+	body map[uint64]interface{}) xlog.Row {
+	t.Helper()
+	raw, err := msgpack.Marshal(map[uint64]interface{}{
+		0x00: uint64(rowType),
+		0x02: uint64(1),
+		0x03: lsn,
+	})
+	require.NoError(t, err)
+	encoded, err := msgpack.Marshal(body)
+	require.NoError(t, err)
+	return xlog.Row{Raw: append(raw, encoded...)}
+}
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, util.CopyFilePreserve(testSnap,
+		filepath.Join(dir, "00000000000000000000.snap")))
+	writeTestXlog(t, filepath.Join(dir, "00000000000000000000.xlog"), xlog.VClock{},
+		[]xlog.Row{makeRawRequest(t, 1, xlog.UpdateType, map[uint64]interface{}{
+			xlog.SpaceIDKey:   uint64(272),
+			xlog.IndexBaseKey: uint64(1),
+			xlog.KeyKey:       []interface{}{"max_id"},
+			xlog.TupleKey:     []interface{}{[]interface{}{"+", uint64(2), uint64(1)}},
+		})},
+		[]xlog.Row{makeRawRequest(t, 2, xlog.InsertType, map[uint64]interface{}{
+			xlog.SpaceIDKey: uint64(272),
+			xlog.TupleKey:   []interface{}{"new_key", uint64(1)},
+		})},
+		[]xlog.Row{makeRawRequest(t, 3, xlog.DeleteType, map[uint64]interface{}{
+			xlog.SpaceIDKey: uint64(320),
+			xlog.KeyKey:     []interface{}{uint64(1)},
+		})},
+		[]xlog.Row{makeRawRequest(t, 4, xlog.UpsertType, map[uint64]interface{}{
+			xlog.SpaceIDKey:    uint64(272),
+			xlog.IndexBaseKey:  uint64(1),
+			xlog.TupleKey:      []interface{}{"version", uint64(0)},
+			xlog.OperationsKey: []interface{}{[]interface{}{"=", uint64(2), uint64(3)}},
+		})})
+
+	var dump bytes.Buffer
+	diffs, err := Diff(testSnap, dir, DiffOpts{Space: []int{272, 320}, Dump: &dump})
+	require.NoError(t, err)
+	assert.Equal(t, []SpaceDiff{
+		{SpaceID: 272, Name: "_schema", Equal: 1, OnlyB: 1, Different: 2},
+		{SpaceID: 320, Name: "_cluster", OnlyA: 1},
+	}, diffs)
+	assert.Equal(t, strings.Join([]string{
+		`{"space_id":272,"space":"_schema","status":"different","key":["max_id"],` +
+			`"a":["max_id",511],"b":["max_id",512]}`,
+		`{"space_id":272,"space":"_schema","status":"only_b","key":["new_key"],` +
+			`"b":["new_key",1]}`,
+		`{"space_id":272,"space":"_schema","status":"different","key":["version"],` +
+			`"a":["version",2,7,1],"b":["version",3,7,1]}`,
+		`{"space_id":320,"space":"_cluster","status":"only_a","key":[1],` +
+			`"a":[1,"8c275dcd-1479-4def-ae31-6e7a763fd84c"]}`,
+	}, "\n")+"\n", dump.String())
+
+	var buf bytes.Buffer
+	WriteDiff(&buf, diffs)
+	assert.Regexp(t, `(?s)SPACE +NAME +EQUAL +ONLY A +ONLY B +DIFFERENT`+
+		`.*272 +_schema +1 +0 +1 +2.*320 +_cluster +0 +1 +0 +0`+
+		`.*• Differing tuples: 4 •`, buf.String())
+
+	// The user spaces are the same.
+	diffs, err = Diff(testSnap, dir, DiffOpts{})
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+	buf.Reset()
+	WriteDiff(&buf, diffs)
+	assert.Contains(t, buf.String(), "• The data are identical •")
+
+	_, err = Diff(testXlog, dir, DiffOpts{})
+	assert.EqualError(t, err, `"`+testXlog+`" is not a .snap file or a directory`)
+	_, err = Diff(t.TempDir(), dir, DiffOpts{})
+	assert.ErrorContains(t, err, "no snapshot in")
+}
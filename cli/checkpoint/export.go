@@ -0,0 +1,554 @@
+package checkpoint
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// defaultPageSize is a default number of tuples fetched from an instance
+// per request.
+const defaultPageSize = 1000
+
+// fetchSpaceEval returns a format, indexes and a type of the primary index
+// of the space.
+const fetchSpaceEval = `
+local name = ...
+local space = box.space[name]
+if space == nil then
+    error(string.format("space %q is not found", name), 0)
+end
+if space.index[0] == nil then
+    error(string.format("space %q has no primary index", name), 0)
+end
+local indexes = {}
+for id, index in pairs(space.index) do
+    if type(id) == "number" then
+        local parts = {}
+        for _, part in ipairs(index.parts) do
+            table.insert(parts, part.fieldno - 1)
+        end
+        table.insert(indexes, {id, index.name, parts})
+    end
+end
+return space:format(), indexes, space.index[0].type
+`
+
+// fetchPageEval returns a page of tuples of the space by the primary index.
+const fetchPageEval = `
+local name, key, iterator, limit = ...
+return box.space[name].index[0]:select(key, {iterator = iterator, limit = limit})
+`
+
+// ExportOpts contains options of an export of a space.
+type ExportOpts struct {
+	// Space is a name of the space to export.
+	Space string
+	// Format is an output format: jsonl or csv.
+	Format string
+	// Where filters the tuples by an index key, if not nil.
+	Where *Where
+	// PageSize is a number of tuples fetched from an instance per request.
+	PageSize int
+}
+
+// Where is a condition on an index key.
+type Where struct {
+	// Index is a name of the index.
+	Index string
+	// Op is a comparison operator: =, >, >=, < or <=.
+	Op string
+	// Key is a key or a key prefix to compare with.
+	Key []interface{}
+}
+
+// ParseWhere parses a condition in the format <index><op><key>, e.g.
+// primary>=10 or name_age='["a", 10]'. The key is in JSON or YAML.
+func ParseWhere(str string) (*Where, error) {
+	pos := strings.IndexAny(str, "=<>")
+	if pos <= 0 || strings.TrimSpace(str[:pos]) == "" {
+		return nil, fmt.Errorf("invalid condition %q: <index><op><key> is expected", str)
+	}
+	end := pos
+	for end < len(str) && strings.ContainsRune("=<>", rune(str[end])) {
+		end++
+	}
+	op := str[pos:end]
+	switch op {
+	case "==":
+		op = "="
+	case "=", ">", ">=", "<", "<=":
+	default:
+		return nil, fmt.Errorf("invalid condition %q: unknown operator %q", str, op)
+	}
+	key, err := ParseKey(str[end:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid condition %q: %w", str, err)
+	}
+	return &Where{Index: strings.TrimSpace(str[:pos]), Op: op, Key: key}, nil
+}
+
+// whereFilter checks tuples against a condition.
+type whereFilter struct {
+	where *Where
+	// indexID is an identifier of the index of the condition.
+	indexID uint64
+	// parts contains zero-based field numbers of the index parts.
+	parts []uint64
+}
+
+// newWhereFilter creates a filter for the condition on the space. It returns
+// nil if the condition is nil.
+func newWhereFilter(where *Where, space string, def *spaceDef) (*whereFilter, error) {
+	if where == nil {
+		return nil, nil
+	}
+	indexID, ok := def.indexNames[where.Index]
+	if !ok {
+		return nil, fmt.Errorf("index %q is not found in space %q", where.Index, space)
+	}
+	parts := def.indexes[indexID]
+	if len(where.Key) > len(parts) {
+		return nil, fmt.Errorf("the key has %d parts, but index %q has %d",
+			len(where.Key), where.Index, len(parts))
+	}
+	return &whereFilter{where: where, indexID: indexID, parts: parts}, nil
+}
+
+// match returns true if the index key of the tuple satisfies the condition.
+func (filter *whereFilter) match(tuple []interface{}) bool {
+	if filter == nil {
+		return true
+	}
+	cmp := 0
+	for i, part := range filter.where.Key {
+		var field interface{}
+		if filter.parts[i] < uint64(len(tuple)) {
+			field = tuple[filter.parts[i]]
+		}
+		if cmp = compareValues(field, part); cmp != 0 {
+			break
+		}
+	}
+	switch filter.where.Op {
+	case "=":
+		return cmp == 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	}
+	return cmp <= 0
+}
+
+// valueRank returns a rank of a value type in the order of Tarantool scalar
+// types: nil, boolean, number, string and others.
+func valueRank(value interface{}) int {
+	switch value.(type) {
+	case nil:
+		return 0
+	case bool:
+		return 1
+	}
+	switch valueClass(value) {
+	case "number":
+		return 2
+	case "string":
+		return 3
+	}
+	return 4
+}
+
+// compareValues compares two values. Numbers of different types are compared
+// by their values.
+func compareValues(a, b interface{}) int {
+	rankA, rankB := valueRank(a), valueRank(b)
+	if rankA != rankB {
+		return rankA - rankB
+	}
+	switch rankA {
+	case 0:
+		return 0
+	case 1:
+		x, y := a.(bool), b.(bool)
+		if x == y {
+			return 0
+		} else if y {
+			return -1
+		}
+		return 1
+	case 2:
+		x, _ := toNumber(a)
+		y, _ := toNumber(b)
+		return toBigFloat(x).Cmp(toBigFloat(y))
+	case 3:
+		x, _ := toString(a)
+		y, _ := toString(b)
+		return strings.Compare(x, y)
+	}
+	return strings.Compare(fmt.Sprint(plainValue(a)), fmt.Sprint(plainValue(b)))
+}
+
+// toBigFloat converts a number returned by toNumber to a big float.
+func toBigFloat(number interface{}) *big.Float {
+	if i, ok := number.(*big.Int); ok {
+		return new(big.Float).SetInt(i)
+	}
+	return big.NewFloat(number.(float64))
+}
+
+// tupleWriter writes tuples in an export format.
+type tupleWriter interface {
+	// write writes the tuple.
+	write(tuple []interface{}) error
+	// close writes buffered data.
+	close() error
+	// discard removes buffered data if the writer is not closed.
+	discard()
+}
+
+// newTupleWriter creates a writer of the format.
+func newTupleWriter(format string, w io.Writer, def *spaceDef) (tupleWriter, error) {
+	switch format {
+	case "jsonl", "json":
+		return &jsonlWriter{w: w, def: def}, nil
+	case "csv":
+		return &csvWriter{w: csv.NewWriter(w), def: def, width: len(def.fields)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q: jsonl or csv is expected", format)
+}
+
+// jsonlWriter writes tuples as JSON objects with field names, one per line.
+type jsonlWriter struct {
+	w   io.Writer
+	def *spaceDef
+}
+
+// write writes the tuple.
+func (writer *jsonlWriter) write(tuple []interface{}) error {
+	object := make(orderedMap, 0, len(tuple))
+	for i, field := range tuple {
+		object = append(object, orderedField{writer.def.fieldName(uint64(i)), plainValue(field)})
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer.w, "%s\n", data)
+	return err
+}
+
+// close writes buffered data.
+func (writer *jsonlWriter) close() error {
+	return nil
+}
+
+// discard removes buffered data if the writer is not closed.
+func (writer *jsonlWriter) discard() {
+}
+
+// csvWriter writes tuples as CSV rows with a header of field names. Rows are
+// spooled to a temporary file until the close, so the header is extended to
+// the longest tuple and all rows are padded to the header width.
+type csvWriter struct {
+	w   *csv.Writer
+	def *spaceDef
+	// width is a number of columns of the header.
+	width int
+	// spool is a temporary file with rows encoded as JSON arrays, it is nil
+	// until the first row.
+	spool *os.File
+	// spoolBuf is a buffered writer of the spool.
+	spoolBuf *bufio.Writer
+	// spoolEnc encodes rows into the spool.
+	spoolEnc *json.Encoder
+}
+
+// write writes the tuple.
+func (writer *csvWriter) write(tuple []interface{}) error {
+	if writer.spool == nil {
+		spool, err := os.CreateTemp("", "tt-export-*.csv")
+		if err != nil {
+			return fmt.Errorf("failed to create a temporary file: %w", err)
+		}
+		writer.spool = spool
+		writer.spoolBuf = bufio.NewWriter(spool)
+		writer.spoolEnc = json.NewEncoder(writer.spoolBuf)
+	}
+	record := make([]string, 0, len(tuple))
+	for _, field := range tuple {
+		value, err := csvValue(field)
+		if err != nil {
+			return err
+		}
+		record = append(record, value)
+	}
+	if len(record) > writer.width {
+		writer.width = len(record)
+	}
+	return writer.spoolEnc.Encode(record)
+}
+
+// close writes the header and the spooled rows padded to the header width.
+func (writer *csvWriter) close() error {
+	defer writer.discard()
+	header := make([]string, 0, writer.width)
+	for i := 0; i < writer.width; i++ {
+		header = append(header, fmt.Sprint(writer.def.fieldName(uint64(i))))
+	}
+	if err := writer.w.Write(header); err != nil {
+		return err
+	}
+	if writer.spool != nil {
+		if err := writer.spoolBuf.Flush(); err != nil {
+			return err
+		}
+		if _, err := writer.spool.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		dec := json.NewDecoder(bufio.NewReader(writer.spool))
+		for {
+			var record []string
+			err := dec.Decode(&record)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("failed to read a temporary file: %w", err)
+			}
+			for len(record) < writer.width {
+				record = append(record, "")
+			}
+			if err := writer.w.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.w.Flush()
+	return writer.w.Error()
+}
+
+// discard removes the spool if the writer is not closed.
+func (writer *csvWriter) discard() {
+	if writer.spool != nil {
+		writer.spool.Close()
+		os.Remove(writer.spool.Name())
+		writer.spool = nil
+	}
+}
+
+// csvValue converts a field into a CSV value. Arrays and maps are encoded
+// in JSON, nil is an empty value.
+func csvValue(field interface{}) (string, error) {
+	switch value := plainValue(field).(type) {
+	case nil:
+		return "", nil
+	case string:
+		return value, nil
+	case float32:
+		return strconv.FormatFloat(float64(value), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64), nil
+	case orderedMap, []interface{}:
+		data, err := json.Marshal(value)
+		return string(data), err
+	default:
+		return fmt.Sprint(value), nil
+	}
+}
+
+// ExportSnapshot writes tuples of the space from the .snap file. It returns
+// a number of the written tuples.
+func ExportSnapshot(path string, opts ExportOpts, w io.Writer) (int, error) {
+	if filepath.Ext(path) != ".snap" {
+		return 0, fmt.Errorf("%q is not a .snap file", path)
+	}
+
+	schema := NewSchema()
+	var spaceID uint64
+	var filter *whereFilter
+	var out tupleWriter
+	defer func() {
+		if out != nil {
+			out.discard()
+		}
+	}()
+	count := 0
+	// The snapshot rows are ordered by space identifiers, so the schema is
+	// complete after _index rows.
+	start := func() error {
+		for id, def := range schema.spaces {
+			if def.name != opts.Space {
+				continue
+			}
+			var err error
+			if filter, err = newWhereFilter(opts.Where, opts.Space, def); err != nil {
+				return err
+			}
+			spaceID = id
+			out, err = newTupleWriter(opts.Format, w, def)
+			return err
+		}
+		return fmt.Errorf("space %q is not found in %q", opts.Space, path)
+	}
+
+	err := readFile(path, Opts{To: math.MaxUint64, ShowSystem: true}, func(row xlog.Row) error {
+		id, ok := row.SpaceID()
+		if !ok {
+			return nil
+		}
+		if id == spaceSpaceID || id == indexSpaceID {
+			schema.applyRow(row)
+			return nil
+		}
+		if id < indexSpaceID {
+			return nil
+		}
+		if out == nil {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		tuple, ok := row.Body[xlog.TupleKey].([]interface{})
+		if id != spaceID || !ok || !filter.match(tuple) {
+			return nil
+		}
+		count++
+		return out.write(tuple)
+	})
+	if err != nil {
+		return count, err
+	}
+	if out == nil {
+		if err := start(); err != nil {
+			return count, err
+		}
+	}
+	return count, out.close()
+}
+
+// fetchSpaceDef fetches a definition of the space from an instance. It also
+// returns true if the primary index is ordered.
+func fetchSpaceDef(evaler connector.Evaler, space string) (*spaceDef, bool, error) {
+	data, err := evaler.Eval(fetchSpaceEval, []interface{}{space}, connector.RequestOpts{})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(data) != 3 {
+		return nil, false, fmt.Errorf("failed to fetch space %q: unexpected response: %v",
+			space, data)
+	}
+
+	def := &spaceDef{
+		name:       space,
+		indexes:    map[uint64][]uint64{},
+		indexNames: map[string]uint64{},
+	}
+	format, _ := data[0].([]interface{})
+	def.setFormat(format)
+	indexes, _ := data[1].([]interface{})
+	for _, index := range indexes {
+		index, ok := index.([]interface{})
+		if !ok || len(index) != 3 {
+			continue
+		}
+		id, _ := toUint64(index[0])
+		name, _ := index[1].(string)
+		parts, _ := index[2].([]interface{})
+		fieldNos := make([]uint64, 0, len(parts))
+		for _, part := range parts {
+			fieldNo, _ := toUint64(part)
+			fieldNos = append(fieldNos, fieldNo)
+		}
+		def.indexes[id] = fieldNos
+		def.indexNames[name] = id
+	}
+	def.pk = def.indexes[0]
+	indexType, _ := data[2].(string)
+	return def, strings.EqualFold(indexType, "TREE"), nil
+}
+
+// ExportInstance writes tuples of the space of an instance. The tuples are
+// fetched by pages through the primary index. It returns a number of the
+// written tuples.
+func ExportInstance(evaler connector.Evaler, opts ExportOpts, w io.Writer) (int, error) {
+	def, ordered, err := fetchSpaceDef(evaler, opts.Space)
+	if err != nil {
+		return 0, err
+	}
+	filter, err := newWhereFilter(opts.Where, opts.Space, def)
+	if err != nil {
+		return 0, err
+	}
+	out, err := newTupleWriter(opts.Format, w, def)
+	if err != nil {
+		return 0, err
+	}
+	defer out.discard()
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	// A condition on an ordered primary index limits the range of the pages.
+	inRange := filter != nil && filter.indexID == 0 && ordered
+	key, iterator := []interface{}{}, "ALL"
+	if inRange && (filter.where.Op == "=" || filter.where.Op == ">=") {
+		key, iterator = filter.where.Key, "GE"
+	} else if inRange && filter.where.Op == ">" {
+		key, iterator = filter.where.Key, "GT"
+	}
+
+	count := 0
+	for {
+		data, err := evaler.Eval(fetchPageEval,
+			[]interface{}{opts.Space, key, iterator, pageSize}, connector.RequestOpts{})
+		if err != nil {
+			return count, err
+		}
+		var tuples []interface{}
+		if len(data) > 0 {
+			tuples, _ = data[0].([]interface{})
+		}
+		for _, tuple := range tuples {
+			tuple, ok := tuple.([]interface{})
+			if !ok {
+				return count, fmt.Errorf("unexpected tuple: %v", tuple)
+			}
+			if !filter.match(tuple) {
+				if inRange {
+					return count, out.close()
+				}
+				continue
+			}
+			if err := out.write(tuple); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(tuples) < pageSize {
+			return count, out.close()
+		}
+		last, _ := tuples[len(tuples)-1].([]interface{})
+		key, iterator = make([]interface{}, 0, len(def.pk)), "GT"
+		for _, fieldNo := range def.pk {
+			if fieldNo < uint64(len(last)) {
+				key = append(key, last[fieldNo])
+			}
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package checkpoint
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// exportEvaler is a mock of an instance with a space ordered by the first
+// unsigned field.
+type exportEvaler struct {
+	tuples   [][]interface{}
+	requests [][]interface{}
+}
+
+// Eval implements connector.Evaler interface.
+func (evaler *exportEvaler) Eval(expr string, args []interface{},
+	opts connector.RequestOpts) ([]interface{}, error) {
+	if expr == fetchSpaceEval {
+		return []interface{}{
+			[]interface{}{
+				map[interface{}]interface{}{"name": "id", "type": "unsigned"},
+				map[interface{}]interface{}{"name": "name", "type": "string"},
+			},
+			[]interface{}{
+				[]interface{}{uint64(0), "primary", []interface{}{uint64(0)}},
+				[]interface{}{uint64(1), "name", []interface{}{uint64(1)}},
+			},
+			"TREE",
+		}, nil
+	}
+
+	evaler.requests = append(evaler.requests, args[1:])
+	key, iterator, limit := args[1].([]interface{}), args[2].(string), args[3].(int)
+	page := []interface{}{}
+	for _, tuple := range evaler.tuples {
+		if len(page) == limit {
+			break
+		}
+		if iterator != "ALL" {
+			cmp := compareValues(tuple[0], key[0])
+			if iterator == "GE" && cmp < 0 || iterator == "GT" && cmp <= 0 {
+				continue
+			}
+		}
+		page = append(page, tuple)
+	}
+	return []interface{}{page}, nil
+}
+
+func TestParseWhere(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected *Where
+	}{
+		{"primary=1", &Where{"primary", "=", []interface{}{1}}},
+		{"primary == [1, 'a']", &Where{"primary", "=", []interface{}{1, "a"}}},
+		{"age>=30", &Where{"age", ">=", []interface{}{30}}},
+		{"name<\"b\"", &Where{"name", "<", []interface{}{"b"}}},
+		{"name<=b", &Where{"name", "<=", []interface{}{"b"}}},
+		{"id>-1", &Where{"id", ">", []interface{}{-1}}},
+	}
+	for _, c := range cases {
+		where, err := ParseWhere(c.str)
+		require.NoError(t, err, c.str)
+		assert.Equal(t, c.expected, where, c.str)
+	}
+
+	_, err := ParseWhere("=1")
+	assert.EqualError(t, err, `invalid condition "=1": <index><op><key> is expected`)
+	_, err = ParseWhere("primary")
+	assert.EqualError(t, err, `invalid condition "primary": <index><op><key> is expected`)
+	_, err = ParseWhere("primary<>1")
+	assert.EqualError(t, err, `invalid condition "primary<>1": unknown operator "<>"`)
+	_, err = ParseWhere("primary=")
+	assert.ErrorContains(t, err, "the key is empty")
+}
+
+func TestCompareValues(t *testing.T) {
+	assert.Equal(t, 0, compareValues(uint64(1), int64(1)))
+	assert.Equal(t, 0, compareValues(1.0, uint64(1)))
+	assert.Less(t, compareValues(int64(-1), uint64(0)), 0)
+	assert.Greater(t, compareValues(uint64(18446744073709551615), 1.5), 0)
+	assert.Less(t, compareValues("a", "b"), 0)
+	assert.Equal(t, 0, compareValues([]byte("a"), "a"))
+	assert.Less(t, compareValues(false, true), 0)
+	assert.Less(t, compareValues(nil, false), 0)
+	assert.Less(t, compareValues(true, uint64(0)), 0)
+	assert.Less(t, compareValues(uint64(10), "1"), 0)
+}
+
+func TestExportSnapshot(t *testing.T) {
+	var buf bytes.Buffer
+	count, err := ExportSnapshot(testSnap, ExportOpts{Space: "_cluster", Format: "jsonl"},
+		&buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, `{"id":1,"uuid":"8c275dcd-1479-4def-ae31-6e7a763fd84c"}`+"\n",
+		buf.String())
+
+	buf.Reset()
+	where, err := ParseWhere("name=LEAST")
+	require.NoError(t, err)
+	count, err = ExportSnapshot(testSnap, ExportOpts{Space: "_func", Format: "csv",
+		Where: where}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, "id,owner,name,setuid,language,body,routine_type,param_list,returns,"+
+		"aggregate,sql_data_access,is_deterministic,is_sandboxed,is_null_call,exports,opts,"+
+		"comment,created,last_altered\n"+
+		`67,1,LEAST,1,SQL_BUILTIN,,function,[],any,none,none,false,false,true,[],{},,`+
+		"2020-12-22 20:31:16,2020-12-22 20:31:16\n", buf.String())
+
+	buf.Reset()
+	where, err = ParseWhere("primary<0")
+	require.NoError(t, err)
+	count, err = ExportSnapshot(testSnap, ExportOpts{Space: "_cluster", Format: "csv",
+		Where: where}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, "id,uuid\n", buf.String())
+
+	_, err = ExportSnapshot(testSnap, ExportOpts{Space: "unknown", Format: "csv"}, &buf)
+	assert.EqualError(t, err, `space "unknown" is not found in "`+testSnap+`"`)
+	_, err = ExportSnapshot(testSnap, ExportOpts{Space: "_cluster", Format: "xml"}, &buf)
+	assert.EqualError(t, err, `unknown export format "xml": jsonl or csv is expected`)
+	where, err = ParseWhere("unknown=1")
+	require.NoError(t, err)
+	_, err = ExportSnapshot(testSnap, ExportOpts{Space: "_cluster", Format: "csv",
+		Where: where}, &buf)
+	assert.EqualError(t, err, `index "unknown" is not found in space "_cluster"`)
+	_, err = ExportSnapshot(testXlog, ExportOpts{Space: "_cluster"}, &buf)
+	assert.EqualError(t, err, `"`+testXlog+`" is not a .snap file`)
+}
+
+func TestCsvWriter_width(t *testing.T) {
+	def := &spaceDef{fields: []string{"id", "name", "comment"}}
+
+	var buf bytes.Buffer
+	writer, err := newTupleWriter("csv", &buf, def)
+	require.NoError(t, err)
+	defer writer.discard()
+	// A short tuple without the trailing nullable fields, a tuple of the
+	// format and a tuple with an extra field.
+	require.NoError(t, writer.write([]interface{}{uint64(1)}))
+	require.NoError(t, writer.write([]interface{}{uint64(2), "b", "c"}))
+	require.NoError(t, writer.write([]interface{}{uint64(3), "d", nil, true}))
+	require.NoError(t, writer.close())
+	assert.Equal(t, "id,name,comment,4\n1,,,\n2,b,c,\n3,d,,true\n", buf.String())
+
+	// A single empty field is kept.
+	buf.Reset()
+	writer, err = newTupleWriter("csv", &buf, &spaceDef{})
+	require.NoError(t, err)
+	require.NoError(t, writer.write([]interface{}{nil}))
+	require.NoError(t, writer.write([]interface{}{uint64(1), "a"}))
+	require.NoError(t, writer.close())
+	assert.Equal(t, "1,2\n,\n1,a\n", buf.String())
+}
+
+func TestExportInstance(t *testing.T) {
+	tuples := [][]interface{}{}
+	for i, name := range []string{"a", "b", "c", "b", "e"} {
+		tuples = append(tuples, []interface{}{uint64(i + 1), name})
+	}
+
+	cases := []struct {
+		name     string
+		where    string
+		requests [][]interface{}
+		output   string
+	}{
+		{
+			"all",
+			"",
+			[][]interface{}{
+				{[]interface{}{}, "ALL", 2},
+				{[]interface{}{uint64(2)}, "GT", 2},
+				{[]interface{}{uint64(4)}, "GT", 2},
+			},
+			"id,name\n1,a\n2,b\n3,c\n4,b\n5,e\n",
+		},
+		{
+			"secondary index",
+			"name=b",
+			[][]interface{}{
+				{[]interface{}{}, "ALL", 2},
+				{[]interface{}{uint64(2)}, "GT", 2},
+				{[]interface{}{uint64(4)}, "GT", 2},
+			},
+			"id,name\n2,b\n4,b\n",
+		},
+		{
+			"primary index equal",
+			"primary=2",
+			[][]interface{}{{[]interface{}{2}, "GE", 2}},
+			"id,name\n2,b\n",
+		},
+		{
+			"primary index greater",
+			"primary>3",
+			[][]interface{}{
+				{[]interface{}{3}, "GT", 2},
+				{[]interface{}{uint64(5)}, "GT", 2},
+			},
+			"id,name\n4,b\n5,e\n",
+		},
+		{
+			"primary index less",
+			"primary<=3",
+			[][]interface{}{
+				{[]interface{}{}, "ALL", 2},
+				{[]interface{}{uint64(2)}, "GT", 2},
+			},
+			"id,name\n1,a\n2,b\n3,c\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evaler := &exportEvaler{tuples: tuples}
+			opts := ExportOpts{Space: "test", Format: "csv", PageSize: 2}
+			if c.where != "" {
+				var err error
+				opts.Where, err = ParseWhere(c.where)
+				require.NoError(t, err)
+			}
+			var buf bytes.Buffer
+			_, err := ExportInstance(evaler, opts, &buf)
+			require.NoError(t, err)
+			assert.Equal(t, c.requests, evaler.requests)
+			assert.Equal(t, c.output, buf.String())
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package checkpoint
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"gopkg.in/yaml.v3"
+)
+
+// systemSpaceIDMax is a maximum identifier of a system space.
+const systemSpaceIDMax = 511
+
+// timeLayouts contains supported layouts of a time filter.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseTime parses a time of a filter. The time could be in RFC3339 format,
+// a local date-time or a Unix time in seconds.
+func ParseTime(str string) (time.Time, error) {
+	if seconds, err := strconv.ParseFloat(str, 64); err == nil {
+		sec, frac := math.Modf(seconds)
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, str, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: RFC3339, date-time or Unix time"+
+		" is expected", str)
+}
+
+// ParseKey parses a key prefix of a filter in JSON or YAML. A scalar value
+// is a single part key. YAML 1.2 rules are used, so yes/no/on/off are strings.
+func ParseKey(str string) ([]interface{}, error) {
+	var key interface{}
+	if err := yaml.Unmarshal([]byte(str), &key); err != nil {
+		return nil, fmt.Errorf("invalid key %q: %s", str, err)
+	}
+	switch key := key.(type) {
+	case nil:
+		return nil, fmt.Errorf("invalid key %q: the key is empty", str)
+	case []interface{}:
+		return key, nil
+	case map[string]interface{}, map[interface{}]interface{}:
+		return nil, fmt.Errorf("invalid key %q: an array or a scalar is expected", str)
+	default:
+		return []interface{}{key}, nil
+	}
+}
+
+// ValidateTypes checks names of request types for a filter.
+func ValidateTypes(types []string) error {
+	for _, name := range types {
+		if _, ok := xlog.ParseRequestType(name); !ok {
+			return fmt.Errorf("unknown request type %q", name)
+		}
+	}
+	return nil
+}
+
+// containsID returns true if the list contains the identifier.
+func containsID(list []int, id uint64) bool {
+	for _, item := range list {
+		if item >= 0 && uint64(item) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// containsType returns true if the list contains the request type.
+func containsType(list []string, requestType xlog.RequestType) bool {
+	for _, name := range list {
+		if strings.EqualFold(name, requestType.String()) {
+			return true
+		}
+	}
+	return false
+}
+
+// valueClass returns a class of a value to compare key parts.
+func valueClass(value interface{}) string {
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	}
+	if _, ok := value.([]byte); ok {
+		return "string"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// equalKeyParts returns true if the key parts are equal. Numbers of
+// different types are equal if they have the same value.
+func equalKeyParts(expected, actual interface{}) bool {
+	if valueClass(expected) != valueClass(actual) {
+		return false
+	}
+	return fmt.Sprint(plainValue(expected)) == fmt.Sprint(plainValue(actual))
+}
+
+// rowKey returns a primary key of the row.
+func rowKey(row xlog.Row, schema *Schema, size int) ([]interface{}, bool) {
+	spaceID, ok := row.SpaceID()
+	if !ok {
+		return nil, false
+	}
+	switch row.Header.Type {
+	case xlog.InsertType, xlog.ReplaceType, xlog.UpsertType:
+		tuple, ok := row.Body[xlog.TupleKey].([]interface{})
+		if !ok {
+			return nil, false
+		}
+		return schema.primaryKey(spaceID, tuple, size), true
+	case xlog.DeleteType, xlog.UpdateType:
+		if indexID, ok := row.Body[xlog.IndexIDKey]; ok {
+			if id, ok := toUint64(indexID); !ok || id != 0 {
+				// The key of a secondary index.
+				return nil, false
+			}
+		}
+		key, ok := row.Body[xlog.KeyKey].([]interface{})
+		return key, ok
+	}
+	return nil, false
+}
+
+// matchKey returns true if the primary key of the row starts with the prefix.
+func matchKey(row xlog.Row, schema *Schema, prefix []interface{}) bool {
+	key, ok := rowKey(row, schema, len(prefix))
+	if !ok || len(key) < len(prefix) {
+		return false
+	}
+	for i, part := range prefix {
+		if !equalKeyParts(part, key[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTime returns true if the row timestamp is in the range of the options.
+func matchTime(opts Opts, row xlog.Row) bool {
+	if opts.Since.IsZero() && opts.Until.IsZero() {
+		return true
+	}
+	if row.Header.Timestamp == 0 {
+		return false
+	}
+	sec, frac := math.Modf(row.Header.Timestamp)
+	timestamp := time.Unix(int64(sec), int64(frac*1e9))
+	if !opts.Since.IsZero() && timestamp.Before(opts.Since) {
+		return false
+	}
+	return opts.Until.IsZero() || timestamp.Before(opts.Until)
+}
+
+// filterRow checks the row against the options in the same way as cat.lua
+// and play.lua scripts do. It returns true if the row is accepted and true
+// if the rest of the file could be skipped.
+func filterRow(opts Opts, row xlog.Row) (accepted bool, stop bool) {
+	lsn, replicaID := row.Header.LSN, row.Header.ReplicaID
+	if len(opts.Replica) == 1 && containsID(opts.Replica, replicaID) && lsn >= opts.To {
+		// The rows of the replica are ordered by lsn.
+		return false, true
+	}
+	if lsn < opts.From || lsn >= opts.To {
+		return false, false
+	}
+	spaceID, hasSpace := row.SpaceID()
+	if opts.Space == nil && hasSpace && spaceID <= systemSpaceIDMax && !opts.ShowSystem {
+		return false, false
+	}
+	if opts.Space != nil && (!hasSpace || !containsID(opts.Space, spaceID)) {
+		return false, false
+	}
+	if opts.Replica != nil && !containsID(opts.Replica, replicaID) {
+		return false, false
+	}
+	if opts.Type != nil && !containsType(opts.Type, row.Header.Type) {
+		return false, false
+	}
+	if opts.Key != nil && !matchKey(row, opts.Schema, opts.Key) {
+		return false, false
+	}
+	return matchTime(opts, row), false
+}
@@ -0,0 +1,186 @@
+package checkpoint
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+const testSnap = "xlog/testdata/test.snap"
+
+func TestParseTime(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected time.Time
+	}{
+		{"1650033990.5", time.Unix(1650033990, 5e8)},
+		{"2022-04-15T14:46:30Z", time.Date(2022, 4, 15, 14, 46, 30, 0, time.UTC)},
+		{"2022-04-15T14:46:30", time.Date(2022, 4, 15, 14, 46, 30, 0, time.Local)},
+		{"2022-04-15 14:46:30", time.Date(2022, 4, 15, 14, 46, 30, 0, time.Local)},
+		{"2022-04-15", time.Date(2022, 4, 15, 0, 0, 0, 0, time.Local)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			actual, err := ParseTime(c.str)
+			require.NoError(t, err)
+			assert.True(t, c.expected.Equal(actual), actual)
+		})
+	}
+
+	_, err := ParseTime("yesterday")
+	assert.EqualError(t, err,
+		`invalid time "yesterday": RFC3339, date-time or Unix time is expected`)
+}
+
+func TestParseKey(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected []interface{}
+	}{
+		{"1", []interface{}{1}},
+		{"abc", []interface{}{"abc"}},
+		{"on", []interface{}{"on"}},
+		{"[yes, no]", []interface{}{"yes", "no"}},
+		{"true", []interface{}{true}},
+		{`[1, "a"]`, []interface{}{1, "a"}},
+		{"[-1.5]", []interface{}{-1.5}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			key, err := ParseKey(c.str)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, key)
+		})
+	}
+
+	_, err := ParseKey("")
+	assert.EqualError(t, err, `invalid key "": the key is empty`)
+	_, err = ParseKey("{a: 1}")
+	assert.EqualError(t, err, `invalid key "{a: 1}": an array or a scalar is expected`)
+	_, err = ParseKey("[1")
+	assert.ErrorContains(t, err, `invalid key "[1"`)
+}
+
+func TestValidateTypes(t *testing.T) {
+	assert.NoError(t, ValidateTypes(nil))
+	assert.NoError(t, ValidateTypes([]string{"insert", "REPLACE", "Delete"}))
+	assert.EqualError(t, ValidateTypes([]string{"insert", "select"}),
+		`unknown request type "select"`)
+}
+
+func TestFilterRow_extended(t *testing.T) {
+	row := makeRow(1, 1, uint64(512))
+	row.Header.Timestamp = 1650033990.5
+	row.Body[xlog.TupleKey] = []interface{}{uint64(10), "a", int64(-1)}
+
+	deleteRow := makeRow(2, 1, uint64(512))
+	deleteRow.Header.Type = xlog.DeleteType
+	deleteRow.Body[xlog.KeyKey] = []interface{}{uint64(10)}
+
+	secondaryRow := makeRow(2, 1, uint64(512))
+	secondaryRow.Header.Type = xlog.DeleteType
+	secondaryRow.Body[xlog.IndexIDKey] = uint64(1)
+	secondaryRow.Body[xlog.KeyKey] = []interface{}{uint64(10)}
+
+	schema := NewSchema()
+	schema.addIndex([]interface{}{uint64(512), uint64(0), "pk", "tree",
+		map[interface{}]interface{}{}, []interface{}{
+			map[interface{}]interface{}{"field": uint64(2), "type": "integer"},
+		}})
+
+	since := time.Unix(1650033990, 0)
+	until := time.Unix(1650033991, 0)
+	cases := []struct {
+		name     string
+		opts     Opts
+		row      xlog.Row
+		accepted bool
+	}{
+		{"since", Opts{To: math.MaxUint64, Since: since}, row, true},
+		{"since after", Opts{To: math.MaxUint64, Since: until}, row, false},
+		{"until", Opts{To: math.MaxUint64, Until: until}, row, true},
+		{"until before", Opts{To: math.MaxUint64, Until: since}, row, false},
+		{"no timestamp", Opts{To: math.MaxUint64, Since: since}, deleteRow, false},
+		{"type", Opts{To: math.MaxUint64, Type: []string{"insert"}}, row, true},
+		{"other type", Opts{To: math.MaxUint64, Type: []string{"delete"}}, row, false},
+		{"key", Opts{To: math.MaxUint64, Key: []interface{}{10}}, row, true},
+		{"key prefix", Opts{To: math.MaxUint64, Key: []interface{}{10, "a"}}, row, true},
+		{"other key", Opts{To: math.MaxUint64, Key: []interface{}{11}}, row, false},
+		{"key type", Opts{To: math.MaxUint64, Key: []interface{}{"10"}}, row, false},
+		{"long key", Opts{To: math.MaxUint64, Key: []interface{}{10, "a", -1, 0}}, row, false},
+		{"delete key", Opts{To: math.MaxUint64, Key: []interface{}{10}}, deleteRow, true},
+		{"secondary key", Opts{To: math.MaxUint64, Key: []interface{}{10}}, secondaryRow, false},
+		{
+			"schema key",
+			Opts{To: math.MaxUint64, Key: []interface{}{-1}, Schema: schema},
+			row, true,
+		},
+		{
+			"schema other key",
+			Opts{To: math.MaxUint64, Key: []interface{}{10}, Schema: schema},
+			row, false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			accepted, _ := filterRow(c.opts, c.row)
+			assert.Equal(t, c.accepted, accepted)
+		})
+	}
+}
+
+func TestMakeRecord_decode(t *testing.T) {
+	opts := Opts{
+		To:         math.MaxUint64,
+		ShowSystem: true,
+		Space:      []int{spaceSpaceID},
+		Key:        []interface{}{spaceSpaceID},
+		Schema:     NewSchema(),
+	}
+	var buf bytes.Buffer
+	err := readFile(testSnap, opts, func(row xlog.Row) error {
+		return writeJson(&buf, makeRecord(row, opts.Schema))
+	})
+	require.NoError(t, err)
+	assert.Regexp(t, `^\{"HEADER":\{"lsn":\d+,"type":"INSERT","timestamp":[\d.]+\},`+
+		`"BODY":\{"space_id":280,"tuple":\{"id":280,"owner":1,"name":"_space",`+
+		`"engine":"memtx","field_count":0,"flags":\{\},"format":\[.*\]\}\}\}\n$`, buf.String())
+
+	// The key is decoded with the primary key parts.
+	schema := NewSchema()
+	schema.addSpace([]interface{}{uint64(512), uint64(1), "test", "memtx", uint64(0),
+		map[interface{}]interface{}{}, []interface{}{
+			map[interface{}]interface{}{"name": "id", "type": "unsigned"},
+			map[interface{}]interface{}{"name": "value", "type": "string"},
+		}})
+	schema.addIndex([]interface{}{uint64(512), uint64(0), "pk", "tree",
+		map[interface{}]interface{}{}, []interface{}{[]interface{}{uint64(0), "unsigned"}}})
+	row := makeRow(3, 1, uint64(512))
+	row.Header.Type = xlog.DeleteType
+	row.Body[xlog.KeyKey] = []interface{}{uint64(1)}
+	buf.Reset()
+	require.NoError(t, writeJson(&buf, makeRecord(row, schema)))
+	assert.Equal(t, `{"HEADER":{"lsn":3,"type":"DELETE","replica_id":1},`+
+		`"BODY":{"space_id":512,"key":{"id":1}}}`+"\n", buf.String())
+
+	row.Header.Type = xlog.ReplaceType
+	row.Body = map[uint64]interface{}{
+		xlog.SpaceIDKey: uint64(512),
+		xlog.TupleKey:   []interface{}{uint64(1), "a", true},
+	}
+	buf.Reset()
+	require.NoError(t, writeYaml(io.Writer(&buf), makeRecord(row, schema)))
+	assert.Equal(t, "---\nHEADER:\n  lsn: 3\n  type: REPLACE\n  replica_id: 1\n"+
+		"BODY:\n  space_id: 512\n  tuple:\n    id: 1\n    value: a\n    3: true\n",
+		buf.String())
+}
@@ -0,0 +1,568 @@
+package checkpoint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/tarantool/go-tarantool/datetime"
+	"github.com/tarantool/go-tarantool/decimal"
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// defaultBatchSize is a default number of tuples sent to an instance in
+// a transaction.
+const defaultBatchSize = 1000
+
+// importBatchEval inserts or replaces the tuples of a batch in a transaction.
+// It returns the rejected tuples with the reasons, a number of the skipped
+// duplicates and the duplicate with the reason if the batch is rolled back.
+const importBatchEval = `
+local name, tuples, on_conflict = ...
+local space = box.space[name]
+local rejected, skipped = {}, 0
+box.begin()
+for i, tuple in ipairs(tuples) do
+    local ok, err
+    if on_conflict == "replace" then
+        ok, err = pcall(space.replace, space, tuple)
+    else
+        ok, err = pcall(space.insert, space, tuple)
+    end
+    if not ok then
+        local duplicate = type(err) == "cdata" and err.code == box.error.TUPLE_FOUND
+        if duplicate and on_conflict == "fail" then
+            box.rollback()
+            return rejected, skipped, {i, tostring(err)}
+        elseif duplicate then
+            skipped = skipped + 1
+        else
+            table.insert(rejected, {i, tostring(err)})
+        end
+    end
+end
+box.commit()
+return rejected, skipped
+`
+
+// ImportOpts contains options of an import into a space.
+type ImportOpts struct {
+	// Space is a name of the space to import into.
+	Space string
+	// Format is an input format: jsonl or csv.
+	Format string
+	// Fields maps input columns to the space fields: <column>:<field>. A column
+	// is a name from the header or a number starting from 1, a field is
+	// a name or a number starting from 1. Unmapped columns are ignored.
+	Fields []string
+	// NoHeader is true if the first CSV row contains data.
+	NoHeader bool
+	// BatchSize is a number of tuples sent in a transaction.
+	BatchSize int
+	// OnConflict is an action on a duplicate key: skip, replace or fail.
+	OnConflict string
+}
+
+// ImportResult contains counts of an import.
+type ImportResult struct {
+	// Imported is a number of the inserted or replaced tuples.
+	Imported int
+	// Skipped is a number of the skipped duplicates.
+	Skipped int
+	// Rejected is a number of the rows written to the error report.
+	Rejected int
+}
+
+// importRow is an input row.
+type importRow struct {
+	// line is a number of the input line.
+	line int
+	// raw is the row to write to the error report.
+	raw interface{}
+	// csv is true for a CSV row, its empty values are nulls.
+	csv bool
+	// names contains names of the columns, if known.
+	names []string
+	// values contains values of the columns.
+	values []interface{}
+}
+
+// rejectedRow is a record of the error report.
+type rejectedRow struct {
+	Line  int         `json:"line"`
+	Row   interface{} `json:"row"`
+	Error string      `json:"error"`
+}
+
+// importer converts input rows into tuples and sends them in batches.
+type importer struct {
+	evaler connector.Evaler
+	opts   ImportOpts
+	def    *spaceDef
+	// byName and byNumber map names and zero-based numbers of the columns
+	// to zero-based numbers of the fields.
+	byName   map[string]int
+	byNumber map[int]int
+	// errors is a writer of the error report.
+	errors io.Writer
+	result ImportResult
+	batch  []importRow
+	tuples []interface{}
+}
+
+// parseFieldNo parses a field name or a number starting from 1 into
+// a zero-based field number.
+func (def *spaceDef) parseFieldNo(field string) (int, bool) {
+	if number, err := strconv.Atoi(field); err == nil && number > 0 {
+		return number - 1, true
+	}
+	for i, name := range def.fields {
+		if name == field {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// parseMapping fills the mapping of the columns from the options.
+func (imp *importer) parseMapping() error {
+	imp.byName, imp.byNumber = map[string]int{}, map[int]int{}
+	for _, item := range imp.opts.Fields {
+		column, field, found := strings.Cut(item, ":")
+		if !found || column == "" || field == "" {
+			return fmt.Errorf("invalid field mapping %q: <column>:<field> is expected", item)
+		}
+		fieldNo, ok := imp.def.parseFieldNo(field)
+		if !ok {
+			return fmt.Errorf("field %q is not found in space %q", field, imp.opts.Space)
+		}
+		if number, err := strconv.Atoi(column); err == nil && number > 0 {
+			imp.byNumber[number-1] = fieldNo
+		} else {
+			imp.byName[column] = fieldNo
+		}
+	}
+	return nil
+}
+
+// mapColumn returns a zero-based field number of the column. It returns
+// false if the column is ignored.
+func (imp *importer) mapColumn(row importRow, column int) (int, bool, error) {
+	var name string
+	if column < len(row.names) {
+		name = row.names[column]
+	}
+	if len(imp.opts.Fields) > 0 {
+		if fieldNo, ok := imp.byName[name]; ok && name != "" {
+			return fieldNo, true, nil
+		}
+		fieldNo, ok := imp.byNumber[column]
+		return fieldNo, ok, nil
+	}
+	if name == "" {
+		return column, true, nil
+	}
+	if fieldNo, ok := imp.def.parseFieldNo(name); ok {
+		return fieldNo, true, nil
+	}
+	return 0, false, fmt.Errorf("column %q is not in the format of space %q",
+		name, imp.opts.Space)
+}
+
+// makeTuple converts the row into a tuple according to the space format.
+func (imp *importer) makeTuple(row importRow) ([]interface{}, error) {
+	values := map[int]interface{}{}
+	size := 0
+	for i, nullable := range imp.def.nullable {
+		if !nullable {
+			size = i + 1
+		}
+	}
+	for column, value := range row.values {
+		fieldNo, ok, err := imp.mapColumn(row, column)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		values[fieldNo] = value
+		if fieldNo+1 > size {
+			size = fieldNo + 1
+		}
+	}
+
+	tuple := make([]interface{}, size)
+	for i := range tuple {
+		fieldType, nullable := "any", true
+		if i < len(imp.def.types) {
+			fieldType, nullable = imp.def.types[i], imp.def.nullable[i]
+		}
+		if fieldType == "" {
+			fieldType = "any"
+		}
+		name := fmt.Sprint(imp.def.fieldName(uint64(i)))
+		value, ok := values[i]
+		if str, isStr := value.(string); isStr && str == "" && row.csv &&
+			(nullable || fieldType != "string") {
+			// An empty CSV value is null.
+			value = nil
+		}
+		if value == nil {
+			if !ok && !nullable {
+				return nil, fmt.Errorf("field %q is not set", name)
+			} else if !nullable {
+				return nil, fmt.Errorf("field %q is not nullable", name)
+			}
+			continue
+		}
+		converted, err := convertField(value, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		tuple[i] = converted
+	}
+	// Trailing nulls are omitted.
+	for len(tuple) > 0 && tuple[len(tuple)-1] == nil {
+		tuple = tuple[:len(tuple)-1]
+	}
+	return tuple, nil
+}
+
+// convertField converts a CSV or JSON value into a value of the field type.
+func convertField(value interface{}, fieldType string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return parseField(v, fieldType)
+	case json.Number:
+		if fieldType == "string" || fieldType == "varbinary" {
+			return nil, fmt.Errorf("a string is expected, got %s", v)
+		}
+		return parseField(v.String(), fieldType)
+	case bool:
+		switch fieldType {
+		case "boolean", "scalar", "any":
+			return v, nil
+		}
+	case []interface{}:
+		switch fieldType {
+		case "array", "any":
+			return jsonValue(v), nil
+		}
+	case map[string]interface{}:
+		switch fieldType {
+		case "map", "any":
+			return jsonValue(v), nil
+		}
+	}
+	return nil, fmt.Errorf("%s value is expected, got %v", fieldType, value)
+}
+
+// parseField parses a string into a value of the field type.
+func parseField(str, fieldType string) (interface{}, error) {
+	var value interface{}
+	var err error
+	switch fieldType {
+	case "string":
+		return str, nil
+	case "varbinary":
+		return []byte(str), nil
+	case "unsigned":
+		value, err = strconv.ParseUint(str, 10, 64)
+	case "integer":
+		if value, err = strconv.ParseInt(str, 10, 64); err != nil {
+			value, err = strconv.ParseUint(str, 10, 64)
+		}
+	case "number":
+		value, err = parseNumber(str)
+	case "double":
+		value, err = strconv.ParseFloat(str, 64)
+	case "boolean":
+		value, err = strconv.ParseBool(str)
+	case "uuid":
+		value, err = uuid.Parse(str)
+	case "decimal":
+		value, err = decimal.NewDecimalFromString(str)
+	case "datetime":
+		if t, parseErr := ParseTime(str); parseErr != nil {
+			err = parseErr
+		} else {
+			value, err = datetime.NewDatetime(t)
+		}
+	case "array", "map":
+		if err = decodeJSON(str, &value); err == nil {
+			value, err = convertField(value, fieldType)
+		}
+	default:
+		// A scalar or any value is a JSON value or a string.
+		if decodeJSON(str, &value) != nil {
+			return str, nil
+		}
+		return jsonValue(value), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s value %q", fieldType, str)
+	}
+	return value, nil
+}
+
+// parseNumber parses an integer or a floating point number.
+func parseNumber(str string) (interface{}, error) {
+	if value, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return value, nil
+	}
+	if value, err := strconv.ParseUint(str, 10, 64); err == nil {
+		return value, nil
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+// decodeJSON decodes a JSON value with numbers as json.Number.
+func decodeJSON(str string, value interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(str))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return fmt.Errorf("unexpected data after a JSON value")
+	}
+	return nil
+}
+
+// jsonValue converts numbers of a decoded JSON value into integers or
+// floating point numbers.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if number, err := parseNumber(v.String()); err == nil {
+			return number
+		}
+		return v.String()
+	case []interface{}:
+		array := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			array = append(array, jsonValue(item))
+		}
+		return array
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = jsonValue(item)
+		}
+		return m
+	}
+	return value
+}
+
+// reject writes the row to the error report.
+func (imp *importer) reject(row importRow, reason string) error {
+	imp.result.Rejected++
+	data, err := json.Marshal(rejectedRow{Line: row.line, Row: row.raw, Error: reason})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(imp.errors, "%s\n", data)
+	return err
+}
+
+// add converts the row and adds it to the batch. The batch is sent if it is
+// full.
+func (imp *importer) add(row importRow) error {
+	tuple, err := imp.makeTuple(row)
+	if err != nil {
+		return imp.reject(row, err.Error())
+	}
+	imp.batch = append(imp.batch, row)
+	imp.tuples = append(imp.tuples, tuple)
+	if len(imp.batch) < imp.opts.BatchSize {
+		return nil
+	}
+	return imp.flush()
+}
+
+// rejectBatch writes the rows of the batch rejected by the instance to the
+// error report. It returns a number of the rejected rows.
+func (imp *importer) rejectBatch(batch []importRow, rejected interface{}) (int, error) {
+	items, _ := rejected.([]interface{})
+	count := 0
+	for _, item := range items {
+		item, ok := item.([]interface{})
+		if !ok || len(item) != 2 {
+			continue
+		}
+		i, _ := toUint64(item[0])
+		if i == 0 || i > uint64(len(batch)) {
+			continue
+		}
+		if err := imp.reject(batch[i-1], fmt.Sprint(item[1])); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// flush sends the batch in a transaction.
+func (imp *importer) flush() error {
+	if len(imp.batch) == 0 {
+		return nil
+	}
+	batch, tuples := imp.batch, imp.tuples
+	imp.batch, imp.tuples = nil, nil
+	data, err := imp.evaler.Eval(importBatchEval,
+		[]interface{}{imp.opts.Space, tuples, imp.opts.OnConflict},
+		connector.RequestOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to import lines %d-%d: %w",
+			batch[0].line, batch[len(batch)-1].line, err)
+	}
+
+	// The rows rejected for other reasons are reported even if the batch is
+	// rolled back because of a duplicate.
+	rejected := 0
+	if len(data) > 0 {
+		if rejected, err = imp.rejectBatch(batch, data[0]); err != nil {
+			return err
+		}
+	}
+	if len(data) > 2 {
+		if failed, ok := data[2].([]interface{}); ok && len(failed) == 2 {
+			i, _ := toUint64(failed[0])
+			if i > 0 && i <= uint64(len(batch)) {
+				return fmt.Errorf("line %d: %v", batch[i-1].line, failed[1])
+			}
+			return fmt.Errorf("%v", failed[1])
+		}
+	}
+	skipped := 0
+	if len(data) > 1 {
+		if count, ok := toUint64(data[1]); ok {
+			skipped = int(count)
+		}
+	}
+	imp.result.Skipped += skipped
+	imp.result.Imported += len(batch) - rejected - skipped
+	return nil
+}
+
+// readCSV reads rows of CSV input.
+func (imp *importer) readCSV(r io.Reader) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	var header []string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if header == nil && !imp.opts.NoHeader {
+			header = record
+			for i := range header {
+				if _, _, err := imp.mapColumn(importRow{names: header}, i); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		row := importRow{line: line, raw: record, csv: true, names: header,
+			values: make([]interface{}, 0, len(record))}
+		for _, value := range record {
+			row.values = append(row.values, value)
+		}
+		if err := imp.add(row); err != nil {
+			return err
+		}
+	}
+}
+
+// readJSONL reads rows of JSON Lines input: objects with field names or
+// arrays of fields.
+func (imp *importer) readJSONL(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		row := importRow{line: line, raw: json.RawMessage(append([]byte{}, data...))}
+		var value interface{}
+		if err := decodeJSON(string(data), &value); err != nil {
+			row.raw = string(data)
+			if err := imp.reject(row, fmt.Sprintf("invalid JSON: %s", err)); err != nil {
+				return err
+			}
+			continue
+		}
+		switch value := value.(type) {
+		case map[string]interface{}:
+			for name, field := range value {
+				row.names = append(row.names, name)
+				row.values = append(row.values, field)
+			}
+		case []interface{}:
+			row.values = value
+		default:
+			if err := imp.reject(row, "an object or an array is expected"); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := imp.add(row); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Import reads CSV or JSON Lines rows, converts them into tuples according to
+// the space format and inserts or replaces them in batches. Each batch is
+// sent in a transaction. The rejected rows are written to the error report
+// as JSON Lines with the reasons.
+func Import(evaler connector.Evaler, r io.Reader, opts ImportOpts,
+	errors io.Writer) (ImportResult, error) {
+	switch opts.OnConflict {
+	case "skip", "replace", "fail":
+	default:
+		return ImportResult{}, fmt.Errorf(
+			"unknown conflict action %q: skip, replace or fail is expected", opts.OnConflict)
+	}
+	read := map[string]func(*importer, io.Reader) error{
+		"csv":   (*importer).readCSV,
+		"jsonl": (*importer).readJSONL,
+		"json":  (*importer).readJSONL,
+	}[opts.Format]
+	if read == nil {
+		return ImportResult{}, fmt.Errorf(
+			"unknown import format %q: csv or jsonl is expected", opts.Format)
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+
+	def, _, err := fetchSpaceDef(evaler, opts.Space)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	imp := &importer{evaler: evaler, opts: opts, def: def, errors: errors}
+	if err := imp.parseMapping(); err != nil {
+		return imp.result, err
+	}
+	if err := read(imp, r); err != nil {
+		return imp.result, err
+	}
+	return imp.result, imp.flush()
+}
@@ -0,0 +1,258 @@
+package checkpoint
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-tarantool/datetime"
+	"github.com/tarantool/go-tarantool/decimal"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// importEvaler is a mock of an instance with a space with the primary key
+// in the first field.
+type importEvaler struct {
+	format  []interface{}
+	tuples  map[string][]interface{}
+	batches int
+}
+
+// newImportEvaler creates a mock of an instance with a space of the fields:
+// [name, type, is_nullable].
+func newImportEvaler(fields ...[]interface{}) *importEvaler {
+	evaler := &importEvaler{tuples: map[string][]interface{}{}}
+	for _, field := range fields {
+		evaler.format = append(evaler.format, map[interface{}]interface{}{
+			"name": field[0], "type": field[1], "is_nullable": field[2],
+		})
+	}
+	return evaler
+}
+
+// Eval implements connector.Evaler interface.
+func (evaler *importEvaler) Eval(expr string, args []interface{},
+	opts connector.RequestOpts) ([]interface{}, error) {
+	if expr == fetchSpaceEval {
+		return []interface{}{
+			evaler.format,
+			[]interface{}{[]interface{}{uint64(0), "primary", []interface{}{uint64(0)}}},
+			"TREE",
+		}, nil
+	}
+
+	evaler.batches++
+	tuples, onConflict := args[1].([]interface{}), args[2].(string)
+	added := map[string][]interface{}{}
+	rejected, skipped := []interface{}{}, uint64(0)
+	for i, tuple := range tuples {
+		tuple := tuple.([]interface{})
+		if name, ok := tuple[1].(string); ok && name == "invalid" {
+			rejected = append(rejected, []interface{}{uint64(i + 1), "invalid name"})
+			continue
+		}
+		key := fmt.Sprint(tuple[0])
+		_, exists := evaler.tuples[key]
+		if _, ok := added[key]; ok || exists {
+			if onConflict == "fail" {
+				return []interface{}{rejected, skipped,
+					[]interface{}{uint64(i + 1), "Duplicate key exists"}}, nil
+			} else if onConflict == "skip" {
+				skipped++
+				continue
+			}
+		}
+		added[key] = tuple
+	}
+	for key, tuple := range added {
+		evaler.tuples[key] = tuple
+	}
+	return []interface{}{rejected, skipped}, nil
+}
+
+func TestImport_csv(t *testing.T) {
+	evaler := newImportEvaler(
+		[]interface{}{"id", "unsigned", false},
+		[]interface{}{"name", "string", false},
+		[]interface{}{"score", "number", true},
+		[]interface{}{"uuid", "uuid", true},
+		[]interface{}{"price", "decimal", true},
+		[]interface{}{"created", "datetime", true},
+		[]interface{}{"tags", "array", true},
+	)
+	input := "name,id,score,uuid,price,created,tags\n" +
+		"a,1,1.5,8c275dcd-1479-4def-ae31-6e7a763fd84c,10.25,2024-04-15T14:46:30Z," +
+		"\"[1,\"\"x\"\"]\"\n" +
+		",2,,,,,\n" +
+		"c,-3,,,,,\n" +
+		"d,4,x,,,,\n" +
+		"invalid,5,,,,,\n" +
+		"f,6,-7\n"
+	var errors bytes.Buffer
+	result, err := Import(evaler, strings.NewReader(input), ImportOpts{Space: "test",
+		Format: "csv", BatchSize: 2, OnConflict: "fail"}, &errors)
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: 3, Rejected: 3}, result)
+	assert.Equal(t, 2, evaler.batches)
+
+	price, err := decimal.NewDecimalFromString("10.25")
+	require.NoError(t, err)
+	created, err := datetime.NewDatetime(time.Date(2024, 4, 15, 14, 46, 30, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]interface{}{
+		"1": {uint64(1), "a", 1.5, uuid.MustParse("8c275dcd-1479-4def-ae31-6e7a763fd84c"),
+			price, created, []interface{}{int64(1), "x"}},
+		"2": {uint64(2), ""},
+		"6": {uint64(6), "f", int64(-7)},
+	}, evaler.tuples)
+	assert.Equal(t, strings.Join([]string{
+		`{"line":4,"row":["c","-3","","","","",""],` +
+			`"error":"field \"id\": invalid unsigned value \"-3\""}`,
+		`{"line":5,"row":["d","4","x","","","",""],` +
+			`"error":"field \"score\": invalid number value \"x\""}`,
+		`{"line":6,"row":["invalid","5","","","","",""],"error":"invalid name"}`,
+	}, "\n")+"\n", errors.String())
+}
+
+func TestImport_fields(t *testing.T) {
+	evaler := newImportEvaler(
+		[]interface{}{"id", "unsigned", false},
+		[]interface{}{"name", "string", false},
+		[]interface{}{"active", "boolean", true},
+	)
+	input := "1,skipped,a,true\n2,skipped,b,false\n"
+	var errors bytes.Buffer
+	result, err := Import(evaler, strings.NewReader(input), ImportOpts{Space: "test",
+		Format: "csv", Fields: []string{"1:id", "3:name", "4:3"}, NoHeader: true,
+		OnConflict: "fail"}, &errors)
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: 2}, result)
+	assert.Equal(t, map[string][]interface{}{
+		"1": {uint64(1), "a", true},
+		"2": {uint64(2), "b", false},
+	}, evaler.tuples)
+	assert.Empty(t, errors.String())
+
+	input = "key,title\n3,c\n"
+	result, err = Import(evaler, strings.NewReader(input), ImportOpts{Space: "test",
+		Format: "csv", Fields: []string{"key:id", "title:name"}, OnConflict: "fail"}, &errors)
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: 1}, result)
+	assert.Equal(t, []interface{}{uint64(3), "c"}, evaler.tuples["3"])
+}
+
+func TestImport_jsonl(t *testing.T) {
+	evaler := newImportEvaler(
+		[]interface{}{"id", "unsigned", false},
+		[]interface{}{"name", "string", false},
+		[]interface{}{"meta", "map", true},
+	)
+	evaler.tuples["1"] = []interface{}{uint64(1), "old"}
+	input := `{"id": 1, "name": "a"}` + "\n" +
+		`{"id": 2, "name": "b", "meta": {"n": 1}}` + "\n\n" +
+		`[3, "c"]` + "\n" +
+		`{"id": 4, "name": 5}` + "\n" +
+		`{"id": 5, "title": "e"}` + "\n" +
+		`{"id": 6` + "\n" +
+		`7` + "\n"
+	var errors bytes.Buffer
+	result, err := Import(evaler, strings.NewReader(input), ImportOpts{Space: "test",
+		Format: "jsonl", OnConflict: "skip"}, &errors)
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{Imported: 2, Skipped: 1, Rejected: 4}, result)
+	assert.Equal(t, map[string][]interface{}{
+		"1": {uint64(1), "old"},
+		"2": {uint64(2), "b", map[string]interface{}{"n": int64(1)}},
+		"3": {uint64(3), "c"},
+	}, evaler.tuples)
+	assert.Equal(t, strings.Join([]string{
+		`{"line":5,"row":{"id":4,"name":5},` +
+			`"error":"field \"name\": a string is expected, got 5"}`,
+		`{"line":6,"row":{"id":5,"title":"e"},` +
+			`"error":"column \"title\" is not in the format of space \"test\""}`,
+		`{"line":7,"row":"{\"id\": 6","error":"invalid JSON: unexpected EOF"}`,
+		`{"line":8,"row":7,"error":"an object or an array is expected"}`,
+	}, "\n")+"\n", errors.String())
+}
+
+func TestImport_failReportsRejected(t *testing.T) {
+	evaler := newImportEvaler(
+		[]interface{}{"id", "unsigned", false},
+		[]interface{}{"name", "string", false},
+	)
+	input := "id,name\n1,a\n2,invalid\n1,c\n"
+	var errors bytes.Buffer
+	result, err := Import(evaler, strings.NewReader(input), ImportOpts{Space: "test",
+		Format: "csv", BatchSize: 3, OnConflict: "fail"}, &errors)
+	assert.EqualError(t, err, "line 4: Duplicate key exists")
+	assert.Equal(t, ImportResult{Rejected: 1}, result)
+	assert.Equal(t, `{"line":3,"row":["2","invalid"],"error":"invalid name"}`+"\n",
+		errors.String())
+}
+
+func TestImport_onConflict(t *testing.T) {
+	input := "id,name\n1,a\n2,b\n1,c\n"
+	cases := []struct {
+		onConflict string
+		result     ImportResult
+		name       string
+		err        string
+	}{
+		{"skip", ImportResult{Imported: 2, Skipped: 1}, "a", ""},
+		{"replace", ImportResult{Imported: 3}, "c", ""},
+		{"fail", ImportResult{Imported: 2}, "a", "line 4: Duplicate key exists"},
+	}
+	for _, c := range cases {
+		t.Run(c.onConflict, func(t *testing.T) {
+			evaler := newImportEvaler(
+				[]interface{}{"id", "unsigned", false},
+				[]interface{}{"name", "string", false},
+			)
+			var errors bytes.Buffer
+			result, err := Import(evaler, strings.NewReader(input), ImportOpts{Space: "test",
+				Format: "csv", BatchSize: 2, OnConflict: c.onConflict}, &errors)
+			if c.err != "" {
+				assert.EqualError(t, err, c.err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, c.result, result)
+			assert.Equal(t, c.name, evaler.tuples["1"][1])
+		})
+	}
+}
+
+func TestImport_errors(t *testing.T) {
+	evaler := newImportEvaler([]interface{}{"id", "unsigned", false})
+	cases := []struct {
+		opts  ImportOpts
+		input string
+		err   string
+	}{
+		{ImportOpts{Format: "csv", OnConflict: "ignore"}, "",
+			`unknown conflict action "ignore": skip, replace or fail is expected`},
+		{ImportOpts{Format: "xml", OnConflict: "fail"}, "",
+			`unknown import format "xml": csv or jsonl is expected`},
+		{ImportOpts{Format: "csv", OnConflict: "fail", Fields: []string{"id"}}, "",
+			`invalid field mapping "id": <column>:<field> is expected`},
+		{ImportOpts{Format: "csv", OnConflict: "fail", Fields: []string{"1:name"}}, "",
+			`field "name" is not found in space "test"`},
+		{ImportOpts{Format: "csv", OnConflict: "fail"}, "id,name\n",
+			`column "name" is not in the format of space "test"`},
+	}
+	for _, c := range cases {
+		c.opts.Space = "test"
+		_, err := Import(evaler, strings.NewReader(c.input), c.opts, &bytes.Buffer{})
+		assert.EqualError(t, err, c.err)
+	}
+
+	_, err := Import(evaler, strings.NewReader("id\n1\"\n"), ImportOpts{Space: "test",
+		Format: "csv", OnConflict: "fail"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to read CSV: parse error on line 2")
+}
@@ -0,0 +1,274 @@
+package checkpoint
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/mattn/go-isatty"
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// readFile calls the callback for each accepted row of the file.
+func readFile(path string, opts Opts, cb func(row xlog.Row) error) error {
+	_, err := readFileMeta(path, opts, cb)
+	return err
+}
+
+// readFileMeta calls the callback for each accepted row of the file and
+// returns the meta of the file.
+func readFileMeta(path string, opts Opts, cb func(row xlog.Row) error) (xlog.Meta, error) {
+	return scanFile(path, opts, func(row xlog.Row, _ int64) error {
+		return cb(row)
+	})
+}
+
+// scanFile calls the callback for each accepted row of the file with a number
+// of bytes of the file read so far and returns the meta of the file.
+func scanFile(path string, opts Opts, cb func(row xlog.Row, offset int64) error) (xlog.Meta,
+	error) {
+	reader, err := xlog.Open(path)
+	if err != nil {
+		return xlog.Meta{}, err
+	}
+	defer reader.Close()
+
+	meta := reader.Meta()
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return meta, nil
+		}
+		if err != nil {
+			return meta, fmt.Errorf("failed to read %q: %w", path, err)
+		}
+		if opts.Schema != nil {
+			opts.Schema.applyRow(row)
+		}
+		accepted, stop := filterRow(opts, row)
+		if stop {
+			return meta, nil
+		}
+		if !accepted {
+			continue
+		}
+		if err := cb(row, reader.Offset()); err != nil {
+			return meta, err
+		}
+	}
+}
+
+// CatNative prints the contents of .snap/.xlog files without a Tarantool
+// executable. Returns an error if such occur during reading files.
+func CatNative(files []string, opts Opts) error {
+	if opts.Stats {
+		return CatStats(files, opts)
+	}
+	if opts.Schema == nil && (opts.Decode || opts.Key != nil) {
+		opts.Schema = NewSchema()
+	}
+	var schema *Schema
+	if opts.Decode {
+		schema = opts.Schema
+	}
+
+	var write func(io.Writer, xlog.Row) error
+	switch opts.Format {
+	case "yaml":
+		write = func(w io.Writer, row xlog.Row) error {
+			return writeYaml(w, makeRecord(row, schema))
+		}
+	case "json":
+		write = func(w io.Writer, row xlog.Row) error {
+			return writeJson(w, makeRecord(row, schema))
+		}
+	case "lua":
+		if opts.Decode {
+			return fmt.Errorf("tuples decoding is not supported for lua format")
+		}
+		write = writeLua
+	default:
+		return fmt.Errorf("unknown format %q", opts.Format)
+	}
+	if err := ValidateTypes(opts.Type); err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	for _, file := range files {
+		out.Flush()
+		fmt.Fprintf(os.Stderr, "• Result of cat: the file \"%s\" is processed below •\n", file)
+		printed := false
+		err := readFile(file, opts, func(row xlog.Row) error {
+			printed = true
+			return write(out, row)
+		})
+		if err != nil {
+			return fmt.Errorf("result of cat: %w", err)
+		}
+		if opts.Format == "yaml" && printed {
+			fmt.Fprint(out, "...\n\n")
+		}
+	}
+	return nil
+}
+
+// playRow sends the row request to the space. Field numbers of update
+// operations are converted to 0-based ones expected by IPROTO requests
+// without an index base.
+func playRow(conn connector.DataManipulator, space interface{}, row xlog.Row) error {
+	var opts connector.RequestOpts
+	var err error
+	switch row.Header.Type {
+	case xlog.InsertType:
+		_, err = conn.Insert(space, row.Body[xlog.TupleKey], opts)
+	case xlog.ReplaceType:
+		_, err = conn.Replace(space, row.Body[xlog.TupleKey], opts)
+	case xlog.DeleteType:
+		_, err = conn.Delete(space, row.IndexID(), row.Body[xlog.KeyKey], opts)
+	case xlog.UpdateType:
+		ops := zeroBasedOps(row.Body[xlog.TupleKey], row.IndexBase())
+		_, err = conn.Update(space, row.IndexID(), row.Body[xlog.KeyKey], ops, opts)
+	case xlog.UpsertType:
+		ops := zeroBasedOps(row.Body[xlog.OperationsKey], row.IndexBase())
+		_, err = conn.Upsert(space, row.Body[xlog.TupleKey], ops, opts)
+	default:
+		return fmt.Errorf("unsupported request type %s", row.Header.Type)
+	}
+	return err
+}
+
+// zeroBasedOps returns update operations with positive field numbers
+// converted from the index base to 0-based ones. Negative field numbers and
+// field names or paths do not depend on the base.
+func zeroBasedOps(ops interface{}, base uint64) interface{} {
+	list, ok := ops.([]interface{})
+	if !ok || base == 0 {
+		return ops
+	}
+	converted := make([]interface{}, 0, len(list))
+	for _, op := range list {
+		if args, ok := op.([]interface{}); ok && len(args) > 1 {
+			if field, ok := args[1].(uint64); ok && field >= base {
+				args = append([]interface{}{}, args...)
+				args[1] = field - base
+				op = args
+			}
+		}
+		converted = append(converted, op)
+	}
+	return converted
+}
+
+// PlayNative is playing the contents of .snap/.xlog files to another
+// Tarantool instance without a Tarantool executable.
+// Returns an error if such occur during playing.
+func PlayNative(connOpts connector.ConnectOpts, files []string, opts Opts) (err error) {
+	applied := xlog.VClock{}
+	if opts.ResumeFile != "" {
+		if applied, err = loadResumeFile(opts.ResumeFile); err != nil {
+			return fmt.Errorf("result of play: %w", err)
+		}
+	}
+
+	if opts.DryRun {
+		counts, err := countRows(files, opts, applied)
+		if err != nil {
+			return fmt.Errorf("result of play: %w", err)
+		}
+		writeDryRun(os.Stdout, opts, counts)
+		return nil
+	}
+
+	// The schema is loaded to resolve names of target spaces.
+	connOpts.LoadSchema = true
+	conn, err := connector.Connect(connOpts)
+	if err != nil {
+		return fmt.Errorf("result of play: no connection to the host %q: %w",
+			connOpts.Address, err)
+	}
+	defer conn.Close()
+	manipulator, ok := conn.(connector.DataManipulator)
+	if !ok {
+		return fmt.Errorf("result of play: the host %q does not accept data requests,"+
+			" a binary port is required", connOpts.Address)
+	}
+
+	// The resume file is saved periodically, so it is saved on an interruption
+	// and on an error to avoid playing applied records again.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// The saver has its own vector clock, so rows are skipped only according
+	// to the loaded resume file but not to already played rows.
+	saver := newResumeSaver(opts.ResumeFile, applied)
+	defer func() {
+		if saveErr := saver.save(); saveErr != nil {
+			err = errors.Join(err, fmt.Errorf("result of play: %w", saveErr))
+		}
+	}()
+
+	if err := playFiles(ctx, manipulator, files, opts, applied, saver); err != nil {
+		return fmt.Errorf("result of play: %w", err)
+	}
+	fmt.Println("\n• Play result: completed successfully •")
+	return nil
+}
+
+// playFiles plays rows of the files which are not applied according to the
+// vector clock loaded from the resume file. Played rows are passed to the
+// saver.
+func playFiles(ctx context.Context, conn connector.DataManipulator, files []string,
+	opts Opts, applied xlog.VClock, saver *resumeSaver) error {
+	// The progress is estimated by sizes of the files to avoid an extra pass
+	// over the files.
+	var bar *progressBar
+	sizes := fileSizes(files)
+	if isatty.IsTerminal(os.Stderr.Fd()) {
+		total := int64(0)
+		for _, size := range sizes {
+			total += size
+		}
+		bar = newProgressBar(os.Stderr, int(total))
+	}
+	limiter := newRateLimiter(opts.Rate)
+	for i, file := range files {
+		fmt.Printf("• Play is processing file \"%s\" •\n", file)
+		var fileOffset int64
+		_, err := scanFile(file, opts, func(row xlog.Row, offset int64) error {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("interrupted")
+			}
+			if bar != nil {
+				bar.add(int(offset - fileOffset))
+				fileOffset = offset
+			}
+			spaceID, ok := row.SpaceID()
+			if !ok || isApplied(applied, row) {
+				return nil
+			}
+			limiter.wait()
+			if err := playRow(conn, targetSpace(opts, spaceID), row); err != nil {
+				return fmt.Errorf("lsn %d: %w", row.Header.LSN, err)
+			}
+			return saver.apply(row)
+		})
+		if bar != nil {
+			if rest := sizes[i] - fileOffset; rest > 0 {
+				bar.add(int(rest))
+			}
+			bar.clear()
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("• Done with file \"%s\" •\n", file)
+	}
+	return nil
+}
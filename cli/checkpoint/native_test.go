@@ -0,0 +1,238 @@
+package checkpoint
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/connector"
+)
+
+const testXlog = "xlog/testdata/test.xlog"
+
+var defaultOpts = Opts{To: math.MaxUint64}
+
+func makeRow(lsn, replicaID uint64, spaceID interface{}) xlog.Row {
+	row := xlog.Row{
+		Header: xlog.Header{Type: xlog.InsertType, LSN: lsn, TSN: lsn, ReplicaID: replicaID},
+	}
+	if spaceID != nil {
+		row.Body = map[uint64]interface{}{xlog.SpaceIDKey: spaceID}
+	}
+	return row
+}
+
+func TestFilterRow(t *testing.T) {
+	cases := []struct {
+		name     string
+		opts     Opts
+		row      xlog.Row
+		accepted bool
+		stop     bool
+	}{
+		{"user space", defaultOpts, makeRow(1, 1, uint64(512)), true, false},
+		{"system space", defaultOpts, makeRow(1, 1, uint64(511)), false, false},
+		{"no space", defaultOpts, makeRow(1, 1, nil), true, false},
+		{
+			"show system",
+			Opts{To: math.MaxUint64, ShowSystem: true},
+			makeRow(1, 1, uint64(280)),
+			true, false,
+		},
+		{"from", Opts{From: 2, To: 5}, makeRow(1, 1, uint64(512)), false, false},
+		{"to", Opts{From: 2, To: 5}, makeRow(5, 1, uint64(512)), false, false},
+		{"in range", Opts{From: 2, To: 5}, makeRow(2, 1, uint64(512)), true, false},
+		{"space", Opts{To: 5, Space: []int{280}}, makeRow(2, 1, uint64(280)), true, false},
+		{"other space", Opts{To: 5, Space: []int{280}}, makeRow(2, 1, uint64(512)), false, false},
+		{"space without id", Opts{To: 5, Space: []int{280}}, makeRow(2, 1, nil), false, false},
+		{"replica", Opts{To: 5, Replica: []int{1, 2}}, makeRow(2, 2, nil), true, false},
+		{"other replica", Opts{To: 5, Replica: []int{1, 2}}, makeRow(2, 3, nil), false, false},
+		{"replica to", Opts{To: 5, Replica: []int{1, 2}}, makeRow(5, 2, nil), false, false},
+		{"single replica to", Opts{To: 5, Replica: []int{2}}, makeRow(5, 2, nil), false, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			accepted, stop := filterRow(c.opts, c.row)
+			assert.Equal(t, c.accepted, accepted)
+			assert.Equal(t, c.stop, stop)
+		})
+	}
+}
+
+func readTestRows(t *testing.T, opts Opts,
+	write func(io.Writer, xlog.Row) error) string {
+	t.Helper()
+	var buf bytes.Buffer
+	err := readFile(testXlog, opts, func(row xlog.Row) error {
+		return write(&buf, row)
+	})
+	require.NoError(t, err)
+	return buf.String()
+}
+
+func TestWriteYaml(t *testing.T) {
+	output := readTestRows(t, Opts{To: math.MaxUint64, ShowSystem: true},
+		func(w io.Writer, row xlog.Row) error {
+			return writeYaml(w, makeRecord(row, nil))
+		})
+	assert.Equal(t, `---
+HEADER:
+  lsn: 1
+  type: UPDATE
+  replica_id: 1
+  timestamp: 1.650033990995323e+09
+BODY:
+  space_id: 272
+  index_base: 1
+  key:
+  - max_id
+  tuple:
+  - - +
+    - 2
+    - 1
+---
+HEADER:
+  lsn: 2
+  type: INSERT
+  replica_id: 1
+  timestamp: 1.6500339909969788e+09
+BODY:
+  space_id: 280
+  tuple:
+  - 512
+  - 1
+  - MY_TEST_SPACE
+  - memtx
+  - 0
+  - {}
+  - []
+`, output)
+}
+
+func TestWriteJson(t *testing.T) {
+	output := readTestRows(t, Opts{To: math.MaxUint64, ShowSystem: true, From: 2},
+		func(w io.Writer, row xlog.Row) error {
+			return writeJson(w, makeRecord(row, nil))
+		})
+	assert.Equal(t, `{"HEADER":{"lsn":2,"type":"INSERT","replica_id":1,`+
+		`"timestamp":1650033990.9969788},"BODY":{"space_id":280,`+
+		`"tuple":[512,1,"MY_TEST_SPACE","memtx",0,{},[]]}}`+"\n", output)
+}
+
+func TestWriteLua(t *testing.T) {
+	output := readTestRows(t, Opts{To: math.MaxUint64, ShowSystem: true}, writeLua)
+	assert.Equal(t, `box.space[272]:update({[1] = '\x6d\x61\x78\x5f\x69\x64'}, `+
+		`{[1] = {[1] = '\x2b', [2] = 2, [3] = 1}})`+"\n"+
+		`box.space[280]:insert({[1] = 512, [2] = 1, `+
+		`[3] = '\x4d\x59\x5f\x54\x45\x53\x54\x5f\x53\x50\x41\x43\x45', `+
+		`[4] = '\x6d\x65\x6d\x74\x78', [5] = 0, [6] = {}, [7] = {}})`+"\n", output)
+
+	var buf bytes.Buffer
+	row := makeRow(1, 1, uint64(512))
+	row.Header.Type = xlog.UpsertType
+	row.Body[xlog.TupleKey] = []interface{}{uint64(1), 1.5, nil, true}
+	row.Body[xlog.OperationsKey] = []interface{}{map[interface{}]interface{}{"b": int64(-1),
+		"a": []byte("x")}}
+	require.NoError(t, writeLua(&buf, row))
+	assert.Equal(t, `box.space[512]:upsert({[1] = 1, [2] = 1.5, [3] = nil, [4] = true}, `+
+		`{[1] = {['\x61'] = '\x78', ['\x62'] = -1}})`+"\n", buf.String())
+}
+
+func TestReadFile_not_exist(t *testing.T) {
+	err := readFile("not_exist.xlog", defaultOpts, func(xlog.Row) error { return nil })
+	assert.ErrorContains(t, err, "no such file or directory")
+}
+
+type manipulatorMock struct {
+	requests [][]interface{}
+	err      error
+}
+
+func (m *manipulatorMock) add(req ...interface{}) ([]interface{}, error) {
+	m.requests = append(m.requests, req)
+	return nil, m.err
+}
+
+func (m *manipulatorMock) Insert(space, tuple interface{},
+	_ connector.RequestOpts) ([]interface{}, error) {
+	return m.add("insert", space, tuple)
+}
+
+func (m *manipulatorMock) Replace(space, tuple interface{},
+	_ connector.RequestOpts) ([]interface{}, error) {
+	return m.add("replace", space, tuple)
+}
+
+func (m *manipulatorMock) Delete(space, index, key interface{},
+	_ connector.RequestOpts) ([]interface{}, error) {
+	return m.add("delete", space, index, key)
+}
+
+func (m *manipulatorMock) Update(space, index, key, ops interface{},
+	_ connector.RequestOpts) ([]interface{}, error) {
+	return m.add("update", space, index, key, ops)
+}
+
+func (m *manipulatorMock) Upsert(space, tuple, ops interface{},
+	_ connector.RequestOpts) ([]interface{}, error) {
+	return m.add("upsert", space, tuple, ops)
+}
+
+func TestPlayRow(t *testing.T) {
+	tuple := []interface{}{uint64(1), "a"}
+	key := []interface{}{uint64(1)}
+	ops := []interface{}{[]interface{}{"=", uint64(2), "b"}, []interface{}{"+", int64(-1), 1}}
+	zeroOps := []interface{}{[]interface{}{"=", uint64(1), "b"}, []interface{}{"+", int64(-1), 1}}
+	pathOps := []interface{}{[]interface{}{"=", "name", "b"}}
+
+	cases := []struct {
+		name     string
+		typ      xlog.RequestType
+		body     map[uint64]interface{}
+		expected []interface{}
+	}{
+		{"insert", xlog.InsertType, map[uint64]interface{}{xlog.TupleKey: tuple},
+			[]interface{}{"insert", "dst", tuple}},
+		{"replace", xlog.ReplaceType, map[uint64]interface{}{xlog.TupleKey: tuple},
+			[]interface{}{"replace", "dst", tuple}},
+		{"delete", xlog.DeleteType,
+			map[uint64]interface{}{xlog.KeyKey: key, xlog.IndexIDKey: uint64(1)},
+			[]interface{}{"delete", "dst", uint64(1), key}},
+		{"update 1-based", xlog.UpdateType, map[uint64]interface{}{
+			xlog.KeyKey: key, xlog.TupleKey: ops, xlog.IndexBaseKey: uint64(1)},
+			[]interface{}{"update", "dst", uint64(0), key, zeroOps}},
+		{"update 0-based", xlog.UpdateType,
+			map[uint64]interface{}{xlog.KeyKey: key, xlog.TupleKey: zeroOps},
+			[]interface{}{"update", "dst", uint64(0), key, zeroOps}},
+		{"upsert paths", xlog.UpsertType, map[uint64]interface{}{
+			xlog.TupleKey: tuple, xlog.OperationsKey: pathOps, xlog.IndexBaseKey: uint64(1)},
+			[]interface{}{"upsert", "dst", tuple, pathOps}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock := &manipulatorMock{}
+			row := xlog.Row{Header: xlog.Header{Type: c.typ}, Body: c.body}
+			require.NoError(t, playRow(mock, "dst", row))
+			assert.Equal(t, [][]interface{}{c.expected}, mock.requests)
+		})
+	}
+
+	// The source operations are not modified.
+	assert.Equal(t, uint64(2), ops[0].([]interface{})[1])
+
+	mock := &manipulatorMock{err: errors.New("access denied")}
+	row := xlog.Row{Header: xlog.Header{Type: xlog.InsertType},
+		Body: map[uint64]interface{}{xlog.TupleKey: tuple}}
+	assert.EqualError(t, playRow(mock, "dst", row), "access denied")
+
+	row.Header.Type = xlog.NopType
+	assert.EqualError(t, playRow(mock, "dst", row), "unsupported request type NOP")
+}
@@ -0,0 +1,288 @@
+package checkpoint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+// progressWidth is a width of the progress bar.
+const progressWidth = 30
+
+// progressInterval is a minimum interval between progress bar renderings.
+const progressInterval = 100 * time.Millisecond
+
+// ParseSpaceMap parses mappings of source spaces to target spaces in the
+// format src:dst. The source is a space identifier, the target is a space
+// identifier or a name.
+func ParseSpaceMap(items []string) (map[uint64]interface{}, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	spaceMap := map[uint64]interface{}{}
+	for _, item := range items {
+		src, dst, found := strings.Cut(item, ":")
+		src, dst = strings.TrimSpace(src), strings.TrimSpace(dst)
+		if !found || src == "" || dst == "" {
+			return nil, fmt.Errorf("invalid space mapping %q: src:dst is expected", item)
+		}
+		srcID, err := strconv.ParseUint(src, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid space mapping %q: the source must be a space id",
+				item)
+		}
+		if _, ok := spaceMap[srcID]; ok {
+			return nil, fmt.Errorf("space %d is mapped more than once", srcID)
+		}
+		if dstID, err := strconv.ParseUint(dst, 10, 64); err == nil {
+			spaceMap[srcID] = dstID
+		} else {
+			spaceMap[srcID] = dst
+		}
+	}
+	return spaceMap, nil
+}
+
+// targetSpace returns a target space identifier or a name of the source space.
+func targetSpace(opts Opts, spaceID uint64) interface{} {
+	if target, ok := opts.SpaceMap[spaceID]; ok {
+		return target
+	}
+	return spaceID
+}
+
+// loadResumeFile loads a vector clock of applied records from the file.
+// An empty vector clock is returned if the file does not exist.
+func loadResumeFile(path string) (xlog.VClock, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return xlog.VClock{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the resume file: %w", err)
+	}
+	vclock, err := xlog.ParseVClock(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the resume file %q: %w", path, err)
+	}
+	return vclock, nil
+}
+
+// saveResumeFile saves a vector clock of applied records to the file. The
+// file is replaced atomically to keep it valid if the process is killed.
+func saveResumeFile(path string, vclock xlog.VClock) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(vclock.String()+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to save the resume file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to save the resume file: %w", err)
+	}
+	return nil
+}
+
+// resumeSaveInterval is a minimum interval between saves of the resume file.
+const resumeSaveInterval = time.Second
+
+// resumeSaver tracks applied records and saves them to the resume file not
+// more often than resumeSaveInterval.
+type resumeSaver struct {
+	// path is a path of the resume file, empty if it is not used.
+	path string
+	// vclock is a vector clock of applied records.
+	vclock xlog.VClock
+	// dirty is true if the vector clock is not saved yet.
+	dirty bool
+	// saved is a time of the last save.
+	saved time.Time
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+}
+
+// newResumeSaver creates a saver of the vector clock to the resume file. The
+// saver updates a copy of the initial vector clock.
+func newResumeSaver(path string, vclock xlog.VClock) *resumeSaver {
+	copied := make(xlog.VClock, len(vclock))
+	for id, lsn := range vclock {
+		copied[id] = lsn
+	}
+	return &resumeSaver{path: path, vclock: copied, saved: time.Now(), now: time.Now}
+}
+
+// apply marks the row as applied and saves the resume file if required.
+func (saver *resumeSaver) apply(row xlog.Row) error {
+	saver.vclock[row.Header.ReplicaID] = row.Header.LSN
+	saver.dirty = true
+	if saver.now().Sub(saver.saved) < resumeSaveInterval {
+		return nil
+	}
+	return saver.save()
+}
+
+// save saves the resume file if there are unsaved applied records.
+func (saver *resumeSaver) save() error {
+	if saver.path == "" || !saver.dirty {
+		return nil
+	}
+	if err := saveResumeFile(saver.path, saver.vclock); err != nil {
+		return err
+	}
+	saver.dirty = false
+	saver.saved = saver.now()
+	return nil
+}
+
+// isApplied returns true if the row is already applied according to the
+// vector clock.
+func isApplied(vclock xlog.VClock, row xlog.Row) bool {
+	lsn, ok := vclock[row.Header.ReplicaID]
+	return ok && row.Header.LSN <= lsn
+}
+
+// countRows counts rows to play per source space.
+func countRows(files []string, opts Opts, applied xlog.VClock) (map[uint64]int, error) {
+	counts := map[uint64]int{}
+	for _, file := range files {
+		err := readFile(file, opts, func(row xlog.Row) error {
+			if spaceID, ok := row.SpaceID(); ok && !isApplied(applied, row) {
+				counts[spaceID]++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// fileSizes returns sizes of the files. The size of a file is zero if it
+// could not be determined.
+func fileSizes(files []string) []int64 {
+	sizes := make([]int64, len(files))
+	for i, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			sizes[i] = info.Size()
+		}
+	}
+	return sizes
+}
+
+// writeDryRun writes counts of rows to play per space.
+func writeDryRun(w io.Writer, opts Opts, counts map[uint64]int) {
+	ids := make([]uint64, 0, len(counts))
+	total := 0
+	for id, count := range counts {
+		ids = append(ids, id)
+		total += count
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	fmt.Fprintln(w, "• Play dry run: records are not sent •")
+	for _, id := range ids {
+		if target, ok := opts.SpaceMap[id]; ok {
+			fmt.Fprintf(w, "   space %d -> %v: %d\n", id, target, counts[id])
+		} else {
+			fmt.Fprintf(w, "   space %d: %d\n", id, counts[id])
+		}
+	}
+	fmt.Fprintf(w, "• Total records: %d •\n", total)
+}
+
+// rateLimiter limits a number of operations per second.
+type rateLimiter struct {
+	// interval is an interval between operations, zero means no limit.
+	interval time.Duration
+	// next is a time of the next operation.
+	next time.Time
+	// sleep is used to wait, replaced in tests.
+	sleep func(time.Duration)
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+}
+
+// newRateLimiter creates a limiter of the rate in operations per second.
+// Zero rate means no limit.
+func newRateLimiter(rate float64) *rateLimiter {
+	limiter := &rateLimiter{sleep: time.Sleep, now: time.Now}
+	if rate > 0 {
+		limiter.interval = time.Duration(float64(time.Second) / rate)
+	}
+	return limiter
+}
+
+// wait waits until the next operation is allowed. The lost time is not
+// compensated with a burst of operations.
+func (limiter *rateLimiter) wait() {
+	if limiter.interval == 0 {
+		return
+	}
+	now := limiter.now()
+	if limiter.next.After(now) {
+		limiter.sleep(limiter.next.Sub(now))
+	} else {
+		limiter.next = now
+	}
+	limiter.next = limiter.next.Add(limiter.interval)
+}
+
+// progressBar renders a progress of playing with ETA. The progress is
+// measured in bytes of the played files.
+type progressBar struct {
+	w        io.Writer
+	total    int
+	done     int
+	start    time.Time
+	rendered time.Time
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+}
+
+// newProgressBar creates a progress bar for the total number of bytes.
+func newProgressBar(w io.Writer, total int) *progressBar {
+	return &progressBar{w: w, total: total, start: time.Now(), now: time.Now}
+}
+
+// add adds done bytes and renders the bar if required.
+func (bar *progressBar) add(done int) {
+	bar.done += done
+	if now := bar.now(); bar.done == bar.total || now.Sub(bar.rendered) >= progressInterval {
+		bar.rendered = now
+		bar.render()
+	}
+}
+
+// render renders the bar in the current line.
+func (bar *progressBar) render() {
+	ratio := 1.0
+	if bar.total > 0 {
+		ratio = float64(bar.done) / float64(bar.total)
+	}
+	filled := int(ratio * progressWidth)
+	line := strings.Repeat("=", filled)
+	if filled < progressWidth {
+		line += ">" + strings.Repeat(" ", progressWidth-filled-1)
+	}
+
+	eta := "--"
+	if bar.done > 0 {
+		elapsed := bar.now().Sub(bar.start)
+		left := time.Duration(float64(elapsed) / float64(bar.done) *
+			float64(bar.total-bar.done))
+		eta = left.Round(time.Second).String()
+	}
+	fmt.Fprintf(bar.w, "\r\033[K[%s] %3.0f%% ETA %s", line, ratio*100, eta)
+}
+
+// clear clears the line of the bar.
+func (bar *progressBar) clear() {
+	fmt.Fprint(bar.w, "\r\033[K")
+}
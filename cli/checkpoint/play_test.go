@@ -0,0 +1,184 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+func TestParseSpaceMap(t *testing.T) {
+	spaceMap, err := ParseSpaceMap(nil)
+	require.NoError(t, err)
+	assert.Nil(t, spaceMap)
+
+	spaceMap, err = ParseSpaceMap([]string{"512:users_v2", " 513 : 600 "})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]interface{}{512: "users_v2", 513: uint64(600)}, spaceMap)
+	assert.Equal(t, "users_v2", targetSpace(Opts{SpaceMap: spaceMap}, 512))
+	assert.Equal(t, uint64(514), targetSpace(Opts{SpaceMap: spaceMap}, 514))
+
+	_, err = ParseSpaceMap([]string{"512"})
+	assert.EqualError(t, err, `invalid space mapping "512": src:dst is expected`)
+	_, err = ParseSpaceMap([]string{"512:"})
+	assert.EqualError(t, err, `invalid space mapping "512:": src:dst is expected`)
+	_, err = ParseSpaceMap([]string{"users:users_v2"})
+	assert.EqualError(t, err,
+		`invalid space mapping "users:users_v2": the source must be a space id`)
+	_, err = ParseSpaceMap([]string{"512:a", "512:b"})
+	assert.EqualError(t, err, "space 512 is mapped more than once")
+}
+
+func TestResumeFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "play.resume")
+
+	vclock, err := loadResumeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{}, vclock)
+
+	require.NoError(t, saveResumeFile(path, xlog.VClock{1: 10, 2: 5}))
+	vclock, err = loadResumeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 10, 2: 5}, vclock)
+	assert.NoFileExists(t, path+".tmp")
+
+	assert.True(t, isApplied(vclock, makeRow(10, 1, nil)))
+	assert.False(t, isApplied(vclock, makeRow(11, 1, nil)))
+	assert.False(t, isApplied(vclock, makeRow(1, 3, nil)))
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0644))
+	_, err = loadResumeFile(path)
+	assert.ErrorContains(t, err, "failed to read the resume file")
+}
+
+func TestCountRows(t *testing.T) {
+	opts := Opts{To: math.MaxUint64, ShowSystem: true}
+	counts, err := countRows([]string{testXlog}, opts, xlog.VClock{})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]int{272: 1, 280: 1}, counts)
+
+	counts, err = countRows([]string{testXlog}, opts, xlog.VClock{1: 1})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint64]int{280: 1}, counts)
+
+	var buf bytes.Buffer
+	opts.SpaceMap = map[uint64]interface{}{280: "my_space"}
+	writeDryRun(&buf, opts, map[uint64]int{272: 1, 280: 2})
+	assert.Equal(t, "• Play dry run: records are not sent •\n"+
+		"   space 272: 1\n"+
+		"   space 280 -> my_space: 2\n"+
+		"• Total records: 3 •\n", buf.String())
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(0, 0)
+	var slept time.Duration
+	limiter := newRateLimiter(10)
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(d time.Duration) {
+		slept += d
+		now = now.Add(d)
+	}
+
+	for i := 0; i < 5; i++ {
+		limiter.wait()
+	}
+	assert.Equal(t, 400*time.Millisecond, slept)
+
+	// The lost time is not compensated.
+	now = now.Add(time.Second)
+	slept = 0
+	limiter.wait()
+	limiter.wait()
+	assert.Equal(t, 100*time.Millisecond, slept)
+
+	limiter = newRateLimiter(0)
+	limiter.sleep = func(time.Duration) { t.Fatal("unexpected sleep") }
+	limiter.wait()
+}
+
+func TestResumeSaver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "play.resume")
+	saver := newResumeSaver(path, xlog.VClock{})
+	now := saver.saved
+	saver.now = func() time.Time { return now }
+
+	// The file is not saved too often.
+	require.NoError(t, saver.apply(makeRow(1, 1, nil)))
+	assert.NoFileExists(t, path)
+
+	now = now.Add(resumeSaveInterval)
+	require.NoError(t, saver.apply(makeRow(2, 1, nil)))
+	vclock, err := loadResumeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 2}, vclock)
+
+	require.NoError(t, saver.apply(makeRow(3, 1, nil)))
+	require.NoError(t, saver.save())
+	vclock, err = loadResumeFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 3}, vclock)
+
+	// Nothing is saved without a path.
+	saver = newResumeSaver("", xlog.VClock{})
+	require.NoError(t, saver.apply(makeRow(1, 1, nil)))
+	require.NoError(t, saver.save())
+}
+
+func TestProgressBar(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 4)
+	now := bar.start
+	bar.now = func() time.Time { return now }
+
+	now = now.Add(2 * time.Second)
+	bar.add(1)
+	assert.Equal(t, "\r\033[K[=======>                      ]  25% ETA 6s", buf.String())
+
+	// The bar is not rendered too often.
+	buf.Reset()
+	bar.add(1)
+	assert.Empty(t, buf.String())
+
+	now = now.Add(2 * time.Second)
+	bar.add(2)
+	assert.Equal(t, "\r\033[K[==============================] 100% ETA 0s", buf.String())
+}
+
+func TestPlayFiles_overlappingLSNs(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{filepath.Join(dir, "first.xlog"), filepath.Join(dir, "second.xlog")}
+	for _, file := range files {
+		writeTestXlog(t, file, xlog.VClock{},
+			[]xlog.Row{makeRawRow(t, 1, 1, true, snapTime)},
+			[]xlog.Row{makeRawRow(t, 2, 2, true, snapTime)})
+	}
+	opts := Opts{To: math.MaxUint64}
+
+	// Rows are not skipped by LSNs of already played rows without a resume
+	// file.
+	mock := &manipulatorMock{}
+	saver := newResumeSaver("", xlog.VClock{})
+	require.NoError(t, playFiles(context.Background(), mock, files, opts, xlog.VClock{}, saver))
+	assert.Len(t, mock.requests, 4)
+	assert.Equal(t, xlog.VClock{1: 2}, saver.vclock)
+
+	// Rows are skipped only by the loaded vector clock.
+	mock = &manipulatorMock{}
+	applied := xlog.VClock{1: 1}
+	saver = newResumeSaver("", applied)
+	require.NoError(t, playFiles(context.Background(), mock, files, opts, applied, saver))
+	assert.Equal(t, [][]interface{}{
+		{"insert", uint64(512), []interface{}{uint64(2)}},
+		{"insert", uint64(512), []interface{}{uint64(2)}},
+	}, mock.requests)
+	assert.Equal(t, xlog.VClock{1: 1}, applied)
+}
@@ -0,0 +1,249 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tarantool/go-tarantool/datetime"
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"gopkg.in/yaml.v2"
+)
+
+// orderedField is a field of an ordered map.
+type orderedField struct {
+	key   interface{}
+	value interface{}
+}
+
+// orderedMap is a map that keeps an order of fields in the output.
+type orderedMap []orderedField
+
+// MarshalYAML implements yaml.Marshaler interface.
+func (m orderedMap) MarshalYAML() (interface{}, error) {
+	slice := make(yaml.MapSlice, 0, len(m))
+	for _, field := range m {
+		slice = append(slice, yaml.MapItem{Key: field.key, Value: field.value})
+	}
+	return slice, nil
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (m orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range m {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(fmt.Sprint(field.key))
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// plainValue converts a decoded msgpack value into a value with ordered maps
+// and extension types represented as strings.
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case []interface{}:
+		array := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			array = append(array, plainValue(item))
+		}
+		return array
+	case map[interface{}]interface{}:
+		keys := make([]interface{}, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		m := make(orderedMap, 0, len(v))
+		for _, key := range keys {
+			m = append(m, orderedField{plainValue(key), plainValue(v[key])})
+		}
+		return m
+	case datetime.Datetime:
+		return v.ToTime().Format(time.RFC3339Nano)
+	case datetime.Interval:
+		return fmt.Sprintf("%+v", v)
+	case fmt.Stringer:
+		return v.String()
+	}
+	return value
+}
+
+// makeRecord makes a record of the row in the same way as Tarantool xlog
+// module does. Tuples and keys are decoded with the schema if it is not nil.
+func makeRecord(row xlog.Row, schema *Schema) orderedMap {
+	header := orderedMap{
+		{"lsn", row.Header.LSN},
+		{"type", row.Header.Type.String()},
+	}
+	if row.Header.ReplicaID != 0 {
+		header = append(header, orderedField{"replica_id", row.Header.ReplicaID})
+	}
+	if row.Header.GroupID != 0 {
+		header = append(header, orderedField{"group_id", row.Header.GroupID})
+	}
+	if row.Header.Sync != 0 {
+		header = append(header, orderedField{"sync", row.Header.Sync})
+	}
+	if row.Header.Timestamp != 0 {
+		header = append(header, orderedField{"timestamp", row.Header.Timestamp})
+	}
+	if row.Header.TSN != row.Header.LSN {
+		header = append(header, orderedField{"tsn", row.Header.TSN})
+	}
+	if row.Header.Flags&xlog.FlagCommit != 0 && row.Header.TSN != row.Header.LSN {
+		header = append(header, orderedField{"commit", true})
+	}
+
+	record := orderedMap{{"HEADER", header}}
+	if row.Body != nil {
+		keys := make([]uint64, 0, len(row.Body))
+		for key := range row.Body {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		body := make(orderedMap, 0, len(keys))
+		for _, key := range keys {
+			body = append(body, orderedField{xlog.BodyKeyName(key), bodyValue(row, key, schema)})
+		}
+		record = append(record, orderedField{"BODY", body})
+	}
+	return record
+}
+
+// bodyValue returns a value of the row body. A tuple and a primary key are
+// decoded with the schema if it is not nil.
+func bodyValue(row xlog.Row, key uint64, schema *Schema) interface{} {
+	value := row.Body[key]
+	spaceID, ok := row.SpaceID()
+	if schema == nil || !ok {
+		return plainValue(value)
+	}
+	switch row.Header.Type {
+	case xlog.InsertType, xlog.ReplaceType, xlog.UpsertType:
+		if key == xlog.TupleKey {
+			return schema.decodeTuple(spaceID, value)
+		}
+	case xlog.DeleteType, xlog.UpdateType:
+		indexID, _ := toUint64(row.Body[xlog.IndexIDKey])
+		if key == xlog.KeyKey && indexID == 0 {
+			return schema.decodeKey(spaceID, value)
+		}
+	}
+	return plainValue(value)
+}
+
+// writeYaml writes the record as a YAML document without the end marker.
+func writeYaml(w io.Writer, record orderedMap) error {
+	data, err := yaml.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "---\n%s", data)
+	return err
+}
+
+// writeJson writes the record as a JSON line.
+func writeJson(w io.Writer, record orderedMap) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// writeLuaValue writes the value as a Lua value. Strings are written as
+// escaped byte sequences.
+func writeLuaValue(sb *strings.Builder, value interface{}) {
+	switch v := value.(type) {
+	case nil:
+		sb.WriteString("nil")
+	case string:
+		sb.WriteByte('\'')
+		for i := 0; i < len(v); i++ {
+			fmt.Fprintf(sb, "\\x%02x", v[i])
+		}
+		sb.WriteByte('\'')
+	case float32:
+		sb.WriteString(strconv.FormatFloat(float64(v), 'g', 14, 32))
+	case float64:
+		sb.WriteString(strconv.FormatFloat(v, 'g', 14, 64))
+	case []interface{}:
+		sb.WriteByte('{')
+		for i, item := range v {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			fmt.Fprintf(sb, "[%d] = ", i+1)
+			writeLuaValue(sb, item)
+		}
+		sb.WriteByte('}')
+	case orderedMap:
+		sb.WriteByte('{')
+		for i, field := range v {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteByte('[')
+			writeLuaValue(sb, field.key)
+			sb.WriteString("] = ")
+			writeLuaValue(sb, field.value)
+		}
+		sb.WriteByte('}')
+	default:
+		fmt.Fprint(sb, v)
+	}
+}
+
+// writeLua writes the row as a Lua request to a space. Rows without a space
+// are skipped.
+func writeLua(w io.Writer, row xlog.Row) error {
+	spaceID, ok := row.SpaceID()
+	if !ok || row.Header.Type == xlog.NopType {
+		return nil
+	}
+	var sb strings.Builder
+	op := strings.ToLower(row.Header.Type.String())
+	fmt.Fprintf(&sb, "box.space[%d]:%s(", spaceID, op)
+	switch row.Header.Type {
+	case xlog.InsertType, xlog.ReplaceType:
+		writeLuaValue(&sb, plainValue(row.Body[xlog.TupleKey]))
+	case xlog.DeleteType:
+		writeLuaValue(&sb, plainValue(row.Body[xlog.KeyKey]))
+	case xlog.UpdateType:
+		writeLuaValue(&sb, plainValue(row.Body[xlog.KeyKey]))
+		sb.WriteString(", ")
+		writeLuaValue(&sb, plainValue(row.Body[xlog.TupleKey]))
+	case xlog.UpsertType:
+		writeLuaValue(&sb, plainValue(row.Body[xlog.TupleKey]))
+		sb.WriteString(", ")
+		writeLuaValue(&sb, plainValue(row.Body[xlog.OperationsKey]))
+	}
+	sb.WriteString(")\n")
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,320 @@
+package checkpoint
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/util"
+)
+
+// RecoverOpts contains options of a point-in-time recovery.
+type RecoverOpts struct {
+	// SrcDir is a directory with source .snap/.xlog files.
+	SrcDir string
+	// DstDir is a directory to write the recovered files to.
+	DstDir string
+	// ToVClock limits the recovered rows by LSNs of replicas, inclusive.
+	// Replicas missing in the vector clock are not limited.
+	ToVClock xlog.VClock
+	// ToTime limits the recovered rows by the time, exclusive.
+	ToTime time.Time
+}
+
+// RecoverResult contains a result of a point-in-time recovery.
+type RecoverResult struct {
+	// Snapshot is a path of the used snapshot.
+	Snapshot string
+	// Xlogs contains paths of the used xlogs.
+	Xlogs []string
+	// VClock is a vector clock of the recovered state.
+	VClock xlog.VClock
+}
+
+// checkpointFile is a .snap/.xlog file with its meta information.
+type checkpointFile struct {
+	path string
+	meta xlog.Meta
+}
+
+// ParseVClockTarget parses a vector clock of a recovery target in the
+// format {1: 10, 2: 5} or 1:10,2:5.
+func ParseVClockTarget(str string) (xlog.VClock, error) {
+	str = strings.TrimSpace(str)
+	if !strings.HasPrefix(str, "{") {
+		str = "{" + str + "}"
+	}
+	vclock, err := xlog.ParseVClock(str)
+	if err != nil {
+		return nil, err
+	}
+	if len(vclock) == 0 {
+		return nil, fmt.Errorf("the target vclock is empty")
+	}
+	return vclock, nil
+}
+
+// listFiles returns files of the directory with the extension sorted by
+// their vector clock signatures.
+func listFiles(dir, ext string) ([]checkpointFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]checkpointFile, 0, len(paths))
+	for _, path := range paths {
+		reader, err := xlog.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, checkpointFile{path, reader.Meta()})
+		reader.Close()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].meta.VClock.Signature() < files[j].meta.VClock.Signature()
+	})
+	return files, nil
+}
+
+// followingXlogs returns the xlogs with rows after the snapshot. The first
+// of them is the newest xlog with the vector clock not newer than the
+// snapshot one.
+func followingXlogs(xlogs []checkpointFile, snap checkpointFile) []checkpointFile {
+	first := 0
+	for i, file := range xlogs {
+		if file.meta.VClock.Signature() <= snap.meta.VClock.Signature() {
+			first = i
+		}
+	}
+	return xlogs[first:]
+}
+
+// vclockBefore returns true if the vector clock does not exceed the target.
+func vclockBefore(vclock, target xlog.VClock) bool {
+	for id, lsn := range target {
+		if vclock[id] > lsn {
+			return false
+		}
+	}
+	return true
+}
+
+// snapshotTime returns a time of the snapshot: a timestamp of its first row
+// or a modification time of the file.
+func snapshotTime(path string) (time.Time, error) {
+	reader, err := xlog.Open(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer reader.Close()
+	row, err := reader.Next()
+	if err != nil && err != io.EOF {
+		return time.Time{}, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	if err == nil && row.Header.Timestamp != 0 {
+		sec, frac := math.Modf(row.Header.Timestamp)
+		return time.Unix(int64(sec), int64(frac*1e9)), nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// exceedsTarget returns true if the row is after the recovery target.
+func exceedsTarget(opts RecoverOpts, row xlog.Row) bool {
+	if lsn, ok := opts.ToVClock[row.Header.ReplicaID]; ok && row.Header.LSN > lsn {
+		return true
+	}
+	if opts.ToTime.IsZero() || row.Header.Timestamp == 0 {
+		return false
+	}
+	sec, frac := math.Modf(row.Header.Timestamp)
+	return !time.Unix(int64(sec), int64(frac*1e9)).Before(opts.ToTime)
+}
+
+// findSnapshot returns the newest snapshot at or before the target.
+func findSnapshot(opts RecoverOpts, snaps []checkpointFile) (checkpointFile, error) {
+	for i := len(snaps) - 1; i >= 0; i-- {
+		snap := snaps[i]
+		if opts.ToVClock != nil && !vclockBefore(snap.meta.VClock, opts.ToVClock) {
+			continue
+		}
+		if !opts.ToTime.IsZero() {
+			snapTime, err := snapshotTime(snap.path)
+			if err != nil {
+				return checkpointFile{}, err
+			}
+			if !snapTime.Before(opts.ToTime) {
+				continue
+			}
+		}
+		return snap, nil
+	}
+	return checkpointFile{}, fmt.Errorf("no snapshot at or before the target in %q",
+		opts.SrcDir)
+}
+
+// scanXlog returns a number of whole transactions of the xlog before the
+// target and true if the target is reached in the file. The vector clock is
+// updated with rows of the transactions.
+func scanXlog(opts RecoverOpts, path string, vclock xlog.VClock) (int, bool, error) {
+	reader, err := xlog.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer reader.Close()
+
+	txCount := 0
+	var tx []xlog.Row
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return txCount, false, nil
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to read %q: %w", path, err)
+		}
+		if exceedsTarget(opts, row) {
+			return txCount, true, nil
+		}
+		tx = append(tx, row)
+		if !row.Header.Commit {
+			continue
+		}
+		for _, txRow := range tx {
+			if txRow.Header.LSN > vclock[txRow.Header.ReplicaID] {
+				vclock[txRow.Header.ReplicaID] = txRow.Header.LSN
+			}
+		}
+		txCount++
+		tx = tx[:0]
+	}
+}
+
+// truncateXlog writes a copy of the xlog with the first transactions only.
+func truncateXlog(src, dst string, txCount int) error {
+	reader, err := xlog.Open(src)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	writer, err := xlog.Create(dst, reader.Meta())
+	if err != nil {
+		return err
+	}
+
+	var tx []xlog.Row
+	for txCount > 0 {
+		row, err := reader.Next()
+		if err != nil {
+			writer.Close()
+			return fmt.Errorf("failed to read %q: %w", src, err)
+		}
+		tx = append(tx, row)
+		if !row.Header.Commit {
+			continue
+		}
+		if err := writer.WriteBlock(tx); err != nil {
+			writer.Close()
+			return fmt.Errorf("failed to write %q: %w", dst, err)
+		}
+		txCount--
+		tx = tx[:0]
+	}
+	return writer.Close()
+}
+
+// checkDstDir creates the destination directory if it does not exist and
+// checks that it has no .snap/.xlog files.
+func checkDstDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create %q: %w", dir, err)
+	}
+	for _, ext := range []string{".snap", ".xlog"} {
+		files, err := filepath.Glob(filepath.Join(dir, "*"+ext))
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			return fmt.Errorf("the destination directory %q already contains .snap/.xlog files",
+				dir)
+		}
+	}
+	return nil
+}
+
+// Recover assembles a data directory with the state as of the target from
+// the newest snapshot at or before the target and the following xlogs
+// truncated to the target. Transactions are never split.
+func Recover(opts RecoverOpts) (RecoverResult, error) {
+	var result RecoverResult
+	if opts.ToVClock == nil && opts.ToTime.IsZero() {
+		return result, fmt.Errorf("the recovery target is not specified")
+	}
+	if vylogs, _ := filepath.Glob(filepath.Join(opts.SrcDir, "*.vylog")); len(vylogs) > 0 {
+		log.Warnf("vinyl files are not recovered, only memtx spaces are restored")
+	}
+
+	snaps, err := listFiles(opts.SrcDir, ".snap")
+	if err != nil {
+		return result, err
+	}
+	snap, err := findSnapshot(opts, snaps)
+	if err != nil {
+		return result, err
+	}
+	xlogs, err := listFiles(opts.SrcDir, ".xlog")
+	if err != nil {
+		return result, err
+	}
+	if err := checkDstDir(opts.DstDir); err != nil {
+		return result, err
+	}
+
+	dstPath := func(path string) string {
+		return filepath.Join(opts.DstDir, filepath.Base(path))
+	}
+	if err := util.CopyFilePreserve(snap.path, dstPath(snap.path)); err != nil {
+		return result, fmt.Errorf("failed to copy %q: %w", snap.path, err)
+	}
+	log.Infof("Snapshot %q is copied", snap.path)
+	result.Snapshot = snap.path
+
+	result.VClock = xlog.VClock{}
+	for id, lsn := range snap.meta.VClock {
+		result.VClock[id] = lsn
+	}
+	for _, file := range followingXlogs(xlogs, snap) {
+		txCount, reached, err := scanXlog(opts, file.path, result.VClock)
+		if err != nil {
+			return result, err
+		}
+		if !reached {
+			if err := util.CopyFilePreserve(file.path, dstPath(file.path)); err != nil {
+				return result, fmt.Errorf("failed to copy %q: %w", file.path, err)
+			}
+			log.Infof("Xlog %q is copied", file.path)
+			result.Xlogs = append(result.Xlogs, file.path)
+			continue
+		}
+		if txCount > 0 {
+			if err := truncateXlog(file.path, dstPath(file.path), txCount); err != nil {
+				return result, err
+			}
+			log.Infof("Xlog %q is truncated to the target", file.path)
+			result.Xlogs = append(result.Xlogs, file.path)
+		}
+		return result, nil
+	}
+	log.Warnf("The target is not reached, all records are recovered")
+	return result, nil
+}
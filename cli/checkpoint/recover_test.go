@@ -0,0 +1,180 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/vmihailenco/msgpack.v2"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/util"
+)
+
+// snapTime is a time of rows of the test snapshot.
+var snapTime = time.Unix(1650460370, 0)
+
+// makeRawRow makes an insert row of a transaction with raw data.
+func makeRawRow(t *testing.T, lsn, tsn uint64, commit bool, ts time.Time) xlog.Row {
+	t.Helper()
+	header := map[uint64]interface{}{
+		0x00: uint64(xlog.InsertType),
+		0x02: uint64(1),
+		0x03: lsn,
+		0x04: float64(ts.UnixNano()) / 1e9,
+	}
+	if tsn != lsn || !commit {
+		header[0x08] = tsn
+	}
+	if commit && tsn != lsn {
+		header[0x09] = uint64(xlog.FlagCommit)
+	}
+	raw, err := msgpack.Marshal(header)
+	require.NoError(t, err)
+	body, err := msgpack.Marshal(map[uint64]interface{}{
+		xlog.SpaceIDKey: uint64(512),
+		xlog.TupleKey:   []interface{}{lsn},
+	})
+	require.NoError(t, err)
+	return xlog.Row{Raw: append(raw, body...)}
+}
+
+// writeTestXlog writes an xlog with the transactions.
+func writeTestXlog(t *testing.T, path string, vclock xlog.VClock, txs ...[]xlog.Row) {
+	t.Helper()
+	writer, err := xlog.Create(path, xlog.Meta{
+		Filetype: "XLOG",
+		Fields:   map[string]string{"VClock": vclock.String()},
+	})
+	require.NoError(t, err)
+	for _, tx := range txs {
+		require.NoError(t, writer.WriteBlock(tx))
+	}
+	require.NoError(t, writer.Close())
+}
+
+// readLSNs returns LSNs of rows of the file.
+func readLSNs(t *testing.T, path string) []uint64 {
+	t.Helper()
+	var lsns []uint64
+	require.NoError(t, readFile(path, Opts{To: 100}, func(row xlog.Row) error {
+		lsns = append(lsns, row.Header.LSN)
+		return nil
+	}))
+	return lsns
+}
+
+// makeRecoverSrc makes a source directory with a snapshot and two xlogs:
+// lsn 1, lsn 2-3 in a transaction, lsn 4 and the next xlog with lsn 5.
+func makeRecoverSrc(t *testing.T) string {
+	dir := t.TempDir()
+	require.NoError(t, util.CopyFilePreserve(testSnap,
+		filepath.Join(dir, "00000000000000000000.snap")))
+	writeTestXlog(t, filepath.Join(dir, "00000000000000000000.xlog"), xlog.VClock{},
+		[]xlog.Row{makeRawRow(t, 1, 1, true, snapTime.Add(10*time.Second))},
+		[]xlog.Row{
+			makeRawRow(t, 2, 2, false, snapTime.Add(20*time.Second)),
+			makeRawRow(t, 3, 2, true, snapTime.Add(20*time.Second)),
+		},
+		[]xlog.Row{makeRawRow(t, 4, 4, true, snapTime.Add(30*time.Second))})
+	writeTestXlog(t, filepath.Join(dir, "00000000000000000004.xlog"), xlog.VClock{1: 4},
+		[]xlog.Row{makeRawRow(t, 5, 5, true, snapTime.Add(40*time.Second))})
+	return dir
+}
+
+func TestRecover(t *testing.T) {
+	src := makeRecoverSrc(t)
+	firstXlog := filepath.Join(src, "00000000000000000000.xlog")
+	secondXlog := filepath.Join(src, "00000000000000000004.xlog")
+
+	cases := []struct {
+		name   string
+		opts   RecoverOpts
+		vclock xlog.VClock
+		xlogs  []string
+		lsns   []uint64
+	}{
+		{
+			"lsn in transaction",
+			RecoverOpts{ToVClock: xlog.VClock{1: 2}},
+			xlog.VClock{1: 1}, []string{firstXlog}, []uint64{1},
+		},
+		{
+			"lsn of transaction end",
+			RecoverOpts{ToVClock: xlog.VClock{1: 3}},
+			xlog.VClock{1: 3}, []string{firstXlog}, []uint64{1, 2, 3},
+		},
+		{
+			"lsn of file end",
+			RecoverOpts{ToVClock: xlog.VClock{1: 4}},
+			xlog.VClock{1: 4}, []string{firstXlog}, []uint64{1, 2, 3, 4},
+		},
+		{
+			"time",
+			RecoverOpts{ToTime: snapTime.Add(25 * time.Second)},
+			xlog.VClock{1: 3}, []string{firstXlog}, []uint64{1, 2, 3},
+		},
+		{
+			"time of a row",
+			RecoverOpts{ToTime: snapTime.Add(20 * time.Second)},
+			xlog.VClock{1: 1}, []string{firstXlog}, []uint64{1},
+		},
+		{
+			"not reached",
+			RecoverOpts{ToVClock: xlog.VClock{1: 100}},
+			xlog.VClock{1: 5}, []string{firstXlog, secondXlog}, []uint64{1, 2, 3, 4},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "dst")
+			c.opts.SrcDir, c.opts.DstDir = src, dst
+			result, err := Recover(c.opts)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(src, "00000000000000000000.snap"), result.Snapshot)
+			assert.Equal(t, c.xlogs, result.Xlogs)
+			assert.Equal(t, c.vclock, result.VClock)
+
+			assert.FileExists(t, filepath.Join(dst, "00000000000000000000.snap"))
+			assert.Equal(t, c.lsns, readLSNs(t, filepath.Join(dst, "00000000000000000000.xlog")))
+			if len(c.xlogs) == 1 {
+				assert.NoFileExists(t, filepath.Join(dst, "00000000000000000004.xlog"))
+			}
+		})
+	}
+}
+
+func TestRecover_errors(t *testing.T) {
+	src := makeRecoverSrc(t)
+
+	_, err := Recover(RecoverOpts{SrcDir: src, DstDir: t.TempDir()})
+	assert.EqualError(t, err, "the recovery target is not specified")
+
+	_, err = Recover(RecoverOpts{SrcDir: src, DstDir: t.TempDir(), ToTime: snapTime})
+	assert.EqualError(t, err, `no snapshot at or before the target in "`+src+`"`)
+
+	dst := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dst, "1.xlog"), []byte{}, 0644))
+	_, err = Recover(RecoverOpts{SrcDir: src, DstDir: dst, ToVClock: xlog.VClock{1: 1}})
+	assert.EqualError(t, err, `the destination directory "`+dst+
+		`" already contains .snap/.xlog files`)
+}
+
+func TestParseVClockTarget(t *testing.T) {
+	vclock, err := ParseVClockTarget("{1: 10, 2: 5}")
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 10, 2: 5}, vclock)
+
+	vclock, err = ParseVClockTarget("1:10,2:5")
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 10, 2: 5}, vclock)
+
+	_, err = ParseVClockTarget("{}")
+	assert.EqualError(t, err, "the target vclock is empty")
+	_, err = ParseVClockTarget("10")
+	assert.EqualError(t, err, `invalid vclock component "10"`)
+}
@@ -0,0 +1,256 @@
+package checkpoint
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+	"github.com/tarantool/tt/cli/connector"
+)
+
+const (
+	// spaceSpaceID is an identifier of _space system space.
+	spaceSpaceID = 280
+	// indexSpaceID is an identifier of _index system space.
+	indexSpaceID = 288
+)
+
+// fetchSchemaEval returns tuples of _space and _index system spaces.
+const fetchSchemaEval = `return box.space._space:select(), box.space._index:select()`
+
+// spaceDef is a definition of a space required to decode its tuples.
+type spaceDef struct {
+	// name is a name of the space.
+	name string
+	// fields contains names of the space format fields.
+	fields []string
+	// types contains types of the space format fields.
+	types []string
+	// nullable contains nullability of the space format fields.
+	nullable []bool
+	// pk contains zero-based field numbers of the primary key parts.
+	pk []uint64
+	// indexes contains zero-based field numbers of parts of the indexes.
+	indexes map[uint64][]uint64
+	// indexNames contains identifiers of the indexes by their names.
+	indexNames map[string]uint64
+}
+
+// Schema contains definitions of spaces to decode tuples. It is collected
+// from _space and _index tuples.
+type Schema struct {
+	spaces map[uint64]*spaceDef
+}
+
+// NewSchema creates an empty schema.
+func NewSchema() *Schema {
+	return &Schema{spaces: map[uint64]*spaceDef{}}
+}
+
+// FetchSchema fetches a schema from an instance.
+func FetchSchema(evaler connector.Evaler) (*Schema, error) {
+	data, err := evaler.Eval(fetchSchemaEval, []interface{}{}, connector.RequestOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the schema: %w", err)
+	}
+	if len(data) != 2 {
+		return nil, fmt.Errorf("failed to fetch the schema: unexpected response: %v", data)
+	}
+
+	schema := NewSchema()
+	spaces, _ := data[0].([]interface{})
+	for _, tuple := range spaces {
+		if tuple, ok := tuple.([]interface{}); ok {
+			schema.addSpace(tuple)
+		}
+	}
+	indexes, _ := data[1].([]interface{})
+	for _, tuple := range indexes {
+		if tuple, ok := tuple.([]interface{}); ok {
+			schema.addIndex(tuple)
+		}
+	}
+	return schema, nil
+}
+
+// space returns a definition of the space, it is created if not exist.
+func (schema *Schema) space(id uint64) *spaceDef {
+	def, ok := schema.spaces[id]
+	if !ok {
+		def = &spaceDef{}
+		schema.spaces[id] = def
+	}
+	return def
+}
+
+// addSpace adds a space format from _space tuple:
+// [id, owner, name, engine, field_count, flags, format].
+func (schema *Schema) addSpace(tuple []interface{}) {
+	if len(tuple) < 7 {
+		return
+	}
+	id, ok := toUint64(tuple[0])
+	if !ok {
+		return
+	}
+	format, _ := tuple[6].([]interface{})
+	def := schema.space(id)
+	def.setFormat(format)
+	def.name, _ = tuple[2].(string)
+}
+
+// setFormat sets names, types and nullability of fields from the space
+// format: [{name = 'id', type = 'unsigned', is_nullable = false}, ...].
+func (def *spaceDef) setFormat(format []interface{}) {
+	def.fields = make([]string, 0, len(format))
+	def.types = make([]string, 0, len(format))
+	def.nullable = make([]bool, 0, len(format))
+	for _, field := range format {
+		name, _ := mapValue(field, "name").(string)
+		fieldType, _ := mapValue(field, "type").(string)
+		nullable, _ := mapValue(field, "is_nullable").(bool)
+		def.fields = append(def.fields, name)
+		def.types = append(def.types, fieldType)
+		def.nullable = append(def.nullable, nullable)
+	}
+}
+
+// addIndex adds index parts from _index tuple:
+// [space_id, index_id, name, type, opts, parts]. The parts could be in the
+// format [{field = 0, ...}, ...] or [[0, 'type'], ...].
+func (schema *Schema) addIndex(tuple []interface{}) {
+	if len(tuple) < 6 {
+		return
+	}
+	id, ok := toUint64(tuple[0])
+	indexID, isUint := toUint64(tuple[1])
+	if !ok || !isUint {
+		return
+	}
+	parts, _ := tuple[5].([]interface{})
+	fieldNos := make([]uint64, 0, len(parts))
+	for _, part := range parts {
+		field := mapValue(part, "field")
+		if part, ok := part.([]interface{}); ok && len(part) > 0 {
+			field = part[0]
+		}
+		fieldNo, ok := toUint64(field)
+		if !ok {
+			return
+		}
+		fieldNos = append(fieldNos, fieldNo)
+	}
+	def := schema.space(id)
+	if def.indexes == nil {
+		def.indexes = map[uint64][]uint64{}
+		def.indexNames = map[string]uint64{}
+	}
+	def.indexes[indexID] = fieldNos
+	if name, ok := tuple[2].(string); ok {
+		def.indexNames[name] = indexID
+	}
+	if indexID == 0 {
+		def.pk = fieldNos
+	}
+}
+
+// applyRow updates the schema with the row of _space or _index system space.
+func (schema *Schema) applyRow(row xlog.Row) {
+	if row.Header.Type != xlog.InsertType && row.Header.Type != xlog.ReplaceType {
+		return
+	}
+	spaceID, _ := row.SpaceID()
+	tuple, _ := row.Body[xlog.TupleKey].([]interface{})
+	switch {
+	case spaceID == spaceSpaceID && tuple != nil:
+		schema.addSpace(tuple)
+	case spaceID == indexSpaceID && tuple != nil:
+		schema.addIndex(tuple)
+	}
+}
+
+// primaryKey returns a primary key of the tuple. The first fields are used
+// if the primary key of the space is unknown.
+func (schema *Schema) primaryKey(spaceID uint64, tuple []interface{},
+	size int) []interface{} {
+	if schema != nil {
+		if def, ok := schema.spaces[spaceID]; ok && def.pk != nil {
+			key := make([]interface{}, 0, len(def.pk))
+			for _, fieldNo := range def.pk {
+				if fieldNo >= uint64(len(tuple)) {
+					break
+				}
+				key = append(key, tuple[fieldNo])
+			}
+			return key
+		}
+	}
+	if size > len(tuple) {
+		size = len(tuple)
+	}
+	return tuple[:size]
+}
+
+// fieldName returns a name of the tuple field or its one-based number.
+func (def *spaceDef) fieldName(fieldNo uint64) interface{} {
+	if fieldNo < uint64(len(def.fields)) && def.fields[fieldNo] != "" {
+		return def.fields[fieldNo]
+	}
+	return fieldNo + 1
+}
+
+// decodeTuple returns the tuple as an ordered map with field names. The tuple
+// is returned as is if the space format is unknown.
+func (schema *Schema) decodeTuple(spaceID uint64, value interface{}) interface{} {
+	def, ok := schema.spaces[spaceID]
+	tuple, isTuple := value.([]interface{})
+	if !ok || !isTuple || len(def.fields) == 0 {
+		return plainValue(value)
+	}
+	decoded := make(orderedMap, 0, len(tuple))
+	for i, field := range tuple {
+		decoded = append(decoded, orderedField{def.fieldName(uint64(i)), plainValue(field)})
+	}
+	return decoded
+}
+
+// decodeKey returns the primary key as an ordered map with field names. The
+// key is returned as is if the primary key or the space format is unknown.
+func (schema *Schema) decodeKey(spaceID uint64, value interface{}) interface{} {
+	def, ok := schema.spaces[spaceID]
+	key, isKey := value.([]interface{})
+	if !ok || !isKey || len(def.fields) == 0 || len(key) > len(def.pk) {
+		return plainValue(value)
+	}
+	decoded := make(orderedMap, 0, len(key))
+	for i, part := range key {
+		decoded = append(decoded, orderedField{def.fieldName(def.pk[i]), plainValue(part)})
+	}
+	return decoded
+}
+
+// toUint64 converts an integer to uint64. The integer type depends on
+// a msgpack decoder.
+func toUint64(value interface{}) (uint64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() >= 0 {
+			return uint64(rv.Int()), true
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	}
+	return 0, false
+}
+
+// mapValue returns a value of the map by the string key.
+func mapValue(value interface{}, key string) interface{} {
+	switch m := value.(type) {
+	case map[interface{}]interface{}:
+		return m[key]
+	case map[string]interface{}:
+		return m[key]
+	}
+	return nil
+}
@@ -0,0 +1,439 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+// truncateSpaceID is an identifier of _truncate system space.
+const truncateSpaceID = 330
+
+// spaceData contains tuples of a space by their encoded primary keys.
+type spaceData map[string][]interface{}
+
+// dataState is an in-memory state of spaces built from .snap/.xlog rows.
+type dataState struct {
+	schema *Schema
+	spaces map[uint64]spaceData
+}
+
+// newDataState creates an empty state.
+func newDataState() *dataState {
+	return &dataState{schema: NewSchema(), spaces: map[uint64]spaceData{}}
+}
+
+// loadState loads a state from a .snap file or from the newest snapshot of
+// a directory and the following xlogs.
+func loadState(path string) (*dataState, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap checkpointFile
+	var xlogs []checkpointFile
+	if info.IsDir() {
+		snaps, err := listFiles(path, ".snap")
+		if err != nil {
+			return nil, err
+		}
+		if len(snaps) == 0 {
+			return nil, fmt.Errorf("no snapshot in %q", path)
+		}
+		snap = snaps[len(snaps)-1]
+		if xlogs, err = listFiles(path, ".xlog"); err != nil {
+			return nil, err
+		}
+		xlogs = followingXlogs(xlogs, snap)
+	} else if filepath.Ext(path) == ".snap" {
+		snap.path = path
+	} else {
+		return nil, fmt.Errorf("%q is not a .snap file or a directory", path)
+	}
+
+	state := newDataState()
+	opts := Opts{To: math.MaxUint64, ShowSystem: true}
+	if err := readFile(snap.path, opts, state.apply); err != nil {
+		return nil, err
+	}
+	for _, file := range xlogs {
+		err := readFile(file.path, opts, func(row xlog.Row) error {
+			if lsn, ok := snap.meta.VClock[row.Header.ReplicaID]; ok && row.Header.LSN <= lsn {
+				// The row is in the snapshot.
+				return nil
+			}
+			if err := state.apply(row); err != nil {
+				return fmt.Errorf("failed to apply lsn %d of %q: %w",
+					row.Header.LSN, file.path, err)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return state, nil
+}
+
+// encodeKey encodes a key or a tuple into a JSON string to compare them.
+// Values unsupported by JSON, like NaN, are encoded as a string.
+func encodeKey(key []interface{}) string {
+	data, err := json.Marshal(plainValue(key))
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(plainValue(key)))
+	}
+	return string(data)
+}
+
+// tupleKey returns an encoded primary key of the tuple. The first field is
+// used if the primary key of the space is unknown.
+func (state *dataState) tupleKey(spaceID uint64, tuple []interface{}) string {
+	return encodeKey(state.schema.primaryKey(spaceID, tuple, 1))
+}
+
+// space returns data of the space, it is created if not exist.
+func (state *dataState) space(spaceID uint64) spaceData {
+	data, ok := state.spaces[spaceID]
+	if !ok {
+		data = spaceData{}
+		state.spaces[spaceID] = data
+	}
+	return data
+}
+
+// findKey returns an encoded primary key of a tuple by the key of the index.
+func (state *dataState) findKey(spaceID uint64, indexID uint64,
+	key []interface{}) (string, bool) {
+	data := state.space(spaceID)
+	if indexID == 0 {
+		encoded := encodeKey(key)
+		_, ok := data[encoded]
+		return encoded, ok
+	}
+
+	def, ok := state.schema.spaces[spaceID]
+	if !ok || def.indexes[indexID] == nil {
+		return "", false
+	}
+	parts := def.indexes[indexID]
+	expected := encodeKey(key)
+	for pk, tuple := range data {
+		indexKey := make([]interface{}, 0, len(parts))
+		for _, fieldNo := range parts {
+			if fieldNo < uint64(len(tuple)) {
+				indexKey = append(indexKey, tuple[fieldNo])
+			}
+		}
+		if encodeKey(indexKey) == expected {
+			return pk, true
+		}
+	}
+	return "", false
+}
+
+// apply applies the row to the state.
+func (state *dataState) apply(row xlog.Row) error {
+	state.schema.applyRow(row)
+	spaceID, ok := row.SpaceID()
+	if !ok {
+		return nil
+	}
+	tuple, _ := row.Body[xlog.TupleKey].([]interface{})
+	key, _ := row.Body[xlog.KeyKey].([]interface{})
+	indexID, _ := toUint64(row.Body[xlog.IndexIDKey])
+
+	switch {
+	case spaceID == spaceSpaceID && row.Header.Type == xlog.DeleteType && len(key) > 0:
+		// The space is dropped.
+		if id, ok := toUint64(key[0]); ok {
+			delete(state.spaces, id)
+		}
+	case spaceID == truncateSpaceID && len(tuple) > 0:
+		// The space is truncated.
+		if id, ok := toUint64(tuple[0]); ok {
+			delete(state.spaces, id)
+		}
+	}
+
+	data := state.space(spaceID)
+	switch row.Header.Type {
+	case xlog.InsertType, xlog.ReplaceType:
+		data[state.tupleKey(spaceID, tuple)] = tuple
+	case xlog.DeleteType:
+		if pk, ok := state.findKey(spaceID, indexID, key); ok {
+			delete(data, pk)
+		}
+	case xlog.UpdateType:
+		pk, ok := state.findKey(spaceID, indexID, key)
+		if !ok {
+			return nil
+		}
+		updated, err := applyOps(data[pk], row.Body[xlog.TupleKey],
+			indexBase(row), state.schema.spaces[spaceID], false)
+		if err != nil {
+			return err
+		}
+		data[pk] = updated
+	case xlog.UpsertType:
+		pk := state.tupleKey(spaceID, tuple)
+		old, ok := data[pk]
+		if !ok {
+			data[pk] = tuple
+			return nil
+		}
+		// Errors of upsert operations are skipped in the same way as
+		// Tarantool does.
+		data[pk], _ = applyOps(old, row.Body[xlog.OperationsKey],
+			indexBase(row), state.schema.spaces[spaceID], true)
+	}
+	return nil
+}
+
+// indexBase returns a base of field numbers of update operations.
+func indexBase(row xlog.Row) int64 {
+	if base, ok := toUint64(row.Body[xlog.IndexBaseKey]); ok {
+		return int64(base)
+	}
+	return 0
+}
+
+// applyOps applies update operations to a copy of the tuple. Failed
+// operations are skipped in the upsert mode.
+func applyOps(tuple []interface{}, ops interface{}, base int64, def *spaceDef,
+	upsert bool) ([]interface{}, error) {
+	list, ok := ops.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid update operations %v", ops)
+	}
+	result := append([]interface{}(nil), tuple...)
+	for _, op := range list {
+		updated, err := applyOp(result, op, base, def)
+		if err != nil {
+			if upsert {
+				continue
+			}
+			return nil, err
+		}
+		result = updated
+	}
+	return result, nil
+}
+
+// toInt64 converts an integer to int64.
+func toInt64(value interface{}) (int64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() <= math.MaxInt64 {
+			return int64(rv.Uint()), true
+		}
+	}
+	return 0, false
+}
+
+// toString converts a string or binary value to a string.
+func toString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+// fieldIndex returns a zero-based index of the operation field. The index
+// could be equal to the tuple length for insert and assignment operations.
+func fieldIndex(field interface{}, tuple []interface{}, base int64, def *spaceDef,
+	op string) (int, error) {
+	size := int64(len(tuple))
+	var index int64
+	if name, ok := toString(field); ok {
+		index = -1
+		if def != nil {
+			for i, fieldName := range def.fields {
+				if fieldName == name {
+					index = int64(i)
+				}
+			}
+		}
+		if index < 0 {
+			return 0, fmt.Errorf("field %q is not found", name)
+		}
+	} else if number, ok := toInt64(field); ok {
+		switch {
+		case number < 0 && op == "!":
+			index = size + number + 1
+		case number < 0:
+			index = size + number
+		default:
+			index = number - base
+		}
+	} else {
+		return 0, fmt.Errorf("invalid field %v", field)
+	}
+
+	maxIndex := size - 1
+	if op == "!" || op == "=" {
+		maxIndex = size
+	}
+	if index < 0 || index > maxIndex {
+		return 0, fmt.Errorf("field %v is out of the tuple", field)
+	}
+	return int(index), nil
+}
+
+// toNumber converts a number to *big.Int or float64.
+func toNumber(value interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return nil, false
+}
+
+// arith applies an arithmetic or a bitwise operation.
+func arith(op string, a, b interface{}) (interface{}, error) {
+	x, okX := toNumber(a)
+	y, okY := toNumber(b)
+	if !okX || !okY {
+		return nil, fmt.Errorf("arithmetic operation %q on %v and %v", op, a, b)
+	}
+	xInt, xIsInt := x.(*big.Int)
+	yInt, yIsInt := y.(*big.Int)
+	if !xIsInt || !yIsInt {
+		if op != "+" && op != "-" {
+			return nil, fmt.Errorf("bitwise operation %q on %v and %v", op, a, b)
+		}
+		xFloat, yFloat := toFloat(x), toFloat(y)
+		if op == "+" {
+			return xFloat + yFloat, nil
+		}
+		return xFloat - yFloat, nil
+	}
+
+	if (op == "&" || op == "|" || op == "^") && (xInt.Sign() < 0 || yInt.Sign() < 0) {
+		return nil, fmt.Errorf("bitwise operation %q on %v and %v", op, a, b)
+	}
+	result := new(big.Int)
+	switch op {
+	case "+":
+		result.Add(xInt, yInt)
+	case "-":
+		result.Sub(xInt, yInt)
+	case "&":
+		result.And(xInt, yInt)
+	case "|":
+		result.Or(xInt, yInt)
+	case "^":
+		result.Xor(xInt, yInt)
+	}
+	if result.IsUint64() {
+		return result.Uint64(), nil
+	}
+	if result.IsInt64() {
+		return result.Int64(), nil
+	}
+	return nil, fmt.Errorf("integer overflow in operation %q on %v and %v", op, a, b)
+}
+
+// toFloat converts a result of toNumber to float64.
+func toFloat(number interface{}) float64 {
+	if i, ok := number.(*big.Int); ok {
+		f, _ := new(big.Float).SetInt(i).Float64()
+		return f
+	}
+	return number.(float64)
+}
+
+// splice applies a splice operation to the string.
+func splice(value, offset, length, insertion interface{}) (string, error) {
+	str, okStr := toString(value)
+	pos, okPos := toInt64(offset)
+	cut, okCut := toInt64(length)
+	ins, okIns := toString(insertion)
+	if !okStr || !okPos || !okCut || !okIns || cut < 0 {
+		return "", fmt.Errorf("invalid splice arguments")
+	}
+	size := int64(len(str))
+	switch {
+	case pos > 0:
+		pos--
+	case pos < 0:
+		pos = size + pos + 1
+	default:
+		return "", fmt.Errorf("splice offset is out of bound")
+	}
+	if pos < 0 {
+		return "", fmt.Errorf("splice offset is out of bound")
+	}
+	if pos > size {
+		pos = size
+	}
+	if pos+cut > size {
+		cut = size - pos
+	}
+	return str[:pos] + ins + str[pos+cut:], nil
+}
+
+// applyOp applies an update operation to the tuple.
+func applyOp(tuple []interface{}, value interface{}, base int64,
+	def *spaceDef) ([]interface{}, error) {
+	op, ok := value.([]interface{})
+	if !ok || len(op) < 3 {
+		return nil, fmt.Errorf("invalid update operation %v", value)
+	}
+	name, _ := toString(op[0])
+	index, err := fieldIndex(op[1], tuple, base, def, name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "=":
+		if index == len(tuple) {
+			return append(tuple, op[2]), nil
+		}
+		tuple[index] = op[2]
+	case "+", "-", "&", "|", "^":
+		if tuple[index], err = arith(name, tuple[index], op[2]); err != nil {
+			return nil, err
+		}
+	case "!":
+		tuple = append(tuple[:index], append([]interface{}{op[2]}, tuple[index:]...)...)
+	case "#":
+		count, ok := toInt64(op[2])
+		if !ok || count <= 0 {
+			return nil, fmt.Errorf("invalid delete count %v", op[2])
+		}
+		end := index + int(count)
+		if end > len(tuple) {
+			end = len(tuple)
+		}
+		tuple = append(tuple[:index], tuple[end:]...)
+	case ":":
+		if len(op) < 5 {
+			return nil, fmt.Errorf("invalid update operation %v", value)
+		}
+		if tuple[index], err = splice(tuple[index], op[2], op[3], op[4]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown update operation %q", name)
+	}
+	return tuple, nil
+}
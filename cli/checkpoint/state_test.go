@@ -0,0 +1,91 @@
+package checkpoint
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOps(t *testing.T) {
+	tuple := []interface{}{uint64(1), "abc", int64(-5), 1.5}
+	def := &spaceDef{fields: []string{"id", "name", "value", "ratio"}}
+	cases := []struct {
+		name     string
+		ops      []interface{}
+		expected []interface{}
+	}{
+		{"assign", []interface{}{[]interface{}{"=", uint64(1), "x"}},
+			[]interface{}{"x", "abc", int64(-5), 1.5}},
+		{"assign by name", []interface{}{[]interface{}{"=", "name", "x"}},
+			[]interface{}{uint64(1), "x", int64(-5), 1.5}},
+		{"assign new field", []interface{}{[]interface{}{"=", uint64(5), true}},
+			[]interface{}{uint64(1), "abc", int64(-5), 1.5, true}},
+		{"add", []interface{}{[]interface{}{"+", uint64(3), uint64(10)}},
+			[]interface{}{uint64(1), "abc", uint64(5), 1.5}},
+		{"subtract", []interface{}{[]interface{}{"-", uint64(1), uint64(2)}},
+			[]interface{}{int64(-1), "abc", int64(-5), 1.5}},
+		{"add float", []interface{}{[]interface{}{"+", int64(-1), uint64(1)}},
+			[]interface{}{uint64(1), "abc", int64(-5), 2.5}},
+		{"bitwise", []interface{}{
+			[]interface{}{"|", uint64(1), uint64(6)},
+			[]interface{}{"&", uint64(1), uint64(3)},
+			[]interface{}{"^", uint64(1), uint64(1)},
+		}, []interface{}{uint64(2), "abc", int64(-5), 1.5}},
+		{"insert", []interface{}{[]interface{}{"!", uint64(2), "x"}},
+			[]interface{}{uint64(1), "x", "abc", int64(-5), 1.5}},
+		{"insert last", []interface{}{[]interface{}{"!", int64(-1), "x"}},
+			[]interface{}{uint64(1), "abc", int64(-5), 1.5, "x"}},
+		{"delete", []interface{}{[]interface{}{"#", uint64(2), uint64(2)}},
+			[]interface{}{uint64(1), 1.5}},
+		{"splice", []interface{}{[]interface{}{":", uint64(2), uint64(2), uint64(1), "XY"}},
+			[]interface{}{uint64(1), "aXYc", int64(-5), 1.5}},
+		{"splice end", []interface{}{[]interface{}{":", uint64(2), int64(-1), uint64(0), "d"}},
+			[]interface{}{uint64(1), "abcd", int64(-5), 1.5}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := applyOps(tuple, c.ops, 1, def, false)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, actual)
+			// The source tuple is not changed.
+			assert.Equal(t, []interface{}{uint64(1), "abc", int64(-5), 1.5}, tuple)
+		})
+	}
+
+	errorCases := []struct {
+		name string
+		ops  []interface{}
+		err  string
+	}{
+		{"unknown", []interface{}{[]interface{}{"?", uint64(1), uint64(1)}},
+			`unknown update operation "?"`},
+		{"out of tuple", []interface{}{[]interface{}{"+", uint64(6), uint64(1)}},
+			"field 6 is out of the tuple"},
+		{"no field", []interface{}{[]interface{}{"=", "missing", uint64(1)}},
+			`field "missing" is not found`},
+		{"string arithmetic", []interface{}{[]interface{}{"+", uint64(2), uint64(1)}},
+			`arithmetic operation "+" on abc and 1`},
+		{"negative bitwise", []interface{}{[]interface{}{"&", uint64(3), uint64(1)}},
+			`bitwise operation "&" on -5 and 1`},
+		{"overflow", []interface{}{[]interface{}{"+", uint64(1), uint64(math.MaxUint64)}},
+			`integer overflow in operation "+" on 1 and 18446744073709551615`},
+		{"invalid", []interface{}{uint64(1)}, "invalid update operation 1"},
+	}
+	for _, c := range errorCases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := applyOps(tuple, c.ops, 1, def, false)
+			assert.EqualError(t, err, c.err)
+		})
+	}
+
+	// Errors are skipped for upsert.
+	actual, err := applyOps(tuple, []interface{}{
+		[]interface{}{"+", uint64(2), uint64(1)},
+		[]interface{}{"=", uint64(2), "x"},
+	}, 1, def, true)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{uint64(1), "x", int64(-5), 1.5}, actual)
+}
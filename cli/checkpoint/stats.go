@@ -0,0 +1,296 @@
+package checkpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+// FileStats contains a header summary of a file.
+type FileStats struct {
+	Path         string `json:"path"`
+	Filetype     string `json:"filetype"`
+	Version      string `json:"version"`
+	InstanceUUID string `json:"instance_uuid"`
+	VClock       string `json:"vclock"`
+	PrevVClock   string `json:"prev_vclock,omitempty"`
+	Count        int    `json:"count"`
+}
+
+// rangeStats contains LSN and timestamp ranges of rows.
+type rangeStats struct {
+	Count   int     `json:"count"`
+	MinLSN  uint64  `json:"lsn_min"`
+	MaxLSN  uint64  `json:"lsn_max"`
+	MinTime float64 `json:"timestamp_min,omitempty"`
+	MaxTime float64 `json:"timestamp_max,omitempty"`
+}
+
+// add adds the row to the ranges.
+func (stats *rangeStats) add(row xlog.Row) {
+	if stats.Count == 0 || row.Header.LSN < stats.MinLSN {
+		stats.MinLSN = row.Header.LSN
+	}
+	if stats.Count == 0 || row.Header.LSN > stats.MaxLSN {
+		stats.MaxLSN = row.Header.LSN
+	}
+	if ts := row.Header.Timestamp; ts != 0 {
+		if stats.MinTime == 0 || ts < stats.MinTime {
+			stats.MinTime = ts
+		}
+		stats.MaxTime = math.Max(stats.MaxTime, ts)
+	}
+	stats.Count++
+}
+
+// merge merges the other ranges into the ranges.
+func (stats *rangeStats) merge(other rangeStats) {
+	if other.Count == 0 {
+		return
+	}
+	if stats.Count == 0 {
+		*stats = other
+		return
+	}
+	if other.MinLSN < stats.MinLSN {
+		stats.MinLSN = other.MinLSN
+	}
+	if other.MaxLSN > stats.MaxLSN {
+		stats.MaxLSN = other.MaxLSN
+	}
+	if other.MinTime != 0 && (stats.MinTime == 0 || other.MinTime < stats.MinTime) {
+		stats.MinTime = other.MinTime
+	}
+	stats.MaxTime = math.Max(stats.MaxTime, other.MaxTime)
+	stats.Count += other.Count
+}
+
+// OperationStats contains statistics of an operation type in a space.
+type OperationStats struct {
+	// SpaceID is nil for rows without a space.
+	SpaceID     *uint64 `json:"space_id"`
+	Type        string  `json:"type"`
+	BodySize    int     `json:"body_size"`
+	AvgBodySize float64 `json:"body_size_avg"`
+	rangeStats
+}
+
+// ReplicaStats contains statistics of rows of a replica.
+type ReplicaStats struct {
+	ReplicaID uint64 `json:"replica_id"`
+	rangeStats
+}
+
+// Stats contains summary statistics of .snap/.xlog files.
+type Stats struct {
+	Files      []FileStats      `json:"files"`
+	Operations []OperationStats `json:"operations"`
+	Replicas   []ReplicaStats   `json:"replicas"`
+	// VClock is a vector clock of the last rows of the replicas.
+	VClock string `json:"vclock"`
+}
+
+// operationKey is a key to aggregate rows.
+type operationKey struct {
+	spaceID  uint64
+	hasSpace bool
+	rowType  xlog.RequestType
+}
+
+// CollectStats collects summary statistics of accepted rows of the files.
+// Rows of snapshots are not included into the replicas statistics, because
+// their LSNs are sequence numbers of the rows.
+func CollectStats(files []string, opts Opts) (Stats, error) {
+	stats := Stats{Files: []FileStats{}}
+	operations := map[operationKey]*OperationStats{}
+	replicas := map[uint64]*ReplicaStats{}
+	for _, file := range files {
+		count := 0
+		fileReplicas := map[uint64]*rangeStats{}
+		meta, err := readFileMeta(file, opts, func(row xlog.Row) error {
+			count++
+			spaceID, hasSpace := row.SpaceID()
+			key := operationKey{spaceID, hasSpace, row.Header.Type}
+			op, ok := operations[key]
+			if !ok {
+				op = &OperationStats{Type: row.Header.Type.String()}
+				if hasSpace {
+					op.SpaceID = &spaceID
+				}
+				operations[key] = op
+			}
+			op.add(row)
+			op.BodySize += row.BodySize
+
+			replica, ok := fileReplicas[row.Header.ReplicaID]
+			if !ok {
+				replica = &rangeStats{}
+				fileReplicas[row.Header.ReplicaID] = replica
+			}
+			replica.add(row)
+			return nil
+		})
+		if err != nil {
+			return stats, err
+		}
+		if meta.Filetype != "SNAP" {
+			for id, fileReplica := range fileReplicas {
+				replica, ok := replicas[id]
+				if !ok {
+					replica = &ReplicaStats{ReplicaID: id}
+					replicas[id] = replica
+				}
+				replica.merge(*fileReplica)
+			}
+		}
+		fileStats := FileStats{
+			Path:         file,
+			Filetype:     meta.Filetype,
+			Version:      meta.Version,
+			InstanceUUID: meta.InstanceUUID,
+			VClock:       meta.VClock.String(),
+			Count:        count,
+		}
+		if meta.PrevVClock != nil {
+			fileStats.PrevVClock = meta.PrevVClock.String()
+		}
+		stats.Files = append(stats.Files, fileStats)
+	}
+
+	keys := make([]operationKey, 0, len(operations))
+	for key := range operations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].hasSpace != keys[j].hasSpace {
+			return keys[i].hasSpace
+		}
+		if keys[i].spaceID != keys[j].spaceID {
+			return keys[i].spaceID < keys[j].spaceID
+		}
+		return keys[i].rowType < keys[j].rowType
+	})
+	stats.Operations = make([]OperationStats, 0, len(keys))
+	for _, key := range keys {
+		op := operations[key]
+		op.AvgBodySize = float64(op.BodySize) / float64(op.Count)
+		stats.Operations = append(stats.Operations, *op)
+	}
+
+	vclock := xlog.VClock{}
+	stats.Replicas = make([]ReplicaStats, 0, len(replicas))
+	for id, replica := range replicas {
+		stats.Replicas = append(stats.Replicas, *replica)
+		vclock[id] = replica.MaxLSN
+	}
+	sort.Slice(stats.Replicas, func(i, j int) bool {
+		return stats.Replicas[i].ReplicaID < stats.Replicas[j].ReplicaID
+	})
+	stats.VClock = vclock.String()
+	return stats, nil
+}
+
+// CatStats prints summary statistics of .snap/.xlog files as tables or JSON.
+func CatStats(files []string, opts Opts) error {
+	if opts.Format != "table" && opts.Format != "json" {
+		return fmt.Errorf("unknown statistics format %q: table or json is expected",
+			opts.Format)
+	}
+	if err := ValidateTypes(opts.Type); err != nil {
+		return err
+	}
+	if opts.Schema == nil && opts.Key != nil {
+		opts.Schema = NewSchema()
+	}
+
+	stats, err := CollectStats(files, opts)
+	if err != nil {
+		return fmt.Errorf("result of cat: %w", err)
+	}
+	if opts.Format == "json" {
+		return writeStatsJson(os.Stdout, stats)
+	}
+	writeStatsTable(os.Stdout, stats)
+	return nil
+}
+
+// writeStatsJson writes the statistics as JSON.
+func writeStatsJson(w io.Writer, stats Stats) error {
+	data, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// formatTimestamp formats a timestamp of rows for a table.
+func formatTimestamp(timestamp float64) string {
+	if timestamp == 0 {
+		return "-"
+	}
+	sec, frac := math.Modf(timestamp)
+	return time.Unix(int64(sec), int64(frac*1e9)).Format("2006-01-02 15:04:05")
+}
+
+// newStatsTable creates a table writer with the header.
+func newStatsTable(w io.Writer, header table.Row) table.Writer {
+	tw := table.NewWriter()
+	tw.SetOutputMirror(w)
+	tw.AppendHeader(header)
+	tw.Style().Options.DrawBorder = false
+	tw.Style().Options.SeparateColumns = false
+	tw.Style().Options.SeparateHeader = false
+	return tw
+}
+
+// writeStatsTable writes the statistics as tables.
+func writeStatsTable(w io.Writer, stats Stats) {
+	fmt.Fprintln(w, "• Files •")
+	tw := newStatsTable(w, table.Row{"FILE", "TYPE", "VERSION", "INSTANCE", "VCLOCK",
+		"PREV VCLOCK", "ROWS"})
+	for _, file := range stats.Files {
+		prev := file.PrevVClock
+		if prev == "" {
+			prev = "-"
+		}
+		tw.AppendRow(table.Row{file.Path, file.Filetype, file.Version, file.InstanceUUID,
+			file.VClock, prev, file.Count})
+	}
+	tw.Render()
+
+	fmt.Fprintln(w, "\n• Operations •")
+	tw = newStatsTable(w, table.Row{"SPACE", "TYPE", "ROWS", "BODY SIZE", "AVG SIZE",
+		"LSN RANGE", "TIME RANGE"})
+	for _, op := range stats.Operations {
+		space := "-"
+		if op.SpaceID != nil {
+			space = fmt.Sprint(*op.SpaceID)
+		}
+		tw.AppendRow(table.Row{space, op.Type, op.Count, op.BodySize,
+			fmt.Sprintf("%.1f", op.AvgBodySize),
+			fmt.Sprintf("%d - %d", op.MinLSN, op.MaxLSN),
+			fmt.Sprintf("%s - %s", formatTimestamp(op.MinTime), formatTimestamp(op.MaxTime)),
+		})
+	}
+	tw.Render()
+
+	fmt.Fprintln(w, "\n• Replicas •")
+	tw = newStatsTable(w, table.Row{"REPLICA", "ROWS", "LSN RANGE", "TIME RANGE"})
+	for _, replica := range stats.Replicas {
+		tw.AppendRow(table.Row{replica.ReplicaID, replica.Count,
+			fmt.Sprintf("%d - %d", replica.MinLSN, replica.MaxLSN),
+			fmt.Sprintf("%s - %s", formatTimestamp(replica.MinTime),
+				formatTimestamp(replica.MaxTime)),
+		})
+	}
+	tw.Render()
+	fmt.Fprintf(w, "\nVClock: %s\n", stats.VClock)
+}
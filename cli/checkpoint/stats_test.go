@@ -0,0 +1,82 @@
+package checkpoint
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectStats(t *testing.T) {
+	opts := Opts{To: math.MaxUint64, ShowSystem: true}
+	stats, err := CollectStats([]string{testXlog, testSnap}, opts)
+	require.NoError(t, err)
+
+	require.Len(t, stats.Files, 2)
+	assert.Equal(t, FileStats{
+		Path:         testXlog,
+		Filetype:     "XLOG",
+		Version:      "0.13",
+		InstanceUUID: "8fb65242-878b-4dc6-a07b-444ae3decc18",
+		VClock:       "{}",
+		Count:        2,
+	}, stats.Files[0])
+	assert.Equal(t, "SNAP", stats.Files[1].Filetype)
+	assert.Equal(t, 515, stats.Files[1].Count)
+
+	require.NotEmpty(t, stats.Operations)
+	update := stats.Operations[1]
+	require.NotNil(t, update.SpaceID)
+	assert.Equal(t, uint64(272), *update.SpaceID)
+	assert.Equal(t, "UPDATE", update.Type)
+	assert.Equal(t, 1, update.Count)
+	assert.Equal(t, 23, update.BodySize)
+	assert.Equal(t, 23.0, update.AvgBodySize)
+	assert.Equal(t, uint64(1), update.MinLSN)
+	assert.Equal(t, uint64(1), update.MaxLSN)
+
+	promote := stats.Operations[len(stats.Operations)-1]
+	assert.Nil(t, promote.SpaceID)
+	assert.Equal(t, "PROMOTE", promote.Type)
+
+	// The snapshot rows are not included.
+	require.Len(t, stats.Replicas, 1)
+	assert.Equal(t, uint64(1), stats.Replicas[0].ReplicaID)
+	assert.Equal(t, 2, stats.Replicas[0].Count)
+	assert.Equal(t, uint64(1), stats.Replicas[0].MinLSN)
+	assert.Equal(t, uint64(2), stats.Replicas[0].MaxLSN)
+	assert.InDelta(t, 1650033990.995, stats.Replicas[0].MinTime, 0.001)
+	assert.Equal(t, "{1: 2}", stats.VClock)
+
+	// The filters are applied.
+	opts.Type = []string{"insert"}
+	stats, err = CollectStats([]string{testXlog}, opts)
+	require.NoError(t, err)
+	require.Len(t, stats.Operations, 1)
+	assert.Equal(t, "INSERT", stats.Operations[0].Type)
+	assert.Equal(t, 1, stats.Files[0].Count)
+}
+
+func TestWriteStats(t *testing.T) {
+	stats, err := CollectStats([]string{testXlog}, Opts{To: math.MaxUint64, ShowSystem: true})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, writeStatsJson(&buf, stats))
+	assert.Contains(t, buf.String(), `"space_id": 272,
+      "type": "UPDATE",
+      "body_size": 23,
+      "body_size_avg": 23,
+      "count": 1,
+      "lsn_min": 1,
+      "lsn_max": 1,`)
+
+	buf.Reset()
+	writeStatsTable(&buf, stats)
+	assert.Regexp(t, `(?s)• Files •.*XLOG +0\.13 +8fb65242-878b-4dc6-a07b-444ae3decc18`+
+		` +\{\} +- +2`+
+		`.*• Operations •.*272 +UPDATE +1 +23 +23\.0 +1 - 1`+
+		`.*• Replicas •.*1 +2 +1 - 2.*VClock: \{1: 2\}`, buf.String())
+}
@@ -0,0 +1,417 @@
+package xlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"gopkg.in/vmihailenco/msgpack.v2"
+	"gopkg.in/vmihailenco/msgpack.v2/codes"
+
+	// Decoders of the Tarantool msgpack extensions.
+	_ "github.com/tarantool/go-tarantool/datetime"
+	_ "github.com/tarantool/go-tarantool/decimal"
+	_ "github.com/tarantool/go-tarantool/uuid"
+)
+
+const (
+	// rowMarker starts a block of plain rows.
+	rowMarker = 0xd5ba0bab
+	// zRowMarker starts a block of zstd compressed rows.
+	zRowMarker = 0xd5ba0bba
+	// eofMarker ends a file.
+	eofMarker = 0xd510aded
+	// fixHeaderSize is a size of a block header with the marker.
+	fixHeaderSize = 19
+)
+
+// crc32cTable is a table for the checksum of a block.
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Meta is a meta information of a file.
+type Meta struct {
+	// Filetype is a type of the file: SNAP, XLOG, VYLOG, etc.
+	Filetype string
+	// Version is a version of the file format.
+	Version string
+	// InstanceUUID is an UUID of the instance that made the file.
+	InstanceUUID string
+	// VClock is a vector clock at the start of the file.
+	VClock VClock
+	// PrevVClock is a vector clock of the previous file.
+	PrevVClock VClock
+	// Fields contains all key-value fields of the meta.
+	Fields map[string]string
+	// keys contains keys of the fields in the order of the file.
+	keys []string
+}
+
+// VClock is a vector clock: a map of a replica id to its LSN.
+type VClock map[uint64]uint64
+
+// Signature returns a sum of all LSNs of the vector clock.
+func (vclock VClock) Signature() uint64 {
+	var sum uint64
+	for _, lsn := range vclock {
+		sum += lsn
+	}
+	return sum
+}
+
+// String returns the vector clock in the format: {1: 10, 2: 5}.
+func (vclock VClock) String() string {
+	ids := make([]uint64, 0, len(vclock))
+	for id := range vclock {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, fmt.Sprintf("%d: %d", id, vclock[id]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// ParseVClock parses a vector clock in the format: {1: 10, 2: 5}.
+func ParseVClock(str string) (VClock, error) {
+	vclock := VClock{}
+	str = strings.TrimSpace(str)
+	if !strings.HasPrefix(str, "{") || !strings.HasSuffix(str, "}") {
+		return nil, fmt.Errorf("invalid vclock %q", str)
+	}
+	str = strings.TrimSpace(str[1 : len(str)-1])
+	if str == "" {
+		return vclock, nil
+	}
+	for _, part := range strings.Split(str, ",") {
+		id, lsn, found := strings.Cut(part, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid vclock component %q", part)
+		}
+		replicaID, err := strconv.ParseUint(strings.TrimSpace(id), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vclock replica id %q", id)
+		}
+		vclock[replicaID], err = strconv.ParseUint(strings.TrimSpace(lsn), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid vclock lsn %q", lsn)
+		}
+	}
+	return vclock, nil
+}
+
+// Reader reads rows of a .snap/.xlog file.
+type Reader struct {
+	// reader is a source of the file data.
+	reader *bufio.Reader
+	// closer closes the file, if any.
+	closer io.Closer
+	// meta is a meta information of the file.
+	meta Meta
+	// decoder decompresses zstd blocks, it is created on demand.
+	decoder *zstd.Decoder
+	// data contains rows of the current block.
+	data []byte
+	// block contains undecoded rows of the current block.
+	block *bytes.Reader
+	// rows decodes rows of the current block.
+	rows *msgpack.Decoder
+	// done is true if the end of the file is reached.
+	done bool
+	// source counts bytes read from the source.
+	source *countingReader
+}
+
+// countingReader counts bytes read from the underlying reader.
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+// Read reads data and counts read bytes.
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
+
+// Open opens a file and reads its meta information.
+func Open(path string) (*Reader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+	reader.closer = file
+	return reader, nil
+}
+
+// NewReader creates a reader and reads the meta information from the source.
+func NewReader(r io.Reader) (*Reader, error) {
+	source := &countingReader{Reader: r}
+	reader := &Reader{reader: bufio.NewReader(source), source: source}
+	if err := reader.readMeta(); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// Meta returns the meta information of the file.
+func (r *Reader) Meta() Meta {
+	return r.meta
+}
+
+// Offset returns a number of bytes of the source consumed by the reader.
+// Rows are read by blocks, so it is an offset of the end of the current block.
+func (r *Reader) Offset() int64 {
+	return r.source.count - int64(r.reader.Buffered())
+}
+
+// Close releases resources of the reader.
+func (r *Reader) Close() error {
+	if r.decoder != nil {
+		r.decoder.Close()
+	}
+	if r.closer != nil {
+		return r.closer.Close()
+	}
+	return nil
+}
+
+// readMetaLine reads a line of the meta information.
+func (r *Reader) readMetaLine() (string, error) {
+	line, err := r.reader.ReadString('\n')
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", fmt.Errorf("failed to read the meta: %w", err)
+	}
+	return strings.TrimSuffix(line, "\n"), nil
+}
+
+// readMeta reads the meta information: a file type, a version and
+// key-value fields up to an empty line.
+func (r *Reader) readMeta() error {
+	var err error
+	if r.meta.Filetype, err = r.readMetaLine(); err != nil {
+		return err
+	}
+	if r.meta.Version, err = r.readMetaLine(); err != nil {
+		return err
+	}
+	if r.meta.Version != "0.12" && r.meta.Version != "0.13" {
+		return fmt.Errorf("unsupported file format version %q", r.meta.Version)
+	}
+
+	r.meta.Fields = map[string]string{}
+	for {
+		line, err := r.readMetaLine()
+		if err != nil {
+			return err
+		}
+		if line == "" {
+			break
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return fmt.Errorf("invalid meta line %q", line)
+		}
+		r.meta.Fields[key] = strings.TrimSpace(value)
+		r.meta.keys = append(r.meta.keys, key)
+	}
+
+	r.meta.InstanceUUID = r.meta.Fields["Instance"]
+	if r.meta.InstanceUUID == "" {
+		r.meta.InstanceUUID = r.meta.Fields["Server"]
+	}
+	if vclock, ok := r.meta.Fields["VClock"]; ok {
+		if r.meta.VClock, err = ParseVClock(vclock); err != nil {
+			return err
+		}
+	}
+	if vclock, ok := r.meta.Fields["PrevVClock"]; ok {
+		if r.meta.PrevVClock, err = ParseVClock(vclock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readBlock reads the next block of rows. It returns io.EOF at the end of
+// the file.
+func (r *Reader) readBlock() error {
+	var fixHeader [fixHeaderSize]byte
+	n, err := io.ReadFull(r.reader, fixHeader[:])
+	if err == io.EOF {
+		// The file is not finished yet.
+		return io.EOF
+	}
+	marker := binary.BigEndian.Uint32(fixHeader[:4])
+	if n >= 4 && marker == eofMarker {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read a block header: %w", io.ErrUnexpectedEOF)
+	}
+	if marker != rowMarker && marker != zRowMarker {
+		return fmt.Errorf("invalid block marker %#x", marker)
+	}
+
+	decoder := msgpack.NewDecoder(bytes.NewReader(fixHeader[4:]))
+	length, err := decoder.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("invalid block header: %w", err)
+	}
+	// A checksum of the previous block is not used.
+	if _, err := decoder.DecodeUint64(); err != nil {
+		return fmt.Errorf("invalid block header: %w", err)
+	}
+	crc, err := decoder.DecodeUint64()
+	if err != nil {
+		return fmt.Errorf("invalid block header: %w", err)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r.reader, data); err != nil {
+		return fmt.Errorf("failed to read a block: %w", io.ErrUnexpectedEOF)
+	}
+	if ^crc32.Update(^uint32(0), crc32cTable, data) != uint32(crc) {
+		return errors.New("block checksum mismatch")
+	}
+
+	if marker == zRowMarker {
+		if r.decoder == nil {
+			if r.decoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+				return err
+			}
+		}
+		if data, err = r.decoder.DecodeAll(data, nil); err != nil {
+			return fmt.Errorf("failed to decompress a block: %w", err)
+		}
+	}
+	r.data = data
+	r.block = bytes.NewReader(data)
+	r.rows = msgpack.NewDecoder(r.block)
+	return nil
+}
+
+// Next returns the next row of the file. It returns io.EOF at the end of
+// the file.
+func (r *Reader) Next() (Row, error) {
+	for !r.done && (r.block == nil || r.block.Len() == 0) {
+		if err := r.readBlock(); err != nil {
+			r.done = true
+			if err != io.EOF {
+				return Row{}, err
+			}
+		}
+	}
+	if r.done {
+		return Row{}, io.EOF
+	}
+
+	start := len(r.data) - r.block.Len()
+	row, err := decodeRow(r.rows, r.block)
+	if err != nil {
+		r.done = true
+		return Row{}, fmt.Errorf("failed to decode a row: %w", err)
+	}
+	row.Raw = r.data[start : len(r.data)-r.block.Len()]
+	return row, nil
+}
+
+// decodeRow decodes a row header and its body.
+func decodeRow(decoder *msgpack.Decoder, block *bytes.Reader) (Row, error) {
+	var row Row
+	tsnFound := false
+	n, err := decoder.DecodeMapLen()
+	if err != nil {
+		return row, err
+	}
+	for i := 0; i < n; i++ {
+		key, err := decoder.DecodeUint64()
+		if err != nil {
+			return row, err
+		}
+		value, err := decoder.DecodeInterface()
+		if err != nil {
+			return row, err
+		}
+		var ok bool
+		switch key {
+		case typeKey:
+			var t uint64
+			t, ok = toUint64(value)
+			row.Header.Type = RequestType(t)
+		case syncKey:
+			row.Header.Sync, ok = toUint64(value)
+		case replicaIDKey:
+			row.Header.ReplicaID, ok = toUint64(value)
+		case lsnKey:
+			row.Header.LSN, ok = toUint64(value)
+		case timestampKey:
+			row.Header.Timestamp, ok = toFloat64(value)
+		case groupIDKey:
+			row.Header.GroupID, ok = toUint64(value)
+		case tsnKey:
+			row.Header.TSN, ok = toUint64(value)
+			tsnFound = true
+		case flagsKey:
+			row.Header.Flags, ok = toUint64(value)
+		default:
+			ok = true
+		}
+		if !ok {
+			return row, fmt.Errorf("invalid header key %#x value: %v", key, value)
+		}
+	}
+	if !tsnFound {
+		row.Header.TSN = row.Header.LSN
+	}
+	// A row without TSN is a single row transaction.
+	row.Header.Commit = !tsnFound || row.Header.Flags&FlagCommit != 0
+
+	if block.Len() == 0 {
+		return row, nil
+	}
+	// A NOP row has no body or an empty one.
+	if row.Header.Type == NopType {
+		if code, err := decoder.PeekCode(); err == nil && code == codes.FixedMapLow {
+			_, err = decoder.DecodeMapLen()
+			return row, err
+		}
+		return row, nil
+	}
+
+	bodyStart := block.Len()
+	n, err = decoder.DecodeMapLen()
+	if err != nil {
+		return row, err
+	}
+	row.Body = make(map[uint64]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, err := decoder.DecodeUint64()
+		if err != nil {
+			return row, err
+		}
+		if row.Body[key], err = decoder.DecodeInterface(); err != nil {
+			return row, err
+		}
+	}
+	row.BodySize = bodyStart - block.Len()
+	return row, nil
+}
@@ -0,0 +1,207 @@
+package xlog_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+func readRows(t *testing.T, reader *xlog.Reader) []xlog.Row {
+	t.Helper()
+	var rows []xlog.Row
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestOpen_xlog(t *testing.T) {
+	reader, err := xlog.Open("testdata/test.xlog")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	meta := reader.Meta()
+	assert.Equal(t, "XLOG", meta.Filetype)
+	assert.Equal(t, "0.13", meta.Version)
+	assert.Equal(t, "8fb65242-878b-4dc6-a07b-444ae3decc18", meta.InstanceUUID)
+	assert.Equal(t, "2.8.3-0-g01023db", meta.Fields["Version"])
+	assert.Equal(t, xlog.VClock{}, meta.VClock)
+	assert.Nil(t, meta.PrevVClock)
+
+	rows := readRows(t, reader)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, xlog.UpdateType, rows[0].Header.Type)
+	assert.Equal(t, uint64(1), rows[0].Header.ReplicaID)
+	assert.Equal(t, uint64(1), rows[0].Header.LSN)
+	assert.Equal(t, uint64(1), rows[0].Header.TSN)
+	assert.InDelta(t, 1650033990.995, rows[0].Header.Timestamp, 0.001)
+	spaceID, ok := rows[0].SpaceID()
+	assert.True(t, ok)
+	assert.Equal(t, uint64(272), spaceID)
+	assert.Equal(t, []interface{}{"max_id"}, rows[0].Body[xlog.KeyKey])
+	assert.Equal(t, []interface{}{[]interface{}{"+", uint64(2), uint64(1)}},
+		rows[0].Body[xlog.TupleKey])
+	assert.Equal(t, 23, rows[0].BodySize)
+
+	assert.Equal(t, xlog.InsertType, rows[1].Header.Type)
+	assert.Equal(t, uint64(2), rows[1].Header.LSN)
+	assert.Equal(t, []interface{}{uint64(512), uint64(1), "MY_TEST_SPACE", "memtx",
+		uint64(0), map[interface{}]interface{}{}, []interface{}{}},
+		rows[1].Body[xlog.TupleKey])
+}
+
+func TestOpen_snap_zstd(t *testing.T) {
+	reader, err := xlog.Open("testdata/test.snap")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	assert.Equal(t, "SNAP", reader.Meta().Filetype)
+	rows := readRows(t, reader)
+	require.Len(t, rows, 515)
+	for i, row := range rows[:513] {
+		assert.Equal(t, xlog.InsertType, row.Header.Type)
+		assert.Equal(t, uint64(i), row.Header.LSN)
+	}
+	assert.Equal(t, xlog.RaftType, rows[513].Header.Type)
+	assert.Equal(t, xlog.RaftPromoteType, rows[514].Header.Type)
+	assert.Equal(t, []interface{}{"max_id", uint64(511)}, rows[1].Body[xlog.TupleKey])
+}
+
+func TestReader_Offset(t *testing.T) {
+	info, err := os.Stat("testdata/test.xlog")
+	require.NoError(t, err)
+	reader, err := xlog.Open("testdata/test.xlog")
+	require.NoError(t, err)
+	defer reader.Close()
+
+	meta := reader.Offset()
+	assert.Greater(t, meta, int64(0))
+	_, err = reader.Next()
+	require.NoError(t, err)
+	assert.Greater(t, reader.Offset(), meta)
+
+	readRows(t, reader)
+	assert.Equal(t, info.Size(), reader.Offset())
+}
+
+func TestOpen_not_exist(t *testing.T) {
+	_, err := xlog.Open("testdata/not_exist.xlog")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestNewReader_invalid_meta(t *testing.T) {
+	cases := []struct {
+		data string
+		err  string
+	}{
+		{"", "failed to read the meta: unexpected EOF"},
+		{"XLOG\n0.11\n\n", `unsupported file format version "0.11"`},
+		{"XLOG\n0.13\nVersion 2.8\n\n", `invalid meta line "Version 2.8"`},
+		{"XLOG\n0.13\nVClock: {1 2}\n\n", `invalid vclock component "1 2"`},
+		{"XLOG\n0.13\nVClock: {}\n", "failed to read the meta: unexpected EOF"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.data, func(t *testing.T) {
+			_, err := xlog.NewReader(strings.NewReader(c.data))
+			assert.EqualError(t, err, c.err)
+		})
+	}
+}
+
+func TestReader_Next_corrupted(t *testing.T) {
+	data, err := os.ReadFile("testdata/test.xlog")
+	require.NoError(t, err)
+	metaLen := bytes.Index(data, []byte("\n\n")) + 2
+
+	cases := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			"truncated block",
+			data[:len(data)-20],
+			"failed to read a block: unexpected EOF",
+		},
+		{
+			"invalid marker",
+			append(append([]byte{}, data[:metaLen]...), bytes.Repeat([]byte{1}, 19)...),
+			"invalid block marker 0x1010101",
+		},
+		{
+			"checksum mismatch",
+			append(append([]byte{}, data[:len(data)-5]...), 0, 0xd5, 0x10, 0xad, 0xed),
+			"block checksum mismatch",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			reader, err := xlog.NewReader(bytes.NewReader(c.data))
+			require.NoError(t, err)
+			for err == nil {
+				_, err = reader.Next()
+			}
+			assert.EqualError(t, err, c.err)
+			_, err = reader.Next()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestReader_Next_no_eof_marker(t *testing.T) {
+	data, err := os.ReadFile("testdata/test.xlog")
+	require.NoError(t, err)
+
+	reader, err := xlog.NewReader(bytes.NewReader(data[:len(data)-4]))
+	require.NoError(t, err)
+	assert.Len(t, readRows(t, reader), 2)
+}
+
+func TestParseVClock(t *testing.T) {
+	vclock, err := xlog.ParseVClock("{1: 10, 2: 5}")
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 10, 2: 5}, vclock)
+	assert.Equal(t, uint64(15), vclock.Signature())
+	assert.Equal(t, "{1: 10, 2: 5}", vclock.String())
+
+	vclock, err = xlog.ParseVClock(" {} ")
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{}, vclock)
+	assert.Equal(t, "{}", vclock.String())
+
+	_, err = xlog.ParseVClock("1: 10")
+	assert.EqualError(t, err, `invalid vclock "1: 10"`)
+	_, err = xlog.ParseVClock("{a: 10}")
+	assert.EqualError(t, err, `invalid vclock replica id "a"`)
+	_, err = xlog.ParseVClock("{1: -1}")
+	assert.EqualError(t, err, `invalid vclock lsn " -1"`)
+}
+
+func TestRequestType_String(t *testing.T) {
+	assert.Equal(t, "INSERT", xlog.InsertType.String())
+	assert.Equal(t, "NOP", xlog.NopType.String())
+	assert.Equal(t, "100", xlog.RequestType(100).String())
+
+	requestType, ok := xlog.ParseRequestType("Upsert")
+	assert.True(t, ok)
+	assert.Equal(t, xlog.UpsertType, requestType)
+	_, ok = xlog.ParseRequestType("select")
+	assert.False(t, ok)
+
+	assert.Equal(t, "space_id", xlog.BodyKeyName(xlog.SpaceIDKey))
+	assert.Equal(t, "100", xlog.BodyKeyName(100))
+}
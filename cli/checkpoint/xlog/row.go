@@ -0,0 +1,212 @@
+package xlog
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RequestType is a type of a row request.
+type RequestType uint64
+
+// Request types of rows.
+const (
+	InsertType       RequestType = 2
+	ReplaceType      RequestType = 3
+	UpdateType       RequestType = 4
+	DeleteType       RequestType = 5
+	UpsertType       RequestType = 9
+	NopType          RequestType = 12
+	RaftType         RequestType = 30
+	RaftPromoteType  RequestType = 31
+	RaftDemoteType   RequestType = 32
+	RaftConfirmType  RequestType = 40
+	RaftRollbackType RequestType = 41
+)
+
+// requestTypeNames contains names of the known request types.
+var requestTypeNames = map[RequestType]string{
+	InsertType:       "INSERT",
+	ReplaceType:      "REPLACE",
+	UpdateType:       "UPDATE",
+	DeleteType:       "DELETE",
+	UpsertType:       "UPSERT",
+	NopType:          "NOP",
+	RaftType:         "RAFT",
+	RaftPromoteType:  "PROMOTE",
+	RaftDemoteType:   "DEMOTE",
+	RaftConfirmType:  "CONFIRM",
+	RaftRollbackType: "ROLLBACK",
+}
+
+// String returns a name of the request type or its number if the type
+// is unknown.
+func (t RequestType) String() string {
+	if name, ok := requestTypeNames[t]; ok {
+		return name
+	}
+	return strconv.FormatUint(uint64(t), 10)
+}
+
+// ParseRequestType parses a name of the request type.
+func ParseRequestType(str string) (RequestType, bool) {
+	str = strings.ToUpper(str)
+	for t, name := range requestTypeNames {
+		if name == str {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// Header keys of a row.
+const (
+	typeKey      = 0x00
+	syncKey      = 0x01
+	replicaIDKey = 0x02
+	lsnKey       = 0x03
+	timestampKey = 0x04
+	groupIDKey   = 0x07
+	tsnKey       = 0x08
+	flagsKey     = 0x09
+)
+
+// Body keys of a row.
+const (
+	SpaceIDKey    = 0x10
+	IndexIDKey    = 0x11
+	IndexBaseKey  = 0x15
+	KeyKey        = 0x20
+	TupleKey      = 0x21
+	OperationsKey = 0x28
+)
+
+// bodyKeyNames contains names of the known body keys.
+var bodyKeyNames = map[uint64]string{
+	SpaceIDKey:    "space_id",
+	IndexIDKey:    "index_id",
+	0x12:          "limit",
+	0x13:          "offset",
+	0x14:          "iterator",
+	IndexBaseKey:  "index_base",
+	KeyKey:        "key",
+	TupleKey:      "tuple",
+	0x22:          "function_name",
+	0x23:          "user_name",
+	0x24:          "instance_uuid",
+	0x25:          "replicaset_uuid",
+	0x26:          "vclock",
+	0x27:          "expr",
+	OperationsKey: "operations",
+	0x29:          "ballot",
+	0x2a:          "tuple_meta",
+	0x2b:          "options",
+	0x2c:          "old_tuple",
+	0x2d:          "new_tuple",
+}
+
+// BodyKeyName returns a name of the body key or its number if the key
+// is unknown.
+func BodyKeyName(key uint64) string {
+	if name, ok := bodyKeyNames[key]; ok {
+		return name
+	}
+	return strconv.FormatUint(key, 10)
+}
+
+// Header flags of a row.
+const (
+	// FlagCommit marks the last row of a transaction.
+	FlagCommit = 0x01
+	// FlagWaitSync marks a row of a synchronous transaction.
+	FlagWaitSync = 0x02
+	// FlagWaitAck marks a row that waits for a replication quorum.
+	FlagWaitAck = 0x04
+)
+
+// Header is a header of a row.
+type Header struct {
+	// Type is a type of the request.
+	Type RequestType
+	// Sync is a request sync number.
+	Sync uint64
+	// ReplicaID is an identifier of the replica that made the row.
+	ReplicaID uint64
+	// LSN is a log sequence number of the row.
+	LSN uint64
+	// Timestamp is a time of the row in seconds since the epoch.
+	Timestamp float64
+	// GroupID is a replication group of the row.
+	GroupID uint64
+	// TSN is a transaction sequence number. It is equal to the LSN of the
+	// first row of the transaction.
+	TSN uint64
+	// Flags contains flags of the row.
+	Flags uint64
+	// Commit is true if the row is the last row of a transaction.
+	Commit bool
+}
+
+// Row is a row of a .snap/.xlog file.
+type Row struct {
+	// Header is a header of the row.
+	Header Header
+	// Body is a body of the row with iproto keys. It is nil if the row has
+	// no body.
+	Body map[uint64]interface{}
+	// BodySize is a size of the msgpack encoded body in bytes.
+	BodySize int
+	// Raw contains the msgpack encoded header and body of the row.
+	Raw []byte
+}
+
+// SpaceID returns a space identifier of the row.
+func (row Row) SpaceID() (uint64, bool) {
+	if id, ok := row.Body[SpaceIDKey]; ok {
+		if id, ok := toUint64(id); ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// IndexID returns an index identifier of the row. It is 0 (the primary index)
+// if the row has no index identifier.
+func (row Row) IndexID() uint64 {
+	id, _ := toUint64(row.Body[IndexIDKey])
+	return id
+}
+
+// IndexBase returns a base of field numbers in update operations of the row.
+// It is 0 if the row has no index base.
+func (row Row) IndexBase() uint64 {
+	base, _ := toUint64(row.Body[IndexBaseKey])
+	return base
+}
+
+// toUint64 converts a decoded msgpack integer to uint64. Positive
+// integers are decoded as uint64 and negative ones as int64.
+func toUint64(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case uint64:
+		return v, true
+	case int64:
+		if v >= 0 {
+			return uint64(v), true
+		}
+	}
+	return 0, false
+}
+
+// toFloat64 converts a decoded msgpack number to float64.
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	}
+	if v, ok := toUint64(value); ok {
+		return float64(v), true
+	}
+	return 0, false
+}
@@ -0,0 +1,146 @@
+package xlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+
+	"gopkg.in/vmihailenco/msgpack.v2"
+)
+
+// metaKeysOrder is an order of known meta keys in a written file.
+var metaKeysOrder = []string{"Version", "Instance", "Server", "VClock", "PrevVClock"}
+
+// Writer writes rows into a .snap/.xlog file. Blocks are written without
+// compression.
+type Writer struct {
+	// writer is a destination of the file data.
+	writer *bufio.Writer
+	// closer closes the file, if any.
+	closer io.Closer
+}
+
+// Create creates a file and writes the meta information.
+func Create(path string, meta Meta) (*Writer, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, err
+	}
+	writer, err := NewWriter(file, meta)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write %q: %w", path, err)
+	}
+	writer.closer = file
+	return writer, nil
+}
+
+// NewWriter creates a writer and writes the meta information.
+func NewWriter(w io.Writer, meta Meta) (*Writer, error) {
+	writer := &Writer{writer: bufio.NewWriter(w)}
+	if err := writer.writeMeta(meta); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// metaKeys returns keys of the meta fields in the order to write.
+func metaKeys(meta Meta) []string {
+	if meta.keys != nil {
+		return meta.keys
+	}
+	keys := make([]string, 0, len(meta.Fields))
+	for _, key := range metaKeysOrder {
+		if _, ok := meta.Fields[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	var other []string
+	for key := range meta.Fields {
+		known := false
+		for _, knownKey := range metaKeysOrder {
+			known = known || key == knownKey
+		}
+		if !known {
+			other = append(other, key)
+		}
+	}
+	sort.Strings(other)
+	return append(keys, other...)
+}
+
+// writeMeta writes the meta information.
+func (w *Writer) writeMeta(meta Meta) error {
+	if meta.Version == "" {
+		meta.Version = "0.13"
+	}
+	fmt.Fprintf(w.writer, "%s\n%s\n", meta.Filetype, meta.Version)
+	for _, key := range metaKeys(meta) {
+		fmt.Fprintf(w.writer, "%s: %s\n", key, meta.Fields[key])
+	}
+	_, err := w.writer.WriteString("\n")
+	return err
+}
+
+// WriteBlock writes the rows as a block. The rows must have raw data.
+// Rows of a transaction should be written in a single block.
+func (w *Writer) WriteBlock(rows []Row) error {
+	var size int
+	for _, row := range rows {
+		if row.Raw == nil {
+			return errors.New("failed to write a block: a row has no raw data")
+		}
+		size += len(row.Raw)
+	}
+	data := make([]byte, 0, size)
+	for _, row := range rows {
+		data = append(data, row.Raw...)
+	}
+
+	var fixHeader [fixHeaderSize]byte
+	binary.BigEndian.PutUint32(fixHeader[:4], rowMarker)
+	header := fixHeader[4:4]
+	for _, value := range []uint32{
+		uint32(len(data)),
+		// A checksum of the previous block is not used.
+		0,
+		^crc32.Update(^uint32(0), crc32cTable, data),
+	} {
+		encoded, err := msgpack.Marshal(value)
+		if err != nil {
+			return err
+		}
+		header = append(header, encoded...)
+	}
+	// The rest of the header is a padding string.
+	if padding := fixHeaderSize - 4 - len(header); padding > 0 {
+		header = append(header, 0xa0|byte(padding-1))
+	}
+
+	if _, err := w.writer.Write(fixHeader[:]); err != nil {
+		return err
+	}
+	_, err := w.writer.Write(data)
+	return err
+}
+
+// Close writes the end marker of the file and closes it.
+func (w *Writer) Close() error {
+	var marker [4]byte
+	binary.BigEndian.PutUint32(marker[:], eofMarker)
+	_, err := w.writer.Write(marker[:])
+	if err == nil {
+		err = w.writer.Flush()
+	}
+	if w.closer != nil {
+		if closeErr := w.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,72 @@
+package xlog_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/checkpoint/xlog"
+)
+
+func TestWriter_round_trip(t *testing.T) {
+	reader, err := xlog.Open("testdata/test.xlog")
+	require.NoError(t, err)
+	defer reader.Close()
+	rows := readRows(t, reader)
+
+	path := filepath.Join(t.TempDir(), "test.xlog")
+	writer, err := xlog.Create(path, reader.Meta())
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteBlock(rows[:1]))
+	require.NoError(t, writer.WriteBlock(rows[1:]))
+	require.NoError(t, writer.Close())
+
+	// The file is written in the same way as Tarantool does.
+	expected, err := os.ReadFile("testdata/test.xlog")
+	require.NoError(t, err)
+	actual, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	written, err := xlog.Open(path)
+	require.NoError(t, err)
+	defer written.Close()
+	assert.Equal(t, reader.Meta(), written.Meta())
+	assert.Equal(t, rows, readRows(t, written))
+
+	_, err = xlog.Create(path, reader.Meta())
+	assert.ErrorContains(t, err, "file exists")
+}
+
+func TestWriter_meta(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := xlog.NewWriter(&buf, xlog.Meta{
+		Filetype: "XLOG",
+		Fields: map[string]string{
+			"PrevVClock": "{1: 1}",
+			"VClock":     "{1: 2}",
+			"Instance":   "8fb65242-878b-4dc6-a07b-444ae3decc18",
+			"Version":    "2.11.0",
+			"Custom":     "value",
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	assert.Equal(t, "XLOG\n0.13\nVersion: 2.11.0\n"+
+		"Instance: 8fb65242-878b-4dc6-a07b-444ae3decc18\n"+
+		"VClock: {1: 2}\nPrevVClock: {1: 1}\nCustom: value\n\n\xd5\x10\xad\xed", buf.String())
+
+	reader, err := xlog.NewReader(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, xlog.VClock{1: 2}, reader.Meta().VClock)
+	assert.Empty(t, readRows(t, reader))
+
+	writer, err = xlog.NewWriter(&bytes.Buffer{}, xlog.Meta{Filetype: "XLOG"})
+	require.NoError(t, err)
+	assert.EqualError(t, writer.WriteBlock([]xlog.Row{{}}),
+		"failed to write a block: a row has no raw data")
+}
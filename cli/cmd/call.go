@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/call"
+	"github.com/tarantool/tt/cli/cmd/internal"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/connect"
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/formatter"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/running"
+	"github.com/tarantool/tt/cli/util"
+	libconnect "github.com/tarantool/tt/lib/connect"
+)
+
+var (
+	callUser        string
+	callPassword    string
+	callFormat      string
+	callSslKeyFile  string
+	callSslCertFile string
+	callSslCaFile   string
+	callSslCiphers  string
+	callBinary      bool
+	callMode        string
+)
+
+// NewCallCmd creates call command.
+func NewCallCmd() *cobra.Command {
+	var callCmd = &cobra.Command{
+		Use: "call (<APP_NAME:INSTANCE_NAME> | <URI> | --mode <MODE> <APP_NAME>)" +
+			" <FUNCTION> [ARGS...] [flags]\n\n" +
+			"  The URI can be specified in the following formats:\n" +
+			"  * [tcp://][username:password@][host:port]\n" +
+			"  * [unix://][username:password@]socketpath\n" +
+			"  * @profile - a connection profile, see `tt profile`\n" +
+			"  To specify relative path without `unix://` use `./`.",
+		Short: "Call a stored function on the tarantool instance",
+		Long: "Call a stored function on the tarantool instance.\n\n" +
+			"The function is called via IPROTO_CALL request if the binary port is used." +
+			" It requires only 'execute' privilege on the function.\n\n" +
+			"Arguments are decoded from JSON or YAML, a plain word is passed as a string:\n\n" +
+			`tt call localhost:3013 box.space.test:select '[1]' '{"iterator": "GE"}'` + "\n\n" +
+			"With --mode the function is called on an instance of the application" +
+			" in the mode: rw, ro, prefer_ro or any. The mode of each instance is" +
+			" checked before the call, so the call is routed to the current master" +
+			" in rw mode.\n\n" +
+			libconnect.EnvCredentialsHelp,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalCallModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.MinimumNArgs(2),
+		ValidArgsFunction: func(
+			cmd *cobra.Command,
+			args []string,
+			toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			validArgs, _ := internal.ValidArgsFunction(
+				cliOpts, &cmdCtx, cmd, toComplete,
+				running.ExtractActiveAppNames,
+				running.ExtractActiveInstanceNames)
+			return validArgs, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	callCmd.Flags().StringVarP(&callUser, "username", "u", "", "username")
+	callCmd.Flags().StringVarP(&callPassword, "password", "p", "", "password")
+	callCmd.Flags().StringVarP(&callFormat, "outputformat", "x",
+		formatter.DefaultFormat.String(), `output format: yaml, lua, table or ttable`)
+	callCmd.Flags().StringVar(&callSslKeyFile, "sslkeyfile", "",
+		`path to a private SSL key file`)
+	callCmd.Flags().StringVar(&callSslCertFile, "sslcertfile", "",
+		`path to an SSL certificate file`)
+	callCmd.Flags().StringVar(&callSslCaFile, "sslcafile", "",
+		`path to a trusted certificate authorities (CA) file`)
+	callCmd.Flags().StringVar(&callSslCiphers, "sslciphers", "",
+		`colon-separated (:) list of SSL cipher suites the connection`)
+	callCmd.Flags().BoolVarP(&callBinary, "binary", "",
+		false, `connect to instance using binary port`)
+	callCmd.Flags().StringVar(&callMode, "mode", "",
+		`call on an instance of the application in the mode: rw, ro, prefer_ro or any`)
+
+	return callCmd
+}
+
+// internalCallModule is a default call module.
+func internalCallModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	connectCtx := connect.ConnectCtx{
+		Username:    callUser,
+		Password:    callPassword,
+		SslKeyFile:  callSslKeyFile,
+		SslCertFile: callSslCertFile,
+		SslCaFile:   callSslCaFile,
+		SslCiphers:  callSslCiphers,
+		Binary:      callBinary,
+	}
+
+	callCtx := call.CallCtx{
+		FormatOpts: formatter.Opts{
+			Graphics:       true,
+			ColumnWidthMax: 0,
+			TableDialect:   formatter.DefaultTableDialect,
+		},
+	}
+	var ok bool
+	if callCtx.Format, ok = formatter.ParseFormat(callFormat); !ok {
+		return util.NewArgError(fmt.Sprintf("unsupported output format: %s", callFormat))
+	}
+
+	var conn connector.Connector
+	if callMode != "" {
+		mode, ok := connector.ParseMode(callMode)
+		if !ok {
+			return util.NewArgError(fmt.Sprintf("unsupported mode: %s", callMode))
+		}
+		pool, err := connectCallPool(cmdCtx, connectCtx, mode, args[0])
+		if err != nil {
+			return err
+		}
+		conn = pool
+	} else {
+		connOpts, newArgs, err := resolveConnectOpts(cmdCtx, cliOpts, &connectCtx, args)
+		if err != nil {
+			return err
+		}
+		args = append([]string{args[0]}, newArgs...)
+		if conn, err = connector.Connect(connOpts); err != nil {
+			return fmt.Errorf("unable to establish connection: %s", err)
+		}
+	}
+	defer conn.Close()
+	callCtx.FuncName = args[1]
+	callCtx.Args = args[2:]
+
+	output, err := call.Call(callCtx, conn)
+	if err != nil {
+		return err
+	}
+	// "Print" is used instead of "log..." to print the result without
+	// any decoration.
+	fmt.Print(output)
+	return nil
+}
+
+// connectCallPool connects to the instances of the application with
+// a role-aware pool.
+func connectCallPool(cmdCtx *cmdcontext.CmdCtx, connectCtx connect.ConnectCtx,
+	mode connector.Mode, appName string) (*connector.RolePool, error) {
+	var runningCtx running.RunningCtx
+	if err := running.FillCtx(cliOpts, cmdCtx, &runningCtx, []string{appName}); err != nil {
+		return nil, err
+	}
+	if (connectCtx.Username != "" || connectCtx.Password != "") && !connectCtx.Binary {
+		return nil, fmt.Errorf("username and password are not supported" +
+			" with a connection via a control socket")
+	}
+
+	var opts []connector.ConnectOpts
+	for _, instance := range runningCtx.Instances {
+		address := instance.ConsoleSocket
+		if connectCtx.Binary {
+			address = instance.BinaryPort
+		}
+		opts = append(opts, makeConnOpts(connector.UnixNetwork, address, connectCtx))
+	}
+	pool, err := connector.ConnectRolePool(opts, connector.RolePoolOpts{Mode: mode})
+	if err != nil {
+		return nil, fmt.Errorf("unable to establish connection: %s", err)
+	}
+	return pool, nil
+}
@@ -11,9 +11,22 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/tarantool/tt/cli/checkpoint"
 	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/connector"
 	"github.com/tarantool/tt/cli/modules"
 	"github.com/tarantool/tt/cli/util"
 	"github.com/tarantool/tt/cli/version"
+	libconnect "github.com/tarantool/tt/lib/connect"
+)
+
+var (
+	// catSince contains since flag.
+	catSince string
+	// catUntil contains until flag.
+	catUntil string
+	// catKey contains key flag.
+	catKey string
+	// catDecodeUri contains decode-uri flag.
+	catDecodeUri string
 )
 
 // catFlags contains flags for cat command.
@@ -32,8 +45,23 @@ func NewCatCmd() *cobra.Command {
 	var catCmd = &cobra.Command{
 		Use:   "cat <FILE>...",
 		Short: "Print into stdout the contents of .snap/.xlog files",
+		Example: `
+# Print insert and replace operations of the last hour in JSON Lines.
+
+	$ tt cat 00000000000000000000.xlog --format json --type insert,replace \
+	    --since "$(date -d '1 hour ago' +%s)"
+
+# Print operations with a primary key prefix and tuples decoded by space formats
+# from the snapshot.
+
+	$ tt cat 00000000000000000000.snap 00000000000000000010.xlog --space 512 \
+	    --key '[1, "a"]' --decode`,
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdCtx.CommandName = cmd.Name()
+			if catFlags.Stats && !cmd.Flags().Changed("format") {
+				// The default format of the statistics is a table.
+				catFlags.Format = "table"
+			}
 			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
 				internalCatModule, args)
 			util.HandleCmdErr(cmd, err)
@@ -47,11 +75,32 @@ func NewCatCmd() *cobra.Command {
 	catCmd.Flags().IntSliceVar(&catFlags.Space, "space", catFlags.Space,
 		"Filter the output by space number. May be passed more than once")
 	catCmd.Flags().StringVar(&catFlags.Format, "format", catFlags.Format,
-		"Output format yaml, json or lua")
+		"Output format yaml, json (JSON Lines) or lua. Statistics are printed as table"+
+			" or json")
 	catCmd.Flags().IntSliceVar(&catFlags.Replica, "replica", catFlags.Replica,
 		"Filter the output by replica id. May be passed more than once")
 	catCmd.Flags().BoolVar(&catFlags.ShowSystem, "show-system", catFlags.ShowSystem,
 		"Show the contents of system spaces")
+	catCmd.Flags().BoolVar(&catFlags.UseTarantool, "use-tarantool", catFlags.UseTarantool,
+		"Read the files with a Tarantool executable instead of the built-in reader")
+	catCmd.Flags().StringVar(&catSince, "since", "",
+		"Show operations starting from the given time: RFC3339, date-time or Unix time")
+	catCmd.Flags().StringVar(&catUntil, "until", "",
+		"Show operations before the given time: RFC3339, date-time or Unix time")
+	catCmd.Flags().StringSliceVar(&catFlags.Type, "type", catFlags.Type,
+		"Filter the output by request type: insert, replace, update, delete or upsert."+
+			" May be passed more than once")
+	catCmd.Flags().StringVar(&catKey, "key", "",
+		"Filter the output by a primary key prefix in JSON or YAML, e.g. '[1, \"a\"]'."+
+			" The first tuple fields are used if the primary key of a space is unknown")
+	catCmd.Flags().BoolVar(&catFlags.Decode, "decode", catFlags.Decode,
+		"Decode tuples into maps with field names. Space formats are taken from"+
+			" _space and _index records of the files")
+	catCmd.Flags().StringVar(&catDecodeUri, "decode-uri", "",
+		"Fetch space formats from an instance to decode tuples, implies --decode")
+	catCmd.Flags().BoolVar(&catFlags.Stats, "stats", catFlags.Stats,
+		"Print statistics per space and operation type, per replica and per file"+
+			" instead of the records")
 
 	return catCmd
 }
@@ -62,6 +111,27 @@ func internalCatModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 		return fmt.Errorf("it is required to specify at least one .xlog or .snap file")
 	}
 
+	if err := fillCatFilters(); err != nil {
+		return err
+	}
+	if !catFlags.UseTarantool {
+		if catDecodeUri != "" {
+			schema, err := fetchCatSchema(catDecodeUri)
+			if err != nil {
+				return err
+			}
+			catFlags.Schema = schema
+			catFlags.Decode = true
+		}
+		log.Infof("Running cat with files: %s\n", args)
+		return checkpoint.CatNative(args, catFlags)
+	}
+	if !catFlags.Since.IsZero() || !catFlags.Until.IsZero() || catFlags.Type != nil ||
+		catFlags.Key != nil || catFlags.Decode || catDecodeUri != "" || catFlags.Stats {
+		return fmt.Errorf("--since, --until, --type, --key, --decode, --decode-uri and" +
+			" --stats are not supported with --use-tarantool")
+	}
+
 	// List of files is passed to lua cat script via environment variable in json format.
 	filesJson, err := json.Marshal(args)
 	if err != nil {
@@ -103,3 +173,65 @@ func internalCatModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 
 	return nil
 }
+
+// fillCatFilters parses filters of cat command.
+func fillCatFilters() error {
+	var err error
+	if catSince != "" {
+		if catFlags.Since, err = checkpoint.ParseTime(catSince); err != nil {
+			return err
+		}
+	}
+	if catUntil != "" {
+		if catFlags.Until, err = checkpoint.ParseTime(catUntil); err != nil {
+			return err
+		}
+	}
+	if catKey != "" {
+		if catFlags.Key, err = checkpoint.ParseKey(catKey); err != nil {
+			return err
+		}
+	}
+	return checkpoint.ValidateTypes(catFlags.Type)
+}
+
+// fetchCatSchema fetches space formats from an instance.
+func fetchCatSchema(uri string) (*checkpoint.Schema, error) {
+	username := os.Getenv(libconnect.TarantoolUsernameEnv)
+	password := os.Getenv(libconnect.TarantoolPasswordEnv)
+	if libconnect.IsProfileURI(uri) {
+		prof, err := loadProfile(uri)
+		if err != nil {
+			return nil, err
+		}
+		uri, username, password = prof.URI, prof.Username, prof.Password
+	}
+	connOpts, err := makeCheckpointConnOpts(uri, username, password)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := connector.Connect(connOpts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %q: %w", uri, err)
+	}
+	defer conn.Close()
+	return checkpoint.FetchSchema(conn)
+}
+
+// makeCheckpointConnOpts makes connection options for cat and play commands.
+// Credentials of the URI are preferred.
+func makeCheckpointConnOpts(uri, username, password string) (connector.ConnectOpts, error) {
+	if libconnect.IsCredentialsURI(uri) {
+		uri, username, password = libconnect.ParseCredentialsURI(uri)
+	}
+	if !libconnect.IsBaseURI(uri) {
+		return connector.ConnectOpts{}, fmt.Errorf("invalid URI %q", uri)
+	}
+	network, address := libconnect.ParseBaseURI(uri)
+	return connector.ConnectOpts{
+		Network:  network,
+		Address:  address,
+		Username: username,
+		Password: password,
+	}, nil
+}
@@ -95,6 +95,9 @@ You could also specify etcd/tarantool username and password with environment var
 * %s - specifies a tarantool username
 * %s - specifies a tarantool password
 
+The URI could also be a reference to a connection profile: @profile, see
+"tt profile". Etcd credentials from the profile are used as URL credentials.
+
 The priority of credentials:
 environment variables < command flags < URL credentials.
 `, float64(clustercmd.DefaultUriTimeout)/float64(time.Second),
@@ -124,6 +127,9 @@ You could also specify etcd/tarantool username and password with environment var
 * %s - specifies a tarantool username
 * %s - specifies a tarantool password
 
+The URI could also be a reference to a connection profile: @profile, see
+"tt profile". Etcd credentials from the profile are used as URL credentials.
+
 The priority of credentials:
 environment variables < command flags < URL credentials.
 `, float64(clustercmd.DefaultUriTimeout)/float64(time.Second),
@@ -416,6 +422,8 @@ func internalClusterShowModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 
 	if uri, err := parseUrl(args[0]); err == nil {
 		return clustercmd.ShowUri(showCtx, uri)
+	} else if libconnect.IsProfileURI(args[0]) {
+		return err
 	}
 
 	// It looks like an application or an application:instance.
@@ -449,6 +457,8 @@ func internalClusterPublishModule(cmdCtx *cmdcontext.CmdCtx, args []string) erro
 
 	if uri, err := parseUrl(args[0]); err == nil {
 		return clustercmd.PublishUri(publishCtx, uri)
+	} else if libconnect.IsProfileURI(args[0]) {
+		return err
 	}
 
 	// It looks like an application or an application:instance.
@@ -581,9 +591,12 @@ func readSourceFile(path string) ([]byte, *libcluster.Config, error) {
 	return data, config, nil
 }
 
-// parseUrl returns a URL, nil if string could be recognized as a URL,
-// otherwise nil, an error.
+// parseUrl returns a URL, nil if string could be recognized as a URL or
+// a reference to a connection profile, otherwise nil, an error.
 func parseUrl(str string) (*url.URL, error) {
+	if libconnect.IsProfileURI(str) {
+		return parseProfileUrl(str)
+	}
 	uri, err := url.Parse(str)
 
 	// The URL general form represented is:
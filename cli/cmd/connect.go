@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"syscall"
 
 	"github.com/apex/log"
@@ -32,6 +34,8 @@ var (
 	connectSslCiphers  string
 	connectInteractive bool
 	connectBinary      bool
+	connectArgs        []string
+	connectOnError     string
 )
 
 // NewConnectCmd creates connect command.
@@ -46,12 +50,17 @@ func NewConnectCmd() *cobra.Command {
 			"  The URI can be specified in the following formats:\n" +
 			"  * [tcp://][username:password@][host:port]\n" +
 			"  * [unix://][username:password@]socketpath\n" +
+			"  * @profile - a connection profile, see `tt profile`\n" +
 			"  To specify relative path without `unix://` use `./`.\n\n" +
 			"  Available commands:\n" +
 			"  * \\shortcuts - get the full list of available shortcuts\n" +
 			"  * \\set language <language> - set language (lua or sql)\n" +
 			"  * \\set output <format> - set output format (lua[,line|block] or yaml)\n" +
 			"  * \\set delimiter <delimiter> - set expression delimiter\n" +
+			"  * \\set pager <command|on|off> - set pager for a long output\n" +
+			"  * \\set color <on|off|dark|light> - set syntax highlighting\n" +
+			"  * \\connect <URI|APP_NAME:INSTANCE_NAME> - connect to another instance\n" +
+			"  * \\reconnect - reconnect to the current instance\n" +
 			"  * \\help - show available backslash commands\n" +
 			"  * \\quit - quit interactive console",
 		Short: "Connect to the tarantool instance",
@@ -59,7 +68,14 @@ func NewConnectCmd() *cobra.Command {
 			libconnect.EnvCredentialsHelp + "\n\n" +
 			"You could pass command line arguments to the interpreted SCRIPT" +
 			" or COMMAND passed via -f flag:\n\n" +
-			`echo "print(...)" | tt connect user:pass@localhost:3013 -f- 1, 2, 3`,
+			`echo "print(...)" | tt connect user:pass@localhost:3013 -f- 1, 2, 3` + "\n\n" +
+			"The arguments are also available in the arg table of the SCRIPT with" +
+			" named arguments passed via --arg flag:\n\n" +
+			`echo "print(arg[1], arg.mode)" | tt connect localhost:3013 -f- --arg mode=fast 1` +
+			"\n\n" +
+			"If the evaluation raises an error or the script has a syntax error, " +
+			"tt exits with the code " +
+			strconv.Itoa(connect.EvalErrorExitCode) + ".",
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdCtx.CommandName = cmd.Name()
 			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
@@ -102,6 +118,12 @@ func NewConnectCmd() *cobra.Command {
 		false, `enter interactive mode after executing 'FILE'`)
 	connectCmd.Flags().BoolVarP(&connectBinary, "binary", "",
 		false, `connect to instance using binary port`)
+	connectCmd.Flags().StringArrayVar(&connectArgs, "arg", nil,
+		`named argument key=value for the script passed via -f, could be repeated`)
+	connectCmd.Flags().StringVar(&connectOnError, "on-error", "",
+		`evaluate the script passed via -f statement by statement: `+
+			`stop or continue on an error. Each statement is a separate chunk, `+
+			`so local variables are not visible in the next statements`)
 
 	return connectCmd
 }
@@ -124,13 +146,23 @@ func makeConnOpts(network, address string, connCtx connect.ConnectCtx) connector
 }
 
 // resolveConnectOpts tries to resolve the first passed argument as an instance
-// name to replace it with a control socket or as a URI with/without
-// credentials.
+// name to replace it with a control socket, as a URI with/without
+// credentials or as a reference to a connection profile.
 // It returns connection options and the remaining args.
 func resolveConnectOpts(cmdCtx *cmdcontext.CmdCtx, cliOpts *config.CliOpts,
 	connectCtx *connect.ConnectCtx, args []string) (
 	connOpts connector.ConnectOpts, newArgs []string, err error) {
 
+	if libconnect.IsProfileURI(args[0]) {
+		prof, profErr := loadProfile(args[0])
+		if profErr != nil {
+			err = profErr
+			return
+		}
+		applyConnectProfile(connectCtx, prof)
+		args = append([]string{prof.URI}, args[1:]...)
+	}
+
 	newArgs = args[1:]
 	// FillCtx returns error if no instances found.
 	var runningCtx running.RunningCtx
@@ -185,6 +217,34 @@ func resolveConnectOpts(cmdCtx *cmdcontext.CmdCtx, cliOpts *config.CliOpts,
 	return
 }
 
+// makeResolveTargetFunc returns a function to resolve a new connection target
+// for the console.
+func makeResolveTargetFunc(cmdCtx *cmdcontext.CmdCtx) connect.ResolveTargetFunc {
+	return func(connectCtx *connect.ConnectCtx, target string) (connector.ConnectOpts, error) {
+		// Credentials from a previous target must not be used.
+		connectCtx.Username = connectUser
+		connectCtx.Password = connectPassword
+		connectCtx.SslKeyFile = connectSslKeyFile
+		connectCtx.SslCertFile = connectSslCertFile
+		connectCtx.SslCaFile = connectSslCaFile
+		connectCtx.SslCiphers = connectSslCiphers
+		connOpts, _, err := resolveConnectOpts(cmdCtx, cliOpts, connectCtx, []string{target})
+		return connOpts, err
+	}
+}
+
+// makeListTargetsFunc returns a function to list running instances for the
+// console.
+func makeListTargetsFunc(cmdCtx *cmdcontext.CmdCtx) func() []string {
+	return func() []string {
+		var runningCtx running.RunningCtx
+		if err := running.FillCtx(cliOpts, cmdCtx, &runningCtx, []string{}); err != nil {
+			return nil
+		}
+		return running.ExtractActiveInstanceNames(runningCtx.Instances)
+	}
+}
+
 // internalConnectModule is a default connect module.
 func internalConnectModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 	connectCtx := connect.ConnectCtx{
@@ -198,6 +258,11 @@ func internalConnectModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 		Interactive: connectInteractive,
 		Binary:      connectBinary,
 	}
+	connectCtx.ResolveTarget = makeResolveTargetFunc(cmdCtx)
+	connectCtx.ListTargets = makeListTargetsFunc(cmdCtx)
+	if cliOpts.Console != nil {
+		connectCtx.Pager = cliOpts.Console.Pager
+	}
 
 	var ok bool
 	if connectCtx.Language, ok = connect.ParseLanguage(connectLanguage); !ok {
@@ -206,6 +271,16 @@ func internalConnectModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 	if connectCtx.Format, ok = formatter.ParseFormat(connectFormat); !ok {
 		return util.NewArgError(fmt.Sprintf("unsupported output format: %s", connectFormat))
 	}
+	if connectCtx.ErrorMode, ok = connect.ParseErrorMode(connectOnError); !ok {
+		return util.NewArgError(fmt.Sprintf("unsupported error mode: %s", connectOnError))
+	}
+	if connectFile == "" && (len(connectArgs) != 0 || connectOnError != "") {
+		return util.NewArgError("--arg and --on-error flags require -f flag")
+	}
+	var err error
+	if connectCtx.ScriptArgs, err = connect.ParseScriptArgs(connectArgs); err != nil {
+		return util.NewArgError(err.Error())
+	}
 
 	connOpts, newArgs, err := resolveConnectOpts(cmdCtx, cliOpts, &connectCtx, args)
 	if err != nil {
@@ -214,12 +289,16 @@ func internalConnectModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 
 	if connectFile != "" {
 		res, err := connect.Eval(connectCtx, connOpts, newArgs)
-		if err != nil {
+		if res != nil {
+			// "Println" is used instead of "log..." to print the result without
+			// any decoration.
+			fmt.Println(string(res))
+		}
+		if errors.Is(err, connect.ErrEvalFailed) {
+			return util.NewExitCodeError(connect.EvalErrorExitCode, err)
+		} else if err != nil {
 			return err
 		}
-		// "Println" is used instead of "log..." to print the result without
-		// any decoration.
-		fmt.Println(string(res))
 		if !connectInteractive || !terminal.IsTerminal(syscall.Stdin) {
 			return nil
 		}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/apex/log"
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/checkpoint"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/connect"
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/util"
+	libconnect "github.com/tarantool/tt/lib/connect"
+)
+
+var (
+	// exportUser contains username flag.
+	exportUser string
+	// exportPassword contains password flag.
+	exportPassword string
+	// exportWhere contains where flag.
+	exportWhere string
+	// exportOutput contains output flag.
+	exportOutput string
+	// exportOpts contains options of export command.
+	exportOpts = checkpoint.ExportOpts{
+		Format:   "jsonl",
+		PageSize: 1000,
+	}
+)
+
+// NewExportCmd creates a new export command.
+func NewExportCmd() *cobra.Command {
+	var exportCmd = &cobra.Command{
+		Use: "export (<APP_NAME:INSTANCE_NAME> | <URI> | <FILE.snap>) --space <NAME>" +
+			" [flags]\n\n" +
+			"  The URI can be specified in the following formats:\n" +
+			"  * [tcp://][username:password@][host:port]\n" +
+			"  * [unix://][username:password@]socketpath\n" +
+			"  * @profile - a connection profile, see `tt profile`\n" +
+			"  To specify relative path without `unix://` use `./`.",
+		Short: "Export tuples of a space to JSON Lines or CSV",
+		Long: "Export tuples of a space to JSON Lines or CSV.\n\n" +
+			"The tuples of an instance are fetched by pages through the primary index" +
+			" via the binary port. The tuples of a .snap file are read directly." +
+			" Fields are named by the space format.\n\n" +
+			libconnect.EnvCredentialsHelp,
+		Example: `
+# Export a space of an instance of the application.
+
+	$ tt export app:storage-001 --space customers > customers.jsonl
+
+# Export tuples with a secondary index key from a snapshot to CSV.
+
+	$ tt export 00000000000000000010.snap --space customers --format csv \
+	    --where 'age>=30' --output customers.csv`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalExportModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	exportCmd.Flags().StringVar(&exportOpts.Space, "space", "", "Name of the space to export")
+	exportCmd.Flags().StringVar(&exportOpts.Format, "format", exportOpts.Format,
+		"Output format: jsonl (JSON Lines) or csv")
+	exportCmd.Flags().StringVar(&exportWhere, "where", "",
+		"Export tuples with an index key matching the condition <index><op><key>, where"+
+			" <op> is one of =, >, >=, <, <= and the key is in JSON or YAML,"+
+			" e.g. 'primary>=[10]'")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "",
+		"Write the tuples to the file instead of stdout")
+	exportCmd.Flags().IntVar(&exportOpts.PageSize, "page-size", exportOpts.PageSize,
+		"Number of tuples to fetch from an instance per request")
+	exportCmd.Flags().StringVarP(&exportUser, "username", "u", "", "username")
+	exportCmd.Flags().StringVarP(&exportPassword, "password", "p", "", "password")
+
+	return exportCmd
+}
+
+// internalExportModule is a default export module.
+func internalExportModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	if exportOpts.Space == "" {
+		return fmt.Errorf("the space to export must be specified with --space")
+	}
+	if exportOpts.PageSize <= 0 {
+		return fmt.Errorf("the page size must be positive")
+	}
+	if exportWhere != "" {
+		var err error
+		if exportOpts.Where, err = checkpoint.ParseWhere(exportWhere); err != nil {
+			return err
+		}
+	}
+
+	export := func(w io.Writer) (int, error) {
+		return checkpoint.ExportSnapshot(args[0], exportOpts, w)
+	}
+	if filepath.Ext(args[0]) != ".snap" {
+		connectCtx := connect.ConnectCtx{
+			Username: exportUser,
+			Password: exportPassword,
+			Binary:   true,
+		}
+		connOpts, _, err := resolveConnectOpts(cmdCtx, cliOpts, &connectCtx, args)
+		if err != nil {
+			return err
+		}
+		conn, err := connector.Connect(connOpts)
+		if err != nil {
+			return fmt.Errorf("unable to establish connection: %s", err)
+		}
+		defer conn.Close()
+		export = func(w io.Writer) (int, error) {
+			return checkpoint.ExportInstance(conn, exportOpts, w)
+		}
+	}
+
+	w := io.Writer(os.Stdout)
+	if exportOutput != "" {
+		file, err := os.Create(exportOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create %q: %w", exportOutput, err)
+		}
+		defer file.Close()
+		w = file
+	}
+	count, err := export(w)
+	if err != nil {
+		return err
+	}
+	log.Infof("Exported %d tuples of space %q", count, exportOpts.Space)
+	return nil
+}
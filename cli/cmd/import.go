@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/checkpoint"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/connect"
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/util"
+	libconnect "github.com/tarantool/tt/lib/connect"
+)
+
+var (
+	// importUser contains username flag.
+	importUser string
+	// importPassword contains password flag.
+	importPassword string
+	// importErrors contains errors flag.
+	importErrors string
+	// importOpts contains options of import command.
+	importOpts = checkpoint.ImportOpts{
+		BatchSize:  1000,
+		OnConflict: "fail",
+	}
+)
+
+// NewImportCmd creates a new import command.
+func NewImportCmd() *cobra.Command {
+	var importCmd = &cobra.Command{
+		Use: "import (<APP_NAME:INSTANCE_NAME> | <URI>) <FILE> --space <NAME> [flags]\n\n" +
+			"  The URI can be specified in the following formats:\n" +
+			"  * [tcp://][username:password@][host:port]\n" +
+			"  * [unix://][username:password@]socketpath\n" +
+			"  * @profile - a connection profile, see `tt profile`\n" +
+			"  To specify relative path without `unix://` use `./`.",
+		Short: "Import tuples into a space from CSV or JSON Lines",
+		Long: "Import tuples into a space from CSV or JSON Lines.\n\n" +
+			"Columns are mapped to the space fields by the CSV header or JSON object keys," +
+			" or by the --fields mapping. Values are converted according to the space" +
+			" format: empty CSV values are nulls, arrays and maps are in JSON, datetime" +
+			" is in RFC3339, date-time or Unix time. The tuples are sent in batches," +
+			" each batch is inserted in a transaction via the binary port. Rejected" +
+			" rows are written to the error report as JSON Lines with the reasons.\n\n" +
+			libconnect.EnvCredentialsHelp,
+		Example: `
+# Import a CSV file with a header into a space of an instance of the application.
+
+	$ tt import app:storage-001 customers.csv --space customers
+
+# Import a CSV file without a header, replace existing tuples.
+
+	$ tt import localhost:3301 customers.csv --space customers --no-header \
+	    --fields 1:id,3:name --on-conflict replace`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalImportModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	importCmd.Flags().StringVar(&importOpts.Space, "space", "",
+		"Name of the space to import into")
+	importCmd.Flags().StringVar(&importOpts.Format, "format", "",
+		"Input format: csv or jsonl (JSON Lines). It is detected by the file extension"+
+			" by default")
+	importCmd.Flags().StringSliceVar(&importOpts.Fields, "fields", nil,
+		"Map columns to fields: <column>:<field>, where a column is a header name or"+
+			" a number starting from 1 and a field is a name or a number. Other columns"+
+			" are ignored")
+	importCmd.Flags().BoolVar(&importOpts.NoHeader, "no-header", false,
+		"The first CSV row contains data, columns are mapped to fields by numbers")
+	importCmd.Flags().IntVar(&importOpts.BatchSize, "batch-size", importOpts.BatchSize,
+		"Number of tuples inserted in a transaction")
+	importCmd.Flags().StringVar(&importOpts.OnConflict, "on-conflict", importOpts.OnConflict,
+		"Action on a duplicate key: skip the row, replace the tuple or fail. On fail"+
+			" the current batch is rolled back and the import is stopped")
+	importCmd.Flags().StringVar(&importErrors, "errors", "",
+		"File of the error report, <FILE>.errors.jsonl by default. An existing file is"+
+			" not overwritten")
+	importCmd.Flags().StringVarP(&importUser, "username", "u", "", "username")
+	importCmd.Flags().StringVarP(&importPassword, "password", "p", "", "password")
+
+	return importCmd
+}
+
+// internalImportModule is a default import module.
+func internalImportModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	if importOpts.Space == "" {
+		return fmt.Errorf("the space to import into must be specified with --space")
+	}
+	if importOpts.BatchSize <= 0 {
+		return fmt.Errorf("the batch size must be positive")
+	}
+	path := args[1]
+	if importOpts.Format == "" {
+		importOpts.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+		if importOpts.Format == "ndjson" {
+			importOpts.Format = "jsonl"
+		}
+	}
+	if importErrors == "" {
+		importErrors = path + ".errors.jsonl"
+	}
+
+	input, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	connectCtx := connect.ConnectCtx{
+		Username: importUser,
+		Password: importPassword,
+		Binary:   true,
+	}
+	connOpts, _, err := resolveConnectOpts(cmdCtx, cliOpts, &connectCtx, args[:1])
+	if err != nil {
+		return err
+	}
+	conn, err := connector.Connect(connOpts)
+	if err != nil {
+		return fmt.Errorf("unable to establish connection: %s", err)
+	}
+	defer conn.Close()
+
+	// An existing file is not overwritten, so the created report is removed
+	// if there are no rejected rows.
+	report, err := os.OpenFile(importErrors, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		return fmt.Errorf("the error report %q already exists, remove it or specify"+
+			" another file with --errors", importErrors)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create %q: %w", importErrors, err)
+	}
+	result, importErr := checkpoint.Import(conn, input, importOpts, report)
+	report.Close()
+	if result.Rejected == 0 {
+		os.Remove(importErrors)
+	}
+
+	log.Infof("Imported %d tuples, skipped %d duplicates, rejected %d rows",
+		result.Imported, result.Skipped, result.Rejected)
+	if result.Rejected > 0 {
+		log.Warnf("The rejected rows are written to %q", importErrors)
+	}
+	return importErr
+}
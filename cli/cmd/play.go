@@ -33,6 +33,8 @@ var (
 	playUsername string
 	// playPassword contains password flag.
 	playPassword string
+	// playSpaceMap contains space-map flag.
+	playSpaceMap []string
 )
 
 // NewPlayCmd creates a new play command.
@@ -60,6 +62,18 @@ func NewPlayCmd() *cobra.Command {
 		"Filter the output by replica id. May be passed more than once")
 	playCmd.Flags().BoolVar(&playFlags.ShowSystem, "show-system", playFlags.ShowSystem,
 		"Show the contents of system spaces")
+	playCmd.Flags().BoolVar(&playFlags.UseTarantool, "use-tarantool", playFlags.UseTarantool,
+		"Read the files with a Tarantool executable instead of the built-in reader")
+	playCmd.Flags().BoolVar(&playFlags.DryRun, "dry-run", playFlags.DryRun,
+		"Count records to play per space without sending them")
+	playCmd.Flags().Float64Var(&playFlags.Rate, "rate", playFlags.Rate,
+		"Maximum number of operations per second, 0 means no limit")
+	playCmd.Flags().StringVar(&playFlags.ResumeFile, "resume-file", playFlags.ResumeFile,
+		"File to persist the last applied LSN of each replica. Already applied"+
+			" records are skipped on a re-run")
+	playCmd.Flags().StringSliceVar(&playSpaceMap, "space-map", playSpaceMap,
+		"Play records of a space into another space in the format src:dst, where src is"+
+			" a space id and dst is a space id or a name. May be passed more than once")
 
 	return playCmd
 }
@@ -70,6 +84,18 @@ func internalPlayModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 		return fmt.Errorf("it is required to specify an URI and at least one .xlog or .snap file")
 	}
 
+	if libconnect.IsProfileURI(args[0]) {
+		prof, err := loadProfile(args[0])
+		if err != nil {
+			return err
+		}
+		if playUsername == "" && playPassword == "" {
+			playUsername = prof.Username
+			playPassword = prof.Password
+		}
+		args = append([]string{prof.URI}, args[1:]...)
+	}
+
 	// List of files and URI is passed to lua play script via environment variable in json format.
 	filesAndUriJson, err := json.Marshal(args)
 	if err != nil {
@@ -93,6 +119,26 @@ func internalPlayModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 		}
 	}
 
+	if playFlags.Rate < 0 {
+		return fmt.Errorf("the rate must be non-negative")
+	}
+	if playFlags.SpaceMap, err = checkpoint.ParseSpaceMap(playSpaceMap); err != nil {
+		return err
+	}
+	if !playFlags.UseTarantool {
+		connOpts, err := makeCheckpointConnOpts(args[0], playUsername, playPassword)
+		if err != nil {
+			return err
+		}
+		log.Infof("Running play with URI=%s and files: %s\n", args[0], args[1:])
+		return checkpoint.PlayNative(connOpts, args[1:], playFlags)
+	}
+	if playFlags.DryRun || playFlags.Rate != 0 || playFlags.ResumeFile != "" ||
+		playFlags.SpaceMap != nil {
+		return fmt.Errorf("--dry-run, --rate, --resume-file and --space-map" +
+			" are not supported with --use-tarantool")
+	}
+
 	os.Setenv("TT_CLI_PLAY_FILES_AND_URI", string(filesAndUriJson))
 	if playUsername != "" {
 		os.Setenv("TT_CLI_PLAY_USERNAME", playUsername)
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/connect"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/profile"
+	"github.com/tarantool/tt/cli/util"
+	libconnect "github.com/tarantool/tt/lib/connect"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	profileAdd     profile.Profile
+	profileForce   bool
+	profileEncrypt bool
+)
+
+// NewProfileCmd creates profile command.
+func NewProfileCmd() *cobra.Command {
+	var profileCmd = &cobra.Command{
+		Use:   "profile",
+		Short: "Manage connection profiles",
+		Long: "Manage connection profiles.\n\n" +
+			"A profile stores a URI and credentials. It could be used as @NAME" +
+			" instead of a URI in connect, call, watch, play, replicaset and" +
+			" cluster commands.\n\n" +
+			"The profiles are stored in $XDG_CONFIG_HOME/tt/profiles.yaml or" +
+			" ~/.config/tt/profiles.yaml with 0600 permissions. The path could be" +
+			" overridden with " + profile.FileEnv + " environment variable.\n" +
+			"The passphrase for the encrypted profiles is read from " +
+			profile.PassphraseEnv + " environment variable or from the terminal.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	var addCmd = &cobra.Command{
+		Use:   "add <NAME> <URI> [flags]",
+		Short: "Add a connection profile",
+		Long: "Add a connection profile.\n\n" +
+			"The password is read from the terminal if the username is specified" +
+			" without the password.",
+		Example: `
+# Add a profile for an instance.
+
+	$ tt profile add prod localhost:3013 -u admin
+
+# Add a profile for a cluster configuration in etcd.
+
+	$ tt profile add etcd http://localhost:2379/prefix --etcd-username root
+
+# Use the profile.
+
+	$ tt connect @prod`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalProfileAddModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+	addCmd.Flags().StringVarP(&profileAdd.Username, "username", "u", "", "username")
+	addCmd.Flags().StringVarP(&profileAdd.Password, "password", "p", "", "password")
+	addCmd.Flags().StringVar(&profileAdd.SslKeyFile, "sslkeyfile", "",
+		`path to a private SSL key file`)
+	addCmd.Flags().StringVar(&profileAdd.SslCertFile, "sslcertfile", "",
+		`path to an SSL certificate file`)
+	addCmd.Flags().StringVar(&profileAdd.SslCaFile, "sslcafile", "",
+		`path to a trusted certificate authorities (CA) file`)
+	addCmd.Flags().StringVar(&profileAdd.SslCiphers, "sslciphers", "",
+		`colon-separated (:) list of SSL cipher suites the connection`)
+	addCmd.Flags().StringVar(&profileAdd.EtcdUsername, "etcd-username", "",
+		"username for etcd")
+	addCmd.Flags().StringVar(&profileAdd.EtcdPassword, "etcd-password", "",
+		"password for etcd")
+	addCmd.Flags().BoolVarP(&profileForce, "force", "f", false,
+		"replace an existing profile")
+	addCmd.Flags().BoolVar(&profileEncrypt, "encrypt", false,
+		"encrypt the profiles file with a passphrase")
+
+	var listCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List connection profiles",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalProfileListModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	var removeCmd = &cobra.Command{
+		Use:   "remove <NAME>",
+		Short: "Remove a connection profile",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalProfileRemoveModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	profileCmd.AddCommand(addCmd, listCmd, removeCmd)
+	return profileCmd
+}
+
+// readSecret reads a secret from the terminal without an echo.
+func readSecret(prompt string) (string, error) {
+	if !terminal.IsTerminal(syscall.Stdin) {
+		return "", fmt.Errorf("unable to read a secret: stdin is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	secret, err := terminal.ReadPassword(syscall.Stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("unable to read a secret: %w", err)
+	}
+	return string(secret), nil
+}
+
+// getProfilesPassphrase returns a passphrase for the encrypted profiles file.
+func getProfilesPassphrase() (string, error) {
+	if passphrase := os.Getenv(profile.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !terminal.IsTerminal(syscall.Stdin) {
+		return "", fmt.Errorf("the profiles file is encrypted, set the passphrase with %s"+
+			" environment variable", profile.PassphraseEnv)
+	}
+	return readSecret("Profiles passphrase: ")
+}
+
+// getNewProfilesPassphrase returns a new passphrase to encrypt the profiles
+// file.
+func getNewProfilesPassphrase() (string, error) {
+	if passphrase := os.Getenv(profile.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	passphrase, err := readSecret("New profiles passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("the passphrase must not be empty")
+	}
+	confirmation, err := readSecret("Repeat the passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirmation {
+		return "", errors.New("the passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// loadProfiles loads the profiles store.
+func loadProfiles() (*profile.Store, error) {
+	path, err := profile.GetDefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return profile.Load(path, getProfilesPassphrase)
+}
+
+// loadProfile loads a profile by the reference: @NAME.
+func loadProfile(ref string) (profile.Profile, error) {
+	store, err := loadProfiles()
+	if err != nil {
+		return profile.Profile{}, err
+	}
+	return store.Get(libconnect.ParseProfileURI(ref))
+}
+
+// applyConnectProfile sets the connect context values from the profile if
+// they are not specified with flags.
+func applyConnectProfile(connectCtx *connect.ConnectCtx, prof profile.Profile) {
+	if connectCtx.Username == "" && connectCtx.Password == "" {
+		connectCtx.Username = prof.Username
+		connectCtx.Password = prof.Password
+	}
+	if connectCtx.SslKeyFile == "" {
+		connectCtx.SslKeyFile = prof.SslKeyFile
+	}
+	if connectCtx.SslCertFile == "" {
+		connectCtx.SslCertFile = prof.SslCertFile
+	}
+	if connectCtx.SslCaFile == "" {
+		connectCtx.SslCaFile = prof.SslCaFile
+	}
+	if connectCtx.SslCiphers == "" {
+		connectCtx.SslCiphers = prof.SslCiphers
+	}
+}
+
+// parseProfileUrl returns a configuration storage URL from the profile.
+// The etcd credentials are preferred if the URL does not contain
+// credentials.
+func parseProfileUrl(ref string) (*url.URL, error) {
+	prof, err := loadProfile(ref)
+	if err != nil {
+		return nil, err
+	}
+	uri, err := parseUrl(prof.URI)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", libconnect.ParseProfileURI(ref), err)
+	}
+	if uri.User == nil {
+		if prof.EtcdUsername != "" || prof.EtcdPassword != "" {
+			uri.User = url.UserPassword(prof.EtcdUsername, prof.EtcdPassword)
+		} else if prof.Username != "" || prof.Password != "" {
+			uri.User = url.UserPassword(prof.Username, prof.Password)
+		}
+	}
+	return uri, nil
+}
+
+// internalProfileAddModule is a "profile add" command.
+func internalProfileAddModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	name, uri := args[0], args[1]
+	if !libconnect.IsProfileURI("@" + name) {
+		return util.NewArgError(fmt.Sprintf("invalid profile name %q: only letters,"+
+			" digits, '_', '.' and '-' are allowed", name))
+	}
+	if libconnect.IsProfileURI(uri) {
+		return util.NewArgError("a profile could not reference another profile")
+	}
+	if _, err := parseUrl(uri); err != nil &&
+		!libconnect.IsBaseURI(uri) && !libconnect.IsCredentialsURI(uri) {
+		return util.NewArgError(fmt.Sprintf("invalid URI %q", uri))
+	}
+	if libconnect.IsCredentialsURI(uri) &&
+		(profileAdd.Username != "" || profileAdd.Password != "") {
+		return util.NewArgError("username and password are specified with" +
+			" flags and a URI")
+	}
+
+	prof := profileAdd
+	prof.URI = uri
+	var err error
+	if prof.Username != "" && prof.Password == "" && terminal.IsTerminal(syscall.Stdin) {
+		if prof.Password, err = readSecret("Password: "); err != nil {
+			return err
+		}
+	}
+	if prof.EtcdUsername != "" && prof.EtcdPassword == "" &&
+		terminal.IsTerminal(syscall.Stdin) {
+		if prof.EtcdPassword, err = readSecret("Etcd password: "); err != nil {
+			return err
+		}
+	}
+
+	store, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if err := store.Add(name, prof, profileForce); err != nil {
+		return err
+	}
+	if profileEncrypt && !store.Encrypted() {
+		passphrase, err := getNewProfilesPassphrase()
+		if err != nil {
+			return err
+		}
+		store.SetPassphrase(passphrase)
+	}
+	return store.Save()
+}
+
+// internalProfileListModule is a "profile list" command.
+func internalProfileListModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	store, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range store.Names() {
+		prof, _ := store.Get(name)
+		// Passwords are never printed.
+		uri := prof.URI
+		if libconnect.IsCredentialsURI(uri) {
+			uri, _, _ = libconnect.ParseCredentialsURI(uri)
+		} else if parsed, err := parseUrl(uri); err == nil {
+			uri = parsed.Redacted()
+		}
+		line := fmt.Sprintf("%s\t%s", name, uri)
+		if prof.Username != "" {
+			line += fmt.Sprintf("\tusername: %s", prof.Username)
+		}
+		if prof.EtcdUsername != "" {
+			line += fmt.Sprintf("\tetcd username: %s", prof.EtcdUsername)
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// internalProfileRemoveModule is a "profile remove" command.
+func internalProfileRemoveModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	store, err := loadProfiles()
+	if err != nil {
+		return err
+	}
+	if err := store.Remove(args[0]); err != nil {
+		return err
+	}
+	return store.Save()
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/apex/log"
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/checkpoint"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/util"
+)
+
+var (
+	// recoverToLsn contains to-lsn flag.
+	recoverToLsn string
+	// recoverToTime contains to-time flag.
+	recoverToTime string
+	// recoverOpts contains options of recover command.
+	recoverOpts checkpoint.RecoverOpts
+)
+
+// NewRecoverCmd creates a new recover command.
+func NewRecoverCmd() *cobra.Command {
+	var recoverCmd = &cobra.Command{
+		Use:   "recover (--to-lsn <VCLOCK> | --to-time <TIME>) --src <DIR> --dst <DIR>",
+		Short: "Assemble a data directory with the state as of the given point in time",
+		Long: "Assemble a data directory with the state as of the given point in time.\n\n" +
+			"The newest snapshot at or before the target is copied from the source" +
+			" directory. The following xlogs are copied and the last one is truncated" +
+			" to the target, transactions are never split. A stopped instance or" +
+			" a scratch instance could be started from the destination directory." +
+			" Vinyl files are not recovered.",
+		Example: `
+# Recover the state right before a row with the given time.
+
+	$ tt recover --to-time 2024-04-15T14:46:30Z --src var/lib/app/inst \
+	    --dst /tmp/recovered
+
+# Recover the state up to the vclock inclusive.
+
+	$ tt recover --to-lsn '{1: 100, 2: 15}' --src var/lib/app/inst --dst /tmp/recovered`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalRecoverModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(0),
+	}
+
+	recoverCmd.Flags().StringVar(&recoverToLsn, "to-lsn", "",
+		"Recover records up to the vclock inclusive, e.g. '{1: 100, 2: 15}'."+
+			" Replicas missing in the vclock are not limited")
+	recoverCmd.Flags().StringVar(&recoverToTime, "to-time", "",
+		"Recover records before the given time: RFC3339, date-time or Unix time")
+	recoverCmd.Flags().StringVar(&recoverOpts.SrcDir, "src", "",
+		"Directory with source .snap/.xlog files")
+	recoverCmd.Flags().StringVar(&recoverOpts.DstDir, "dst", "",
+		"Directory to write the recovered files to, it must not contain .snap/.xlog files")
+
+	return recoverCmd
+}
+
+// internalRecoverModule is a default recover module.
+func internalRecoverModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	if (recoverToLsn == "") == (recoverToTime == "") {
+		return fmt.Errorf("exactly one of --to-lsn and --to-time must be specified")
+	}
+	if recoverOpts.SrcDir == "" || recoverOpts.DstDir == "" {
+		return fmt.Errorf("--src and --dst directories must be specified")
+	}
+
+	var err error
+	if recoverToLsn != "" {
+		if recoverOpts.ToVClock, err = checkpoint.ParseVClockTarget(recoverToLsn); err != nil {
+			return err
+		}
+	} else if recoverOpts.ToTime, err = checkpoint.ParseTime(recoverToTime); err != nil {
+		return err
+	}
+
+	result, err := checkpoint.Recover(recoverOpts)
+	if err != nil {
+		return err
+	}
+	log.Infof("Recovered to vclock %s in %q", result.VClock, recoverOpts.DstDir)
+	return nil
+}
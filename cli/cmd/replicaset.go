@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 
 	"github.com/tarantool/tt/cli/cmdcontext"
 	"github.com/tarantool/tt/cli/connect"
@@ -40,11 +41,20 @@ var (
 	replicasetBootstrapVshard          bool
 	replicasetCartridgeReplicasetsFile string
 	replicasetReplicasetName           string
+	replicasetReplication              bool
+	replicasetFormat                   string
+	replicasetSwitchover               bool
+	replicasetWaitBalanced             bool
+	replicasetWaitBalancedTimeout      int
+	replicasetJoinURI                  string
+	replicasetJoinTimeout              int
+	replicasetDryRun                   bool
 	rebootstrapConfirmed               bool
 
 	replicasetUriHelp = "  The URI can be specified in the following formats:\n" +
 		"  * [tcp://][username:password@][host:port]\n" +
 		"  * [unix://][username:password@]socketpath\n" +
+		"  * @profile - a connection profile, see `tt profile`\n" +
 		"  To specify relative path without `unix://` use `./`."
 )
 
@@ -57,6 +67,10 @@ func newStatusCmd() *cobra.Command {
 		DisableFlagsInUseLine: true,
 		Short:                 "Show a replicaset status",
 		Long: "Show a replicaset status.\n\n" +
+			"Broken or lagging replication links of connected instances are flagged." +
+			" Use --replication to show the vclock, the election state and all the" +
+			" replication links of the instances. The json and yaml formats contain" +
+			" the full topology with replication details.\n\n" +
 			libconnect.EnvCredentialsHelp + "\n\n",
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdCtx.CommandName = cmd.Name()
@@ -69,6 +83,10 @@ func newStatusCmd() *cobra.Command {
 
 	addOrchestratorFlags(cmd)
 	addTarantoolConnectFlags(cmd)
+	cmd.Flags().BoolVar(&replicasetReplication, "replication", false,
+		"show replication details of instances")
+	cmd.Flags().StringVar(&replicasetFormat, "format", "table",
+		"output format: table, json or yaml")
 	return cmd
 }
 
@@ -81,6 +99,16 @@ func newPromoteCmd() *cobra.Command {
 		DisableFlagsInUseLine: true,
 		Short:                 "Promote an instance",
 		Long: "Promote an instance.\n\n" +
+			"With --switchover the current master is made read-only first, the" +
+			" instance waits until its vclock catches up with the master's vclock" +
+			" until the master's vclock stops advancing and only then it is" +
+			" promoted. The instance must be the only writable" +
+			" one after the promotion. The previous master is restored if any step" +
+			" fails or times out. The switchover is supported for an instance of an" +
+			" application with off or manual failover of the centralized config and" +
+			" with disabled, eventual or stateful failover of Cartridge. An instance" +
+			" of an application with a custom orchestrator is always promoted this" +
+			" way unless elections are enabled.\n\n" +
 			libconnect.EnvCredentialsHelp + "\n\n",
 		Run: func(cmd *cobra.Command, args []string) {
 			cmdCtx.CommandName = cmd.Name()
@@ -95,10 +123,13 @@ func newPromoteCmd() *cobra.Command {
 	addTarantoolConnectFlags(cmd)
 	cmd.Flags().BoolVarP(&replicasetForce, "force", "f", false,
 		"to force a promotion:\n"+
-			"  * config: skip instances not found locally\n"+
+			"  * config, custom: skip instances not found locally\n"+
 			"  * cartridge: force inconsistency")
 	cmd.Flags().IntVarP(&replicasetTimeout, "timeout", "",
 		replicasetcmd.DefaultTimeout, "promoting timeout")
+	cmd.Flags().BoolVar(&replicasetSwitchover, "switchover", false,
+		"make the current master read-only and wait until the instance catches up"+
+			" with it before the promotion, roll back on a failure")
 	integrity.RegisterWithIntegrityFlag(cmd.Flags(), &replicasetIntegrityPrivateKey)
 
 	return cmd
@@ -209,6 +240,40 @@ func newBootstrapVShardCmd() *cobra.Command {
 	return cmd
 }
 
+// newVShardStatusCmd creates a "vshard status" command.
+func newVShardStatusCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "status [--cartridge|--config|--custom] [--wait-balanced] [--timeout secs] " +
+			"[flags] (<APP_NAME> | <APP_NAME:INSTANCE_NAME>)",
+		DisableFlagsInUseLine: true,
+		Short:                 "Show a vshard status of the cluster",
+		Long: "Show a vshard status of the cluster.\n\n" +
+			"The status contains bucket counters of the storage replicasets reported by" +
+			" the masters, bucket counters of the routers, the rebalancer location and" +
+			" state, the storage and router alerts. The cluster is balanced if there" +
+			" are no buckets in transfer and the buckets are distributed according to" +
+			" the replicaset weights and the rebalancer disbalance threshold. Use" +
+			" --wait-balanced to wait until the cluster is balanced.\n\n" +
+			libconnect.EnvCredentialsHelp + "\n\n",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalReplicasetVShardStatusModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	addOrchestratorFlags(cmd)
+	addTarantoolConnectFlags(cmd)
+	cmd.Flags().BoolVarP(&replicasetWaitBalanced, "wait-balanced", "", false,
+		"wait until the cluster is balanced")
+	cmd.Flags().IntVarP(&replicasetWaitBalancedTimeout, "timeout", "",
+		replicasetcmd.VShardWaitBalancedDefaultTimeout, "timeout of waiting for the balance")
+
+	return cmd
+}
+
 // newVShardCmd creates a "replicaset vshard" subcommand.
 func newVShardCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -218,6 +283,7 @@ func newVShardCmd() *cobra.Command {
 	}
 
 	cmd.AddCommand(newBootstrapVShardCmd())
+	cmd.AddCommand(newVShardStatusCmd())
 	return cmd
 }
 
@@ -242,6 +308,47 @@ func newRebootstrapCmd() *cobra.Command {
 	return cmd
 }
 
+// newJoinCmd creates a "replicaset join" command.
+func newJoinCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: "join [--uri URI] [--dry-run] [--timeout secs] [flags] " +
+			"--replicaset <REPLICASET_NAME> <APP_NAME:INSTANCE_NAME>",
+		DisableFlagsInUseLine: true,
+		Short:                 "Join a new instance into a replicaset",
+		Long: "Join a new instance into a replicaset of an application with the" +
+			" centralized config.\n\n" +
+			"The instance is added into the local cluster config and into the instances" +
+			" config of the application, then it is started. The command waits until" +
+			" the instance joins the replicaset and catches up with the master, then" +
+			" reloads the configuration of the running instances. The changes are" +
+			" rolled back if the instance fails to start or join: the instance is" +
+			" stopped, removed from the replicaset and its data directories are" +
+			" removed. Use --dry-run to" +
+			" show the cluster config diff without changes. Applications with a remote" +
+			" config source (etcd or a Tarantool config storage) are not supported.",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalReplicasetJoinModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(1),
+	}
+
+	cmd.Flags().StringVarP(&replicasetReplicasetName, "replicaset", "", "",
+		"name of the replicaset to join")
+	cmd.MarkFlagRequired("replicaset")
+	cmd.Flags().StringVarP(&replicasetJoinURI, "uri", "", "",
+		"iproto listen URI of the instance, it is inherited from the config by default")
+	cmd.Flags().BoolVarP(&replicasetDryRun, "dry-run", "", false,
+		"show the cluster config diff only")
+	integrity.RegisterWithIntegrityFlag(cmd.Flags(), &replicasetIntegrityPrivateKey)
+	cmd.Flags().IntVarP(&replicasetJoinTimeout, "timeout", "",
+		replicaset.JoinDefaultTimeout, "timeout of each joining step")
+
+	return cmd
+}
+
 // NewReplicasetCmd creates a replicaset command.
 func NewReplicasetCmd() *cobra.Command {
 	cmd := &cobra.Command{
@@ -257,6 +364,7 @@ func NewReplicasetCmd() *cobra.Command {
 	cmd.AddCommand(newVShardCmd())
 	cmd.AddCommand(newBootstrapCmd())
 	cmd.AddCommand(newRebootstrapCmd())
+	cmd.AddCommand(newJoinCmd())
 
 	return cmd
 }
@@ -400,6 +508,7 @@ func internalReplicasetPromoteModule(cmdCtx *cmdcontext.CmdCtx, args []string) e
 		Orchestrator:  ctx.Orchestrator,
 		Force:         replicasetForce,
 		Timeout:       replicasetTimeout,
+		Switchover:    replicasetSwitchover,
 	})
 }
 
@@ -437,6 +546,11 @@ func internalReplicasetDemoteModule(cmdCtx *cmdcontext.CmdCtx, args []string) er
 
 // internalReplicasetStatusModule is a "status" command for the replicaset module.
 func internalReplicasetStatusModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	format := strings.ToLower(replicasetFormat)
+	if !slices.Contains(replicasetcmd.StatusFormats, format) {
+		return util.NewArgError(fmt.Sprintf("unsupported output format: %s", replicasetFormat))
+	}
+
 	var ctx replicasetCtx
 	if err := replicasetFillCtx(cmdCtx, &ctx, args, false); err != nil {
 		return err
@@ -449,6 +563,8 @@ func internalReplicasetStatusModule(cmdCtx *cmdcontext.CmdCtx, args []string) er
 		RunningCtx:    ctx.RunningCtx,
 		Conn:          ctx.Conn,
 		Orchestrator:  ctx.Orchestrator,
+		Replication:   replicasetReplication,
+		Format:        format,
 	})
 }
 
@@ -507,6 +623,26 @@ func internalReplicasetBootstrapVShardModule(cmdCtx *cmdcontext.CmdCtx, args []s
 	})
 }
 
+// internalReplicasetVShardStatusModule is a "status" command for
+// the "replicaset vshard" module.
+func internalReplicasetVShardStatusModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	var ctx replicasetCtx
+	if err := replicasetFillCtx(cmdCtx, &ctx, args, false); err != nil {
+		return err
+	}
+	if ctx.IsInstanceConnect {
+		defer ctx.Conn.Close()
+	}
+	return replicasetcmd.VShardStatus(replicasetcmd.VShardCmdCtx{
+		IsApplication: ctx.IsApplication,
+		RunningCtx:    ctx.RunningCtx,
+		Conn:          ctx.Conn,
+		Orchestrator:  ctx.Orchestrator,
+		Timeout:       replicasetWaitBalancedTimeout,
+		WaitBalanced:  replicasetWaitBalanced,
+	})
+}
+
 // internalReplicasetBootstrapModule is a "bootstrap" command for the "replicaset" module.
 func internalReplicasetBootstrapModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
 	_, instName, found := strings.Cut(args[0], string(running.InstanceDelimiter))
@@ -570,3 +706,28 @@ func internalReplicasetRebootstrapModule(cmdCtx *cmdcontext.CmdCtx, args []strin
 		Confirmed:    rebootstrapConfirmed,
 	})
 }
+
+// internalReplicasetJoinModule is a "join" command for the replicaset module.
+func internalReplicasetJoinModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	appName, instName, found := strings.Cut(args[0], string(running.InstanceDelimiter))
+	if !found {
+		return util.NewArgError(
+			"an instance name is not specified. Please use app:instance format.")
+	}
+	collectors, publishers, err := createDataCollectorsAndDataPublishers(
+		cmdCtx.Integrity, replicasetIntegrityPrivateKey)
+	if err != nil {
+		return err
+	}
+
+	return replicaset.Join(*cmdCtx, *cliOpts, replicaset.JoinCtx{
+		AppName:        appName,
+		InstName:       instName,
+		ReplicasetName: replicasetReplicasetName,
+		URI:            replicasetJoinURI,
+		DryRun:         replicasetDryRun,
+		Publishers:     publishers,
+		Collectors:     collectors,
+		Timeout:        replicasetJoinTimeout,
+	})
+}
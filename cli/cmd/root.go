@@ -169,9 +169,15 @@ After that tt will be able to manage the application using 'replicaset_example'
 		NewLogrotateCmd(),
 		NewCheckCmd(),
 		NewConnectCmd(),
+		NewCallCmd(),
+		NewWatchCmd(),
 		NewRocksCmd(),
 		NewCatCmd(),
 		NewPlayCmd(),
+		NewRecoverCmd(),
+		NewXlogCmd(),
+		NewExportCmd(),
+		NewImportCmd(),
 		NewCartridgeCmd(),
 		NewClusterCmd(),
 		NewCoredumpCmd(),
@@ -194,6 +200,7 @@ After that tt will be able to manage the application using 'replicaset_example'
 		NewKillCmd(),
 		NewLogCmd(),
 		NewEnableCmd(),
+		NewProfileCmd(),
 	)
 	if err := injectCmds(rootCmd); err != nil {
 		panic(err.Error())
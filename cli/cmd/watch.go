@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/cmd/internal"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/connect"
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/running"
+	"github.com/tarantool/tt/cli/util"
+	"github.com/tarantool/tt/cli/watch"
+	libconnect "github.com/tarantool/tt/lib/connect"
+)
+
+var (
+	watchUser        string
+	watchPassword    string
+	watchFormat      string
+	watchUntil       string
+	watchSslKeyFile  string
+	watchSslCertFile string
+	watchSslCaFile   string
+	watchSslCiphers  string
+)
+
+// NewWatchCmd creates watch command.
+func NewWatchCmd() *cobra.Command {
+	var watchCmd = &cobra.Command{
+		Use: "watch (<APP_NAME:INSTANCE_NAME> | <URI>) <KEY> [KEY...] [flags]\n\n" +
+			"  The URI can be specified in the following formats:\n" +
+			"  * [tcp://][username:password@][host:port]\n" +
+			"  * [unix://][username:password@]socketpath\n" +
+			"  * @profile - a connection profile, see `tt profile`\n" +
+			"  To specify relative path without `unix://` use `./`.",
+		Short: "Watch for key updates on the tarantool instance",
+		Long: "Watch for key updates on the tarantool instance.\n\n" +
+			"The command subscribes to the keys via IPROTO watchers (Tarantool 2.10+)" +
+			" and prints every notification with a timestamp as it arrives." +
+			" Built-in keys like box.status, box.id and box.election are supported" +
+			" as well as keys broadcasted with box.broadcast().\n\n" +
+			"The --until option is a Lua expression with the `key` and `value`" +
+			" variables. The command exits when the expression becomes truthy:\n\n" +
+			`tt watch app:storage box.election --until "value.state == 'leader'"` +
+			"\n\n" + libconnect.EnvCredentialsHelp,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalWatchModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.MinimumNArgs(2),
+		ValidArgsFunction: func(
+			cmd *cobra.Command,
+			args []string,
+			toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) != 0 {
+				return []string{"box.status", "box.id", "box.election", "box.schema"},
+					cobra.ShellCompDirectiveNoFileComp
+			}
+			validArgs, _ := internal.ValidArgsFunction(
+				cliOpts, &cmdCtx, cmd, toComplete,
+				running.ExtractActiveAppNames,
+				running.ExtractActiveInstanceNames)
+			return validArgs, cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+
+	watchCmd.Flags().StringVarP(&watchUser, "username", "u", "", "username")
+	watchCmd.Flags().StringVarP(&watchPassword, "password", "p", "", "password")
+	watchCmd.Flags().StringVar(&watchFormat, "format",
+		watch.DefaultFormat.String(), `output format: yaml or json`)
+	watchCmd.Flags().StringVar(&watchUntil, "until", "",
+		`Lua expression to stop watching when it becomes truthy`)
+	watchCmd.Flags().StringVar(&watchSslKeyFile, "sslkeyfile", "",
+		`path to a private SSL key file`)
+	watchCmd.Flags().StringVar(&watchSslCertFile, "sslcertfile", "",
+		`path to an SSL certificate file`)
+	watchCmd.Flags().StringVar(&watchSslCaFile, "sslcafile", "",
+		`path to a trusted certificate authorities (CA) file`)
+	watchCmd.Flags().StringVar(&watchSslCiphers, "sslciphers", "",
+		`colon-separated (:) list of SSL cipher suites the connection`)
+
+	return watchCmd
+}
+
+// internalWatchModule is a default watch module.
+func internalWatchModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	connectCtx := connect.ConnectCtx{
+		Username:    watchUser,
+		Password:    watchPassword,
+		SslKeyFile:  watchSslKeyFile,
+		SslCertFile: watchSslCertFile,
+		SslCaFile:   watchSslCaFile,
+		SslCiphers:  watchSslCiphers,
+		// Watchers are available only via IPROTO.
+		Binary: true,
+	}
+
+	watchCtx := watch.WatchCtx{
+		Until: watchUntil,
+	}
+	var ok bool
+	if watchCtx.Format, ok = watch.ParseFormat(watchFormat); !ok {
+		return util.NewArgError(fmt.Sprintf("unsupported output format: %s", watchFormat))
+	}
+
+	connOpts, newArgs, err := resolveConnectOpts(cmdCtx, cliOpts, &connectCtx, args)
+	if err != nil {
+		return err
+	}
+	watchCtx.Keys = newArgs
+	connOpts.RequireWatchers = true
+
+	conn, err := connector.Connect(connOpts)
+	if err != nil {
+		return fmt.Errorf("unable to establish connection: %s", err)
+	}
+	defer conn.Close()
+
+	watcher, ok := conn.(connector.Watcher)
+	if !ok {
+		return fmt.Errorf("watchers are supported only for the binary protocol")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return watch.Watch(ctx, watchCtx, watcher, os.Stdout)
+}
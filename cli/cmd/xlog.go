@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/tarantool/tt/cli/checkpoint"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/modules"
+	"github.com/tarantool/tt/cli/util"
+)
+
+var (
+	// xlogDiffDump contains dump flag.
+	xlogDiffDump string
+	// xlogDiffOpts contains options of xlog diff command.
+	xlogDiffOpts checkpoint.DiffOpts
+)
+
+// NewXlogCmd creates a new xlog command.
+func NewXlogCmd() *cobra.Command {
+	var xlogCmd = &cobra.Command{
+		Use:   "xlog",
+		Short: "Inspect .snap/.xlog files",
+	}
+
+	var diffCmd = &cobra.Command{
+		Use:   "diff <A> <B>",
+		Short: "Compare data of two snapshots or data directories",
+		Long: "Compare data of two snapshots or data directories space by space.\n\n" +
+			"A data directory is represented by its newest snapshot with the following" +
+			" xlogs applied in memory. Tuples are matched by primary keys from the" +
+			" _index definitions and reported as only in A, only in B or different.",
+		Example: `
+# Compare data directories of two replicas.
+
+	$ tt xlog diff var/lib/app/replica1 var/lib/app/replica2
+
+# Compare a space of two snapshots and dump the differing tuples.
+
+	$ tt xlog diff a/00000000000000000010.snap b/00000000000000000012.snap \
+	    --space 512 --dump diff.jsonl`,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdCtx.CommandName = cmd.Name()
+			err := modules.RunCmd(&cmdCtx, cmd.CommandPath(), &modulesInfo,
+				internalXlogDiffModule, args)
+			util.HandleCmdErr(cmd, err)
+		},
+		Args: cobra.ExactArgs(2),
+	}
+
+	diffCmd.Flags().IntSliceVar(&xlogDiffOpts.Space, "space", nil,
+		"Compare the space with the given number only. May be passed more than once")
+	diffCmd.Flags().BoolVar(&xlogDiffOpts.ShowSystem, "show-system", false,
+		"Compare system spaces too")
+	diffCmd.Flags().StringVar(&xlogDiffDump, "dump", "",
+		"Write the differing tuples as JSON Lines to the file, - for stdout. The summary"+
+			" is written to stderr in the last case")
+
+	xlogCmd.AddCommand(diffCmd)
+	return xlogCmd
+}
+
+// internalXlogDiffModule is a default xlog diff module.
+func internalXlogDiffModule(cmdCtx *cmdcontext.CmdCtx, args []string) error {
+	switch xlogDiffDump {
+	case "":
+	case "-":
+		xlogDiffOpts.Dump = os.Stdout
+	default:
+		file, err := os.Create(xlogDiffDump)
+		if err != nil {
+			return fmt.Errorf("failed to create %q: %w", xlogDiffDump, err)
+		}
+		defer file.Close()
+		xlogDiffOpts.Dump = file
+	}
+
+	diffs, err := checkpoint.Diff(args[0], args[1], xlogDiffOpts)
+	if err != nil {
+		return err
+	}
+	// The summary is written to stderr to keep the dump in stdout parsable.
+	if xlogDiffOpts.Dump == os.Stdout {
+		checkpoint.WriteDiff(os.Stderr, diffs)
+	} else {
+		checkpoint.WriteDiff(os.Stdout, diffs)
+	}
+	return nil
+}
@@ -27,8 +27,9 @@ var luaCodeFiles = []generateLuaCodeOpts{
 		PackageName: "connect",
 		FileName:    "cli/connect/lua_code_gen.go",
 		VariablesMap: map[string]string{
-			"evalFuncBody":           "cli/connect/lua/eval_func_body.lua",
-			"getSuggestionsFuncBody": "cli/connect/lua/get_suggestions_func_body.lua",
+			"evalFuncBody":              "cli/connect/lua/eval_func_body.lua",
+			"getSuggestionsFuncBody":    "cli/connect/lua/get_suggestions_func_body.lua",
+			"getSqlSuggestionsFuncBody": "cli/connect/lua/get_sql_suggestions_func_body.lua",
 		},
 	},
 	{
@@ -20,6 +20,8 @@ package config
 //    distfiles: path
 //  ee:
 //    credential_path: path
+//  console:
+//    pager: command
 
 // ModuleOpts is used to store all module options.
 type ModulesOpts struct {
@@ -34,6 +36,14 @@ type EEOpts struct {
 	CredPath string `mapstructure:"credential_path" yaml:"credential_path"`
 }
 
+// ConsoleOpts is used to store tt connect console options.
+type ConsoleOpts struct {
+	// Pager is a pager command for a long console output. The value "off"
+	// disables the pager. If it is empty, the PAGER environment variable
+	// or less is used.
+	Pager string `mapstructure:"pager" yaml:"pager"`
+}
+
 // AppOpts is used to store all app options.
 type AppOpts struct {
 	// RunDir is a path to directory that stores various instance
@@ -97,4 +107,6 @@ type CliOpts struct {
 	Templates []TemplateOpts
 	// Repo is a struct used to store paths to local files.
 	Repo *RepoOpts
+	// Console is a struct that contains tt connect console options.
+	Console *ConsoleOpts
 }
@@ -115,6 +115,9 @@ func GetSystemCliOpts() *config.CliOpts {
 	templates := []config.TemplateOpts{
 		{Path: "templates"},
 	}
+	console := config.ConsoleOpts{
+		Pager: "",
+	}
 	return &config.CliOpts{
 		Env:       getDefaultTtEnvOpts(),
 		Modules:   &modules,
@@ -122,6 +125,7 @@ func GetSystemCliOpts() *config.CliOpts {
 		Repo:      &repo,
 		EE:        &ee,
 		Templates: templates,
+		Console:   &console,
 	}
 }
 
@@ -140,6 +144,9 @@ func GetDefaultCliOpts() *config.CliOpts {
 	templates := []config.TemplateOpts{
 		{Path: "templates"},
 	}
+	console := config.ConsoleOpts{
+		Pager: "",
+	}
 	return &config.CliOpts{
 		Env:       getDefaultTtEnvOpts(),
 		Modules:   &modules,
@@ -147,6 +154,7 @@ func GetDefaultCliOpts() *config.CliOpts {
 		Repo:      &repo,
 		EE:        &ee,
 		Templates: templates,
+		Console:   &console,
 	}
 }
 
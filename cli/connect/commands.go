@@ -9,6 +9,7 @@ import (
 
 	"github.com/apex/log"
 
+	"github.com/tarantool/tt/cli/connector"
 	"github.com/tarantool/tt/cli/formatter"
 )
 
@@ -30,8 +31,10 @@ var (
 )
 
 var (
-	errNotUnsigned = errors.New("the command expects one unsigned number")
-	errNotBoolean  = errors.New("the command expects one boolean")
+	errNotUnsigned     = errors.New("the command expects one unsigned number")
+	errNotBoolean      = errors.New("the command expects one boolean")
+	errTxnNotSupported = errors.New("interactive transactions are supported only" +
+		" for a binary connection to an instance with IPROTO streams support")
 )
 
 // find returns true if the string is found in the sorted slice.
@@ -160,12 +163,12 @@ func (command argSetCmdDecorator) Aliases() []string {
 // Run checks that there is one allowed argument and runs the command.
 func (command argSetCmdDecorator) Run(console *Console,
 	cmd string, args []string) (string, error) {
-	if len(args) != 1 || !find(command.sorted, args[0]) {
+	if len(args) != 1 || !find(command.sorted, strings.ToLower(args[0])) {
 		return "", fmt.Errorf("the command expects one of: %s",
 			strings.Join(command.sorted, ", "))
 	}
 
-	return command.base.Run(console, cmd, args)
+	return command.base.Run(console, cmd, []string{strings.ToLower(args[0])})
 }
 
 // argUnsignedDecorator is a decorator for a command that checks that a
@@ -225,11 +228,12 @@ func (command argBooleanCmdDecorator) Run(console *Console,
 	if len(args) != 1 {
 		return "", errNotBoolean
 	}
-	if _, err := strconv.ParseBool(args[0]); err != nil {
+	arg := strings.ToLower(args[0])
+	if _, err := strconv.ParseBool(arg); err != nil {
 		return "", errNotBoolean
 	}
 
-	return command.base.Run(console, cmd, args)
+	return command.base.Run(console, cmd, []string{arg})
 }
 
 // cmdInfo describes an additional information about a command.
@@ -361,6 +365,31 @@ func setTableColumnWidthMaxFunc(console *Console,
 	return "", nil
 }
 
+// setTableFlattenFunc sets the flatten mode for tables on/off.
+func setTableFlattenFunc(console *Console, cmd string, args []string) (string, error) {
+	console.formatOpts.Flatten = args[0] == switchOn
+	return "", nil
+}
+
+// setColorFunc sets the syntax highlighting on/off or sets a color theme.
+func setColorFunc(console *Console, cmd string, args []string) (string, error) {
+	switch args[0] {
+	case switchOn:
+		console.color = true
+	case switchOff:
+		console.color = false
+	default:
+		theme, ok := formatter.ParseColorTheme(args[0])
+		if !ok {
+			// It should not happen in practice.
+			return "", fmt.Errorf("unsupported color theme: %s", args[0])
+		}
+		console.color = true
+		console.colorTheme = theme
+	}
+	return "", nil
+}
+
 // switchNextFormatFunc switches to a next output format.
 func switchNextFormatFunc(console *Console, cmd string, args []string) (string, error) {
 	console.format = (1 + console.format) % formatter.FormatsAmount
@@ -387,6 +416,97 @@ func disableGraphicsFunc(console *Console, cmd string, args []string) (string, e
 	return "", nil
 }
 
+// setPagerFunc sets the pager command for the console.
+func setPagerFunc(console *Console, cmd string, args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("the command expects a pager command, %s or %s",
+			PagerOn, PagerOff)
+	}
+
+	console.pager = strings.Join(args, " ")
+	return "", nil
+}
+
+// connectFunc connects the console to a new target.
+func connectFunc(console *Console, cmd string, args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf("the command expects one URI or instance name")
+	}
+
+	if err := switchTarget(console, args[0]); err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %s", args[0], err)
+	}
+	log.Infof("Connected to %s", console.title)
+	return "", nil
+}
+
+// reconnectFunc reconnects the console to the current target.
+func reconnectFunc(console *Console, cmd string, args []string) (string, error) {
+	if err := reconnect(console, console.connOpts); err != nil {
+		return "", fmt.Errorf("failed to reconnect: %s", err)
+	}
+	log.Infof("Reconnected to %s", console.title)
+	return "", nil
+}
+
+// beginTxnFunc begins an interactive transaction in the console stream.
+func beginTxnFunc(console *Console, cmd string, args []string) (string, error) {
+	if console.streamer == nil {
+		return "", errTxnNotSupported
+	}
+
+	opts := connector.TxnOpts{}
+	if len(args) > 1 {
+		return "", fmt.Errorf("the command expects an optional isolation level")
+	}
+	if len(args) == 1 {
+		isolation, ok := connector.ParseTxnIsolation(args[0])
+		if !ok {
+			return "", fmt.Errorf("the command expects one of: %s, %s, %s, %s",
+				connector.DefaultTxnIsolation, connector.ReadCommittedTxnIsolation,
+				connector.ReadConfirmedTxnIsolation, connector.BestEffortTxnIsolation)
+		}
+		opts.Isolation = isolation
+	}
+
+	if err := console.streamer.Begin(opts); err != nil {
+		return "", fmt.Errorf("failed to begin a transaction: %s", err)
+	}
+	console.txnActive = true
+	return "", nil
+}
+
+// commitTxnFunc commits the interactive transaction in the console stream.
+func commitTxnFunc(console *Console, cmd string, args []string) (string, error) {
+	if console.streamer == nil {
+		return "", errTxnNotSupported
+	}
+
+	err := console.streamer.Commit()
+	updateTxnState(console)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit the transaction: %s", err)
+	}
+	console.txnActive = false
+	return "", nil
+}
+
+// rollbackTxnFunc rolls back the interactive transaction in the console
+// stream.
+func rollbackTxnFunc(console *Console, cmd string, args []string) (string, error) {
+	if console.streamer == nil {
+		return "", errTxnNotSupported
+	}
+
+	err := console.streamer.Rollback()
+	updateTxnState(console)
+	if err != nil {
+		return "", fmt.Errorf("failed to rollback the transaction: %s", err)
+	}
+	console.txnActive = false
+	return "", nil
+}
+
 // getShortcuts returns a list of allowed shortcuts.
 func getShortcutsFunc(console *Console, cmd string, args []string) (string, error) {
 	return shortcutListText, nil
@@ -463,6 +583,32 @@ var cmdInfos = []cmdInfo{
 			),
 		),
 	},
+	cmdInfo{
+		Short: setTableFlatten + " <on/off>",
+		Long:  "expand nested maps and arrays into columns for table/ttable",
+		Cmd: newArgSetCmdDecorator(
+			newBaseCmd([]string{setTableFlatten}, setTableFlattenFunc),
+			[]string{switchOn, switchOff},
+		),
+	},
+	cmdInfo{
+		Short: setColor + " <on/off/theme>",
+		Long:  "set syntax highlighting on/off or theme dark or light",
+		Cmd: newArgSetCmdDecorator(
+			newBaseCmd([]string{setColor}, setColorFunc),
+			[]string{
+				switchOn,
+				switchOff,
+				formatter.DarkColorTheme.String(),
+				formatter.LightColorTheme.String(),
+			},
+		),
+	},
+	cmdInfo{
+		Short: setPager + " <command/on/off>",
+		Long:  "set pager command for a long output or disable it",
+		Cmd:   newBaseCmd([]string{setPager}, setPagerFunc),
+	},
 	cmdInfo{
 		Short: setNextFormat,
 		Long:  "switches output format cyclically",
@@ -528,6 +674,37 @@ var cmdInfos = []cmdInfo{
 			newBaseCmd([]string{getShortcutsList}, getShortcutsFunc),
 		),
 	},
+	cmdInfo{
+		Short: setConnect + " <URI/instance>",
+		Long:  "connect to another instance",
+		Cmd:   newBaseCmd([]string{setConnect}, connectFunc),
+	},
+	cmdInfo{
+		Short: setReconnect,
+		Long:  "reconnect to the current instance",
+		Cmd: newNoArgsCmdDecorator(
+			newBaseCmd([]string{setReconnect}, reconnectFunc),
+		),
+	},
+	cmdInfo{
+		Short: beginTxn + " [isolation]",
+		Long:  "begin a transaction in the binary port session",
+		Cmd:   newBaseCmd([]string{beginTxn}, beginTxnFunc),
+	},
+	cmdInfo{
+		Short: commitTxn,
+		Long:  "commit the transaction",
+		Cmd: newNoArgsCmdDecorator(
+			newBaseCmd([]string{commitTxn}, commitTxnFunc),
+		),
+	},
+	cmdInfo{
+		Short: rollbackTxn,
+		Long:  "rollback the transaction",
+		Cmd: newNoArgsCmdDecorator(
+			newBaseCmd([]string{rollbackTxn}, rollbackTxnFunc),
+		),
+	},
 	// The Tarantool console has `\quit` command, but it requires execute
 	// access.
 	cmdInfo{
@@ -572,19 +749,17 @@ func (executor cmdExecutor) Execute(console *Console, in string) bool {
 	dirtyTokens := strings.Split(strings.TrimSpace(in), " ")
 
 	tokens := []string{}
-	lowerTokens := []string{}
 	for _, token := range dirtyTokens {
 		token = strings.Trim(token, " ")
 		if token != "" {
 			tokens = append(tokens, token)
-			lowerTokens = append(lowerTokens, strings.ToLower(token))
 		}
 	}
 
 	for i := len(tokens); i > 0; i-- {
 		key := strings.Join(tokens[:i], " ")
 		if cmd, ok := executor.cmds[key]; ok {
-			msg, err := cmd.Run(console, key, lowerTokens[i:])
+			msg, err := cmd.Run(console, key, tokens[i:])
 			if err != nil {
 				log.Errorf("%s\n", err)
 			} else if msg != "" {
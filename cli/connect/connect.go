@@ -13,6 +13,12 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// ResolveTargetFunc resolves a connection target: a URI or an instance name.
+// It returns connection options and updates the connection context with
+// the resolved credentials and the target.
+type ResolveTargetFunc func(connectCtx *ConnectCtx, target string) (connector.ConnectOpts,
+	error)
+
 // ConnectCtx contains information for connecting to the instance.
 type ConnectCtx struct {
 	// Username of the tarantool user.
@@ -21,6 +27,11 @@ type ConnectCtx struct {
 	Password string
 	// SrcFile describes the source of code for the evaluation.
 	SrcFile string
+	// ScriptArgs contains named arguments for the evaluated script. They are
+	// available in the script with positional arguments in the arg table.
+	ScriptArgs map[string]string
+	// ErrorMode defines a behavior of the script evaluation on an error.
+	ErrorMode ErrorMode
 	// Language to use for execution.
 	Language Language
 	// Format to use as an output format.
@@ -40,6 +51,16 @@ type ConnectCtx struct {
 	ConnectTarget string
 	// Binary port is used
 	Binary bool
+	// Pager is a pager command for a long output of the interactive
+	// console. The value "off" disables the pager, an empty value or "on"
+	// means the default pager.
+	Pager string
+	// ResolveTarget resolves a new connection target for the console. The
+	// console could not switch the target if it is not set.
+	ResolveTarget ResolveTargetFunc
+	// ListTargets returns a list of known connection targets to complete
+	// a new target in the console. It could be nil.
+	ListTargets func() []string
 }
 
 const (
@@ -84,6 +105,9 @@ func Connect(connectCtx ConnectCtx, connOpts connector.ConnectOpts) error {
 }
 
 // Eval executes the command on the remote instance (according to args).
+// The command is evaluated statement by statement if an error mode is set.
+// If the evaluation raised an error, the result is returned with
+// ErrEvalFailed.
 func Eval(connectCtx ConnectCtx, connOpts connector.ConnectOpts, args []string) ([]byte, error) {
 	command, err := getEvalCmd(connectCtx)
 	if err != nil {
@@ -97,26 +121,72 @@ func Eval(connectCtx ConnectCtx, connOpts connector.ConnectOpts, args []string)
 	}
 	defer conn.Close()
 
-	evalArgs := []interface{}{command, connectCtx.Language == SQLLanguage}
 	if connectCtx.Language != DefaultLanguage {
 		// Change a language.
 		if err := ChangeLanguage(conn, connectCtx.Language); err != nil {
 			return nil, fmt.Errorf("unable to change a language: %s", err)
 		}
+	}
+
+	stmts := []string{command}
+	if connectCtx.ErrorMode != NoneErrorMode {
+		var validator ValidateCloser
+		if connectCtx.Language == SQLLanguage {
+			validator = NewSQLValidator()
+		} else {
+			validator = NewLuaValidator()
+		}
+		stmts = SplitStatements(command, validator)
+		validator.Close()
+	}
+
+	var res []byte
+	evalFailed := false
+	for _, stmt := range stmts {
+		resYAML, failed, err := evalStmt(conn, connectCtx, stmt, args)
+		if err != nil {
+			return res, err
+		}
+
+		res = append(res, resYAML...)
+		if failed {
+			evalFailed = true
+			if connectCtx.ErrorMode != ContinueErrorMode {
+				break
+			}
+		}
+	}
+
+	if evalFailed {
+		return res, ErrEvalFailed
+	}
+	return res, nil
+}
+
+// evalStmt evaluates the statement on the remote instance and returns the
+// YAML-encoded result and true if the statement raised an error.
+func evalStmt(evaler connector.Evaler, connectCtx ConnectCtx, stmt string,
+	args []string) ([]byte, bool, error) {
+	evalArgs := []interface{}{stmt, connectCtx.Language == SQLLanguage}
+	if connectCtx.Language != DefaultLanguage {
 		evalArgs = append(evalArgs, false)
 	} else {
 		needMetaInfo := connectCtx.Format == formatter.TableFormat ||
 			connectCtx.Format == formatter.TTableFormat
-		evalArgs = append(evalArgs, needMetaInfo)
+		scriptArgs := connectCtx.ScriptArgs
+		if scriptArgs == nil {
+			scriptArgs = map[string]string{}
+		}
+		evalArgs = append(evalArgs, needMetaInfo, scriptArgs)
 		for i := range args {
 			evalArgs = append(evalArgs, args[i])
 		}
 	}
 
 	// Execution of the command.
-	response, err := conn.Eval(evalFuncBody, evalArgs, connector.RequestOpts{})
+	response, err := evaler.Eval(evalFuncBody, evalArgs, connector.RequestOpts{})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// Check that the result is encoded in YAML and convert it to bytes,
@@ -125,10 +195,17 @@ func Eval(connectCtx ConnectCtx, connOpts connector.ConnectOpts, args []string)
 	resYAML := []byte((response[0]).(string))
 	var checkMock interface{}
 	if err = yaml.Unmarshal(resYAML, &checkMock); err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	return resYAML, nil
+	// A Lua error of a script is marked by the second returned value. SQL
+	// statements are evaluated by the console module, which reports an error
+	// only as the result.
+	failed := len(response) > 1 && response[1] == true
+	if connectCtx.Language == SQLLanguage {
+		failed = isConsoleEvalError(resYAML)
+	}
+	return resYAML, failed, nil
 }
 
 // runConsole run a new console.
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"syscall"
@@ -50,17 +51,34 @@ type Console struct {
 	language   Language
 	format     formatter.Format
 	formatOpts formatter.Opts
+	pager      string
 	quit       bool
+	// color is true if the output syntax highlighting is enabled. It
+	// works only for a terminal output.
+	color      bool
+	colorTheme formatter.ColorTheme
 
 	history *commandHistory
 
 	prefix            string
+	txnPrefix         string
 	livePrefixEnabled bool
 	livePrefix        string
 	livePrefixFunc    func() (string, bool)
 
 	connOpts connector.ConnectOpts
+	connCtx  ConnectCtx
 	conn     connector.Connector
+	// streamer is the connection bound to an IPROTO stream, it is nil if
+	// the connection does not support streams.
+	streamer connector.Streamer
+	// txnActive is true if there is an active transaction in the stream.
+	txnActive bool
+	// sqlNames is a cache of space, field and index names for SQL
+	// completion. It is loaded on demand and reset after a statement is
+	// executed or the console is reconnected, since the schema could be
+	// changed.
+	sqlNames []string
 
 	executor   func(in string)
 	completer  func(in prompt.Document) []prompt.Suggest
@@ -82,15 +100,17 @@ func NewConsole(connOpts connector.ConnectOpts, connectCtx ConnectCtx, title str
 	error) {
 	console := &Console{
 		title:    title,
-		connOpts: connOpts,
+		connCtx:  connectCtx,
 		language: connectCtx.Language,
 		format:   connectCtx.Format,
+		pager:    connectCtx.Pager,
 		formatOpts: formatter.Opts{
 			Graphics:       true,
 			ColumnWidthMax: 0,
 			TableDialect:   formatter.DefaultTableDialect,
 		},
-		quit: false,
+		colorTheme: formatter.DefaultColorTheme,
+		quit:       false,
 	}
 
 	var err error
@@ -107,16 +127,8 @@ func NewConsole(connOpts connector.ConnectOpts, connectCtx ConnectCtx, title str
 	}
 
 	// Connect to specified address.
-	console.conn, err = connector.Connect(connOpts)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect: %s", err)
-	}
-
-	// Change a language.
-	if connectCtx.Language != DefaultLanguage {
-		if err := ChangeLanguage(console.conn, connectCtx.Language); err != nil {
-			return nil, fmt.Errorf("unable to change a language: %s", err)
-		}
+	if err := reconnect(console, connOpts); err != nil {
+		return nil, err
 	}
 
 	// Initialize user commands executor.
@@ -180,6 +192,100 @@ func (console *Console) Close() {
 	}
 }
 
+// reconnect replaces the console connection with a new connection to the
+// instance. The console settings and the history are kept.
+func reconnect(console *Console, connOpts connector.ConnectOpts) error {
+	conn, err := connector.Connect(connOpts)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %s", err)
+	}
+
+	// Change a language.
+	if console.language != DefaultLanguage {
+		if err := ChangeLanguage(conn, console.language); err != nil {
+			conn.Close()
+			return fmt.Errorf("unable to change a language: %s", err)
+		}
+	}
+
+	// Bind the console session to a stream, so an interactive transaction
+	// could be continued between requests.
+	var streamer connector.Streamer
+	if connStreamer, ok := conn.(connector.Streamer); ok {
+		if err := connStreamer.BindStream(); err != nil {
+			log.Debugf("Unable to bind the console to a stream: %s", err)
+		} else {
+			streamer = connStreamer
+		}
+	}
+
+	if console.conn != nil {
+		if console.txnActive {
+			log.Warnf("The active transaction is rolled back")
+		}
+		console.conn.Close()
+	}
+	console.conn = conn
+	console.connOpts = connOpts
+	console.streamer = streamer
+	console.txnActive = false
+	console.sqlNames = nil
+	return nil
+}
+
+// txnStmtRe matches statements that could begin or finish a transaction.
+var txnStmtRe = regexp.MustCompile(`(?i)\b(begin|commit|rollback|start\s+transaction|atomic)\b`)
+
+// mayChangeTxnState returns true if a transaction state in the stream could be
+// changed after the statement execution. A statement in an active transaction
+// could abort it, so the state is checked after any statement in that case.
+func mayChangeTxnState(console *Console, stmt string) bool {
+	return console.streamer != nil && (console.txnActive || txnStmtRe.MatchString(stmt))
+}
+
+// updateTxnState updates the state of the transaction in the stream.
+func updateTxnState(console *Console) {
+	if console.streamer == nil {
+		return
+	}
+
+	var results []bool
+	opts := connector.RequestOpts{
+		ReadTimeout: 3 * time.Second,
+		ResData:     &results,
+	}
+	if _, err := console.conn.Eval("return box.is_in_txn()", []interface{}{},
+		opts); err != nil {
+		log.Debugf("Unable to get the transaction state: %s", err)
+		return
+	}
+	console.txnActive = len(results) > 0 && results[0]
+}
+
+// switchTarget connects the console to a new target: a URI or an instance
+// name. The console settings and the history are kept.
+func switchTarget(console *Console, target string) error {
+	if console.connCtx.ResolveTarget == nil {
+		return fmt.Errorf("switching the connection target is not supported")
+	}
+
+	connCtx := console.connCtx
+	connCtx.ConnectTarget = ""
+	connOpts, err := connCtx.ResolveTarget(&connCtx, target)
+	if err != nil {
+		return err
+	}
+
+	if err := reconnect(console, connOpts); err != nil {
+		return err
+	}
+
+	console.connCtx = connCtx
+	console.title = genConsoleTitle(connOpts, connCtx)
+	setPrefix(console)
+	return nil
+}
+
 // getExecutor returns command executor.
 func getExecutor(console *Console) func(string) {
 	commandsExecutor := newCmdExecutor()
@@ -258,9 +364,14 @@ func getExecutor(console *Console) func(string) {
 			log.Errorf("Unable to format output: %s", err)
 			log.Infof("Source YAML:\n%s", data)
 		} else {
-			fmt.Print(output)
+			printOutput(console, output)
 		}
 
+		if mayChangeTxnState(console, console.input) {
+			updateTxnState(console)
+		}
+		// The statement could change the schema.
+		console.sqlNames = nil
 		console.input = ""
 		console.livePrefixEnabled = false
 	}
@@ -274,10 +385,15 @@ func getCompleter(console *Console) prompt.Completer {
 			return nil
 		}
 
+		if strings.HasPrefix(in.Text, setConnect+" ") {
+			return getTargetSuggestions(console, in)
+		}
+
 		if console.language == SQLLanguage {
 			// Tarantool does not implements auto-completion for SQL:
 			// https://github.com/tarantool/tarantool/issues/2304
-			return nil
+			// So it is implemented on the client side.
+			return getSQLSuggestions(console, in)
 		}
 
 		lastWordStart := in.FindStartOfPreviousWordUntilSeparator(tarantoolWordSeparators)
@@ -318,6 +434,105 @@ func getCompleter(console *Console) prompt.Completer {
 	return completer
 }
 
+// getTargetSuggestions returns suggestions of connection targets for the
+// \connect command.
+func getTargetSuggestions(console *Console, in prompt.Document) []prompt.Suggest {
+	if console.connCtx.ListTargets == nil {
+		return nil
+	}
+
+	arg := strings.TrimLeft(strings.TrimPrefix(in.Text, setConnect), " ")
+	if strings.Contains(arg, " ") {
+		return nil
+	}
+	lastWordStart := in.FindStartOfPreviousWordUntilSeparator(tarantoolWordSeparators)
+	lastWord := in.Text[lastWordStart:]
+
+	targets := console.connCtx.ListTargets()
+	sort.Strings(targets)
+
+	suggestions := []prompt.Suggest{}
+	for _, target := range targets {
+		// The prompt replaces only the last word, so the suggestion is
+		// cut to it.
+		if strings.HasPrefix(target, arg) {
+			suggestions = append(suggestions, prompt.Suggest{
+				Text: target[len(arg)-len(lastWord):],
+			})
+		}
+	}
+	return suggestions
+}
+
+// getSQLSuggestions returns suggestions of SQL keywords, space and column
+// names for the last word.
+func getSQLSuggestions(console *Console, in prompt.Document) []prompt.Suggest {
+	// Nothing to suggest inside a string literal, a quoted identifier or
+	// a comment.
+	if _, ok := lexSQL(console.input + "\n" + in.Text); !ok {
+		return nil
+	}
+
+	lastWordStart := in.FindStartOfPreviousWordUntilSeparator(tarantoolWordSeparators)
+	lastWord := in.Text[lastWordStart:]
+	if len(lastWord) == 0 {
+		return nil
+	}
+
+	return filterSQLSuggestions(lastWord, getSQLNames(console))
+}
+
+// getSQLNames returns the cached space, field and index names. The names are
+// loaded from the instance if the cache is empty.
+func getSQLNames(console *Console) []string {
+	if console.sqlNames != nil {
+		return console.sqlNames
+	}
+
+	var results [][]string
+	opts := connector.RequestOpts{
+		ReadTimeout: 3 * time.Second,
+		ResData:     &results,
+	}
+	if _, err := console.conn.Eval(getSqlSuggestionsFuncBody, []interface{}{},
+		opts); err != nil || len(results) == 0 {
+		return nil
+	}
+	console.sqlNames = results[0]
+	if console.sqlNames == nil {
+		console.sqlNames = []string{}
+	}
+	return console.sqlNames
+}
+
+// filterSQLSuggestions returns SQL keywords and names that start with the
+// word. The case of letters is ignored.
+func filterSQLSuggestions(word string, names []string) []prompt.Suggest {
+	prefix := strings.ToUpper(word)
+	var texts []string
+	for _, candidates := range [][]string{sqlKeywords, names} {
+		for _, candidate := range candidates {
+			if strings.HasPrefix(strings.ToUpper(candidate), prefix) {
+				texts = append(texts, candidate)
+			}
+		}
+	}
+
+	texts = arrayOperations.DifferenceString(texts)
+	if len(texts) == 0 {
+		return nil
+	}
+	sort.Strings(texts)
+
+	suggestions := make([]prompt.Suggest, len(texts))
+	for i, text := range texts {
+		suggestions[i] = prompt.Suggest{
+			Text: text,
+		}
+	}
+	return suggestions
+}
+
 func setTitle(console *Console, title string) {
 	if console.title != "" {
 		return
@@ -328,6 +543,7 @@ func setTitle(console *Console, title string) {
 
 func setPrefix(console *Console) {
 	console.prefix = fmt.Sprintf("%s> ", console.title)
+	console.txnPrefix = fmt.Sprintf("%s(txn)> ", console.title)
 
 	livePrefixIndent := len(console.title)
 	if livePrefixIndent > MaxLivePrefixIndent {
@@ -336,8 +552,16 @@ func setPrefix(console *Console) {
 
 	console.livePrefix = fmt.Sprintf("%s> ", strings.Repeat(" ", livePrefixIndent))
 
+	// The prefix could be changed after the prompt start, so it is always
+	// returned as a live prefix.
 	console.livePrefixFunc = func() (string, bool) {
-		return console.livePrefix, console.livePrefixEnabled
+		if console.livePrefixEnabled {
+			return console.livePrefix, true
+		}
+		if console.txnActive {
+			return console.txnPrefix, true
+		}
+		return console.prefix, true
 	}
 }
 
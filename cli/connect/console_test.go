@@ -0,0 +1,266 @@
+package connect
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tarantool/go-prompt"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// txnConnMock is a connection with an IPROTO stream.
+type txnConnMock struct {
+	connector.Connector
+	inTxn bool
+	opts  []connector.TxnOpts
+	err   error
+}
+
+func (conn *txnConnMock) Eval(expr string, args []interface{},
+	opts connector.RequestOpts) ([]interface{}, error) {
+	*opts.ResData.(*[]bool) = []bool{conn.inTxn}
+	return nil, nil
+}
+
+func (conn *txnConnMock) BindStream() error {
+	return nil
+}
+
+func (conn *txnConnMock) Begin(opts connector.TxnOpts) error {
+	if conn.err != nil {
+		return conn.err
+	}
+	conn.opts = append(conn.opts, opts)
+	conn.inTxn = true
+	return nil
+}
+
+func (conn *txnConnMock) Commit() error {
+	if conn.err != nil {
+		return conn.err
+	}
+	conn.inTxn = false
+	return nil
+}
+
+func (conn *txnConnMock) Rollback() error {
+	conn.inTxn = false
+	return conn.err
+}
+
+func newTxnConsole(conn *txnConnMock) *Console {
+	console := &Console{
+		title:    "localhost:3301",
+		conn:     conn,
+		streamer: conn,
+	}
+	setPrefix(console)
+	return console
+}
+
+func TestGetTargetSuggestions(t *testing.T) {
+	targets := []string{"app:storage-b", "app:router", "app:storage-a", "other:inst"}
+	console := &Console{
+		connCtx: ConnectCtx{
+			ListTargets: func() []string { return targets },
+		},
+	}
+
+	cases := []struct {
+		text     string
+		expected []string
+	}{
+		{"\\connect ", []string{"app:router", "app:storage-a", "app:storage-b", "other:inst"}},
+		{"\\connect app:", []string{"app:router", "app:storage-a", "app:storage-b"}},
+		{"\\connect app:storage-", []string{"a", "b"}},
+		{"\\connect app:storage-a", []string{"a"}},
+		{"\\connect ot", []string{"other:inst"}},
+		{"\\connect foo", []string{}},
+		{"\\connect app:router foo", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.text, func(t *testing.T) {
+			buf := prompt.NewBuffer()
+			buf.InsertText(c.text, false, true)
+
+			suggestions := getTargetSuggestions(console, *buf.Document())
+			if c.expected == nil {
+				assert.Nil(t, suggestions)
+				return
+			}
+			texts := []string{}
+			for _, suggestion := range suggestions {
+				texts = append(texts, suggestion.Text)
+			}
+			assert.Equal(t, c.expected, texts)
+		})
+	}
+}
+
+func TestGetTargetSuggestions_noList(t *testing.T) {
+	console := &Console{}
+	buf := prompt.NewBuffer()
+	buf.InsertText("\\connect ", false, true)
+
+	assert.Nil(t, getTargetSuggestions(console, *buf.Document()))
+}
+
+func TestSwitchTarget_notSupported(t *testing.T) {
+	console := &Console{}
+	assert.EqualError(t, switchTarget(console, "localhost:3301"),
+		"switching the connection target is not supported")
+}
+
+func TestFilterSQLSuggestions(t *testing.T) {
+	names := []string{"T", "users", "ID", "NAME", "users", "_space"}
+
+	cases := []struct {
+		word     string
+		expected []string
+	}{
+		{"sel", []string{"SELECT"}},
+		{"SEL", []string{"SELECT"}},
+		{"u", []string{"UNION", "UNIQUE", "UNKNOWN", "UPDATE", "USING", "users"}},
+		{"na", []string{"NAME"}},
+		{"_s", []string{"_space"}},
+		{"foo", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.word, func(t *testing.T) {
+			suggestions := filterSQLSuggestions(c.word, names)
+			if c.expected == nil {
+				assert.Nil(t, suggestions)
+				return
+			}
+			texts := []string{}
+			for _, suggestion := range suggestions {
+				texts = append(texts, suggestion.Text)
+			}
+			assert.Equal(t, c.expected, texts)
+		})
+	}
+}
+
+func TestTxnFuncs(t *testing.T) {
+	conn := &txnConnMock{}
+	console := newTxnConsole(conn)
+
+	prefix, _ := console.livePrefixFunc()
+	assert.Equal(t, "localhost:3301> ", prefix)
+
+	_, err := beginTxnFunc(console, beginTxn, []string{"Read-Committed"})
+	require.NoError(t, err)
+	assert.True(t, console.txnActive)
+	assert.Equal(t, []connector.TxnOpts{
+		{Isolation: connector.ReadCommittedTxnIsolation},
+	}, conn.opts)
+	prefix, _ = console.livePrefixFunc()
+	assert.Equal(t, "localhost:3301(txn)> ", prefix)
+
+	_, err = commitTxnFunc(console, commitTxn, nil)
+	require.NoError(t, err)
+	assert.False(t, console.txnActive)
+	prefix, _ = console.livePrefixFunc()
+	assert.Equal(t, "localhost:3301> ", prefix)
+
+	_, err = beginTxnFunc(console, beginTxn, nil)
+	require.NoError(t, err)
+	assert.True(t, console.txnActive)
+	assert.Equal(t, connector.TxnOpts{}, conn.opts[1])
+
+	_, err = rollbackTxnFunc(console, rollbackTxn, nil)
+	require.NoError(t, err)
+	assert.False(t, console.txnActive)
+}
+
+func TestTxnFuncs_errors(t *testing.T) {
+	conn := &txnConnMock{}
+	console := newTxnConsole(conn)
+
+	_, err := beginTxnFunc(console, beginTxn, []string{"serializable"})
+	assert.EqualError(t, err, "the command expects one of: default, read-committed,"+
+		" read-confirmed, best-effort")
+	_, err = beginTxnFunc(console, beginTxn, []string{"default", "default"})
+	assert.EqualError(t, err, "the command expects an optional isolation level")
+
+	conn.err = errors.New("any error")
+	_, err = beginTxnFunc(console, beginTxn, nil)
+	assert.EqualError(t, err, "failed to begin a transaction: any error")
+	assert.False(t, console.txnActive)
+
+	// The transaction state is updated from the instance on an error.
+	conn.inTxn = true
+	_, err = commitTxnFunc(console, commitTxn, nil)
+	assert.EqualError(t, err, "failed to commit the transaction: any error")
+	assert.True(t, console.txnActive)
+
+	_, err = rollbackTxnFunc(console, rollbackTxn, nil)
+	assert.EqualError(t, err, "failed to rollback the transaction: any error")
+	assert.False(t, console.txnActive)
+
+	console.streamer = nil
+	for _, f := range []runFunc{beginTxnFunc, commitTxnFunc, rollbackTxnFunc} {
+		_, err = f(console, "", nil)
+		assert.ErrorIs(t, err, errTxnNotSupported)
+	}
+}
+
+func TestMayChangeTxnState(t *testing.T) {
+	console := newTxnConsole(&txnConnMock{})
+
+	cases := []struct {
+		stmt     string
+		expected bool
+	}{
+		{"box.begin()", true},
+		{"box.commit()", true},
+		{"box.rollback()", true},
+		{"box.atomic(f)", true},
+		{"START TRANSACTION;", true},
+		{"begin;", true},
+		{"box.space.test:get(1)", false},
+		{"SELECT * FROM beginners;", false},
+	}
+	for _, c := range cases {
+		t.Run(c.stmt, func(t *testing.T) {
+			assert.Equal(t, c.expected, mayChangeTxnState(console, c.stmt))
+		})
+	}
+
+	console.txnActive = true
+	assert.True(t, mayChangeTxnState(console, "box.space.test:get(1)"))
+
+	console.streamer = nil
+	assert.False(t, mayChangeTxnState(console, "box.begin()"))
+}
+
+// namesConnMock returns SQL completion names and counts requests.
+type namesConnMock struct {
+	connector.Connector
+	calls int
+}
+
+func (conn *namesConnMock) Eval(expr string, args []interface{},
+	opts connector.RequestOpts) ([]interface{}, error) {
+	conn.calls++
+	*opts.ResData.(*[][]string) = [][]string{{"users"}}
+	return nil, nil
+}
+
+func TestGetSQLNames_cache(t *testing.T) {
+	conn := &namesConnMock{}
+	console := &Console{conn: conn}
+
+	assert.Equal(t, []string{"users"}, getSQLNames(console))
+	assert.Equal(t, []string{"users"}, getSQLNames(console))
+	assert.Equal(t, 1, conn.calls)
+
+	console.sqlNames = nil
+	assert.Equal(t, []string{"users"}, getSQLNames(console))
+	assert.Equal(t, 2, conn.calls)
+}
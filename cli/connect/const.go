@@ -23,6 +23,23 @@ const setTableColumnWidthMaxLong = "\\set table_column_width"
 // width for tables.
 const setTableColumnWidthMaxShort = "\\xw"
 
+// setTableFlatten is a command to switch expanding of nested maps and arrays
+// into separate columns for tables.
+const setTableFlatten = "\\set table_flatten"
+
+// setColor is a command to set up the syntax highlighting of the output.
+const setColor = "\\set color"
+
+const (
+	// switchOn is a value to enable a console setting.
+	switchOn = "on"
+	// switchOff is a value to disable a console setting.
+	switchOff = "off"
+)
+
+// setPager is a command to set a pager for a long output.
+const setPager = "\\set pager"
+
 // setNextFormat is a command to set a next format cyclically.
 const setNextFormat = "\\x"
 
@@ -46,6 +63,21 @@ const setGraphicsEnable = "\\xG"
 // table/ttalbe output formats.
 const setGraphicsDisable = "\\xg"
 
+// setConnect is a command to connect the console to a new target.
+const setConnect = "\\connect"
+
+// setReconnect is a command to reconnect the console to the current target.
+const setReconnect = "\\reconnect"
+
+// beginTxn is a command to begin an interactive transaction.
+const beginTxn = "\\begin"
+
+// commitTxn is a command to commit the interactive transaction.
+const commitTxn = "\\commit"
+
+// rollbackTxn is a command to rollback the interactive transaction.
+const rollbackTxn = "\\rollback"
+
 // setQuit is a short command to set ttable format.
 var setQuit = []string{"\\quit", "\\q"}
 
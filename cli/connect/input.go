@@ -76,9 +76,10 @@ func NewSQLValidator() *SQLValidator {
 	return &SQLValidator{}
 }
 
-// Validate always returns true.
-func (v SQLValidator) Validate(_ string) bool {
-	return true
+// Validate returns true if the string is empty or a completed statement for
+// the SQL language. A statement is completed by a top-level semicolon.
+func (v SQLValidator) Validate(str string) bool {
+	return isSQLStmtCompleted(str)
 }
 
 // Close closes the SQL validator. It is safe to call it multiple times.
@@ -113,17 +113,88 @@ func TestSQLValidator_Close_multiple(t *testing.T) {
 	assert.NoError(t, v.Close())
 }
 
-func TestSQLValidator_Validate(t *testing.T) {
+func TestSQLValidator_Validate_true(t *testing.T) {
 	v := NewSQLValidator()
-	assert.True(t, v.Validate("any"))
-	assert.True(t, v.Validate("any other"))
+
+	cases := []string{
+		"",
+		"  \n ",
+		"-- comment",
+		"/* comment */",
+		"select 1;",
+		"SELECT 1 ;  ",
+		"select 1; -- comment",
+		"select 1; /* comment */",
+		"select\n  1\nfrom t\n;",
+		"select 1; select 2;",
+		"select 'a;b';",
+		"select 'it''s';",
+		"select \"a;b\" from t;",
+		"select \"a\"\"b\" from t;",
+		"select 1 -- comment;\n;",
+		"select 1 /* ; */;",
+		"select (1);",
+		"select 'статус';",
+		"create trigger t after insert on a for each row begin " +
+			"insert into b values (1); end;",
+		"CREATE TRIGGER t AFTER INSERT ON a FOR EACH ROW BEGIN " +
+			"UPDATE b SET c = CASE WHEN 1 THEN 2 END; END;",
+		"select 1 from t where a = 'begin';",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			assert.True(t, v.Validate(c))
+		})
+	}
+}
+
+func TestSQLValidator_Validate_false(t *testing.T) {
+	v := NewSQLValidator()
+
+	cases := []string{
+		"select 1",
+		"select 1 -- ;",
+		"select 1 /* ; */",
+		"select 1; select 2",
+		"select ';",
+		"select 'a;",
+		"select 'it'';",
+		"select \"a;",
+		"select 1 /* comment;",
+		"select (1;",
+		"create trigger t after insert on a for each row begin " +
+			"insert into b values (1);",
+		"create trigger t after insert on a for each row begin " +
+			"update b set c = case when 1 then 2 end;",
+	}
+
+	for _, c := range cases {
+		t.Run(c, func(t *testing.T) {
+			assert.False(t, v.Validate(c))
+		})
+	}
 }
 
 func TestSQLValidator_Validate_afterClose(t *testing.T) {
 	v := NewSQLValidator()
-	assert.True(t, v.Validate("any"))
+	assert.True(t, v.Validate("select 1;"))
 	assert.NoError(t, v.Close())
-	assert.True(t, v.Validate("any"))
+	assert.True(t, v.Validate("select 1;"))
+}
+
+func TestAddStmtPart_sql(t *testing.T) {
+	v := NewSQLValidator()
+
+	stmt, completed := AddStmtPart("", "select *", v)
+	assert.False(t, completed)
+	stmt, completed = AddStmtPart(stmt, "from t", v)
+	assert.False(t, completed)
+	stmt, completed = AddStmtPart(stmt, "where a = 'x;", v)
+	assert.False(t, completed)
+	stmt, completed = AddStmtPart(stmt, "y';", v)
+	assert.True(t, completed)
+	assert.Equal(t, "select *\nfrom t\nwhere a = 'x;\ny';", stmt)
 }
 
 type ValidatorMock struct {
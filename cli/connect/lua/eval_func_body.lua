@@ -4,6 +4,7 @@ local args = {...}
 local cmd = table.remove(args, 1)
 local is_sql_language = table.remove(args, 1)
 local need_metainfo = table.remove(args, 1)
+local script_args = table.remove(args, 1)
 
 local function is_command(line)
     return line:sub(1, 1) == '\\'
@@ -18,9 +19,24 @@ if not fun then
     fun, errmsg = loadstring(cmd)
 end
 if not fun then
+    if script_args ~= nil then
+        return yaml.encode({{error = errmsg}}), true
+    end
     return yaml.encode({box.NULL})
 end
 
+if script_args ~= nil then
+    local script_arg = {}
+    for i, value in ipairs(args) do
+        script_arg[i] = value
+    end
+    for key, value in pairs(script_args) do
+        script_arg[key] = value
+    end
+    local env = setmetatable({arg = script_arg}, {__index = _G, __newindex = _G})
+    setfenv(fun, env)
+end
+
 local function table_pack(...)
     return {n = select('#', ...), ...}
 end
@@ -44,6 +60,9 @@ if not ret[1] then
     if err == nil then
         err = box.NULL
     end
+    if script_args ~= nil then
+        return yaml.encode({{error = err}}), true
+    end
     return yaml.encode({{error = err}})
 end
 if ret.n == 1 then
@@ -0,0 +1,13 @@
+local names = {}
+for _, space in box.space._vspace:pairs() do
+    table.insert(names, space[3])
+    for _, field in ipairs(space[7]) do
+        if type(field) == 'table' and field.name ~= nil then
+            table.insert(names, field.name)
+        end
+    end
+end
+for _, index in box.space._vindex:pairs() do
+    table.insert(names, index[3])
+end
+return names
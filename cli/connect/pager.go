@@ -0,0 +1,118 @@
+package connect
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"unicode/utf8"
+
+	"github.com/apex/log"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/tarantool/tt/cli/formatter"
+)
+
+const (
+	// PagerOff is a pager value that disables the pager.
+	PagerOff = "off"
+	// PagerOn is a pager value that enables the default pager.
+	PagerOn = "on"
+
+	// pagerEnv is an environment variable with a user-preferred pager.
+	pagerEnv = "PAGER"
+	// defaultPager is a pager command used if the PAGER environment
+	// variable is not set.
+	defaultPager = "less"
+	// defaultTablePager is a pager command used for table formats if the
+	// PAGER environment variable is not set. Long lines are chopped
+	// instead of wrapping, so tables are not broken.
+	defaultTablePager = "less -S"
+)
+
+// getPagerCmd returns a pager command line for the pager setting and the
+// output format. It returns an empty string if the pager is disabled.
+func getPagerCmd(pager string, format formatter.Format) string {
+	pager = strings.TrimSpace(pager)
+	if strings.ToLower(pager) == PagerOff {
+		return ""
+	}
+	if pager != "" && strings.ToLower(pager) != PagerOn {
+		return pager
+	}
+
+	if envPager := strings.TrimSpace(os.Getenv(pagerEnv)); envPager != "" {
+		return envPager
+	}
+	if format == formatter.TableFormat || format == formatter.TTableFormat {
+		return defaultTablePager
+	}
+	return defaultPager
+}
+
+// countScreenLines returns an amount of terminal lines needed to display the
+// output on a terminal with the width.
+func countScreenLines(output string, width int) int {
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if width <= 0 {
+		return len(lines)
+	}
+
+	count := 0
+	for _, line := range lines {
+		length := utf8.RuneCountInString(line)
+		if length == 0 {
+			count++
+		} else {
+			count += (length + width - 1) / width
+		}
+	}
+	return count
+}
+
+// isOutputFit returns true if the output fits the terminal.
+func isOutputFit(output string) bool {
+	width, height, err := terminal.GetSize(syscall.Stdout)
+	if err != nil || height <= 0 {
+		return true
+	}
+	return countScreenLines(output, width) < height
+}
+
+// runPager pipes the output to the pager command. The terminal state is
+// restored after the pager exits, so the prompt could continue to work as
+// before.
+func runPager(pagerCmd string, output string) error {
+	fd := int(os.Stdin.Fd())
+	if state, err := terminal.GetState(fd); err == nil {
+		defer terminal.Restore(fd, state)
+	}
+
+	cmd := exec.Command("sh", "-c", pagerCmd)
+	cmd.Stdin = strings.NewReader(output)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// printOutput prints the formatted output. The output is passed to the pager
+// if the pager is enabled, the output is a terminal and the output does not
+// fit it. The syntax is highlighted only if the output is printed to
+// a terminal directly.
+func printOutput(console *Console, output string) {
+	isTerminal := terminal.IsTerminal(syscall.Stdout)
+	pagerCmd := getPagerCmd(console.pager, console.format)
+	if pagerCmd == "" || !isTerminal || isOutputFit(output) {
+		if console.color && isTerminal {
+			output = formatter.Highlight(console.format, output, console.colorTheme)
+		}
+		fmt.Print(output)
+		return
+	}
+
+	if err := runPager(pagerCmd, output); err != nil {
+		log.Warnf("Failed to run the pager %q: %s", pagerCmd, err)
+		fmt.Print(output)
+	}
+}
@@ -0,0 +1,59 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tarantool/tt/cli/formatter"
+)
+
+func TestGetPagerCmd(t *testing.T) {
+	cases := []struct {
+		name     string
+		pager    string
+		env      string
+		format   formatter.Format
+		expected string
+	}{
+		{"off", "off", "more", formatter.YamlFormat, ""},
+		{"off mixed case", "OfF", "", formatter.TableFormat, ""},
+		{"custom", "most -s", "more", formatter.YamlFormat, "most -s"},
+		{"env", "", "more", formatter.TableFormat, "more"},
+		{"on with env", "on", "more", formatter.YamlFormat, "more"},
+		{"default yaml", "", "", formatter.YamlFormat, "less"},
+		{"default lua", "on", "", formatter.LuaFormat, "less"},
+		{"default table", "", "", formatter.TableFormat, "less -S"},
+		{"default ttable", "", "", formatter.TTableFormat, "less -S"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv(pagerEnv, c.env)
+			assert.Equal(t, c.expected, getPagerCmd(c.pager, c.format))
+		})
+	}
+}
+
+func TestCountScreenLines(t *testing.T) {
+	cases := []struct {
+		name     string
+		output   string
+		width    int
+		expected int
+	}{
+		{"single line", "abc\n", 80, 1},
+		{"no trailing newline", "abc\ndef", 80, 2},
+		{"empty lines", "a\n\n\nb\n", 80, 4},
+		{"wrapped", "abcdef\nab\n", 3, 3},
+		{"exact width", "abc\n", 3, 1},
+		{"unicode", "абвгде\n", 3, 2},
+		{"unknown width", "abcdef\nab\n", 0, 2},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, countScreenLines(c.output, c.width))
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package connect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	noneErrorModeStr     = ""
+	stopErrorModeStr     = "stop"
+	continueErrorModeStr = "continue"
+)
+
+// EvalErrorExitCode is an exit code for the case when the evaluated code
+// raised an error on the instance.
+const EvalErrorExitCode = 3
+
+// ErrEvalFailed is reported when the evaluated code raised an error on the
+// instance.
+var ErrEvalFailed = errors.New("the evaluation raised an error")
+
+// ErrorMode defines a behavior of the script evaluation on an error.
+type ErrorMode int
+
+const (
+	// NoneErrorMode evaluates the whole script at once.
+	NoneErrorMode ErrorMode = iota
+	// StopErrorMode evaluates the script statement by statement and stops
+	// on the first error.
+	StopErrorMode
+	// ContinueErrorMode evaluates the script statement by statement and
+	// continues the evaluation after an error.
+	ContinueErrorMode
+)
+
+// ParseErrorMode parses an error mode string representation. It supports
+// mixed case letters.
+func ParseErrorMode(str string) (ErrorMode, bool) {
+	switch strings.ToLower(str) {
+	case noneErrorModeStr:
+		return NoneErrorMode, true
+	case stopErrorModeStr:
+		return StopErrorMode, true
+	case continueErrorModeStr:
+		return ContinueErrorMode, true
+	}
+	return NoneErrorMode, false
+}
+
+// String returns a string representation of the error mode.
+func (mode ErrorMode) String() string {
+	switch mode {
+	case NoneErrorMode:
+		return noneErrorModeStr
+	case StopErrorMode:
+		return stopErrorModeStr
+	case ContinueErrorMode:
+		return continueErrorModeStr
+	default:
+		panic("Unknown error mode")
+	}
+}
+
+// ParseScriptArgs parses a list of "key=value" strings into named script
+// arguments.
+func ParseScriptArgs(args []string) (map[string]string, error) {
+	named := map[string]string{}
+	for _, arg := range args {
+		key, value, found := strings.Cut(arg, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid argument %q: expected key=value", arg)
+		}
+		named[key] = value
+	}
+	return named, nil
+}
+
+// SplitStatements splits the script into completed statements with the
+// validator.
+func SplitStatements(script string, validator Validator) []string {
+	var stmts []string
+	stmt := ""
+	completed := true
+	for _, line := range strings.Split(script, "\n") {
+		stmt, completed = AddStmtPart(stmt, line, validator)
+		if completed {
+			if strings.TrimSpace(stmt) != "" {
+				stmts = append(stmts, stmt)
+			}
+			stmt = ""
+		}
+	}
+	if !completed && strings.TrimSpace(stmt) != "" {
+		// The last statement is incomplete, but it is evaluated anyway to
+		// get an error from the instance.
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+// isConsoleEvalError returns true if the YAML-encoded result of the console
+// module evaluation is an error raised on the instance.
+func isConsoleEvalError(resYAML []byte) bool {
+	var res []map[string]interface{}
+	if err := yaml.Unmarshal(resYAML, &res); err != nil || len(res) != 1 {
+		return false
+	}
+	_, found := res[0]["error"]
+	return found && len(res[0]) == 1
+}
@@ -0,0 +1,136 @@
+package connect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+func TestParseErrorMode(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected ErrorMode
+		ok       bool
+	}{
+		{"", NoneErrorMode, true},
+		{"stop", StopErrorMode, true},
+		{"StOp", StopErrorMode, true},
+		{"continue", ContinueErrorMode, true},
+		{"CONTINUE", ContinueErrorMode, true},
+		{"skip", NoneErrorMode, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			mode, ok := ParseErrorMode(c.str)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.expected, mode)
+		})
+	}
+}
+
+func TestErrorMode_String(t *testing.T) {
+	assert.Equal(t, "", NoneErrorMode.String())
+	assert.Equal(t, "stop", StopErrorMode.String())
+	assert.Equal(t, "continue", ContinueErrorMode.String())
+	assert.PanicsWithValue(t, "Unknown error mode", func() { _ = ErrorMode(666).String() })
+}
+
+func TestParseScriptArgs(t *testing.T) {
+	args, err := ParseScriptArgs([]string{"a=1", "b=", "c=x=y"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "", "c": "x=y"}, args)
+
+	args, err = ParseScriptArgs(nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{}, args)
+
+	for _, invalid := range []string{"a", "=1"} {
+		_, err = ParseScriptArgs([]string{invalid})
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	validator := NewLuaValidator()
+	defer validator.Close()
+
+	cases := []struct {
+		name     string
+		script   string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"blank lines", "\n  \n\n", nil},
+		{"single", "return 1", []string{"return 1"}},
+		{"multiple", "a = 1\n\nb = 2\nreturn a + b\n",
+			[]string{"a = 1", "b = 2", "return a + b"}},
+		{"multi-line", "function f()\n  return 1\nend\nreturn f()",
+			[]string{"function f()\n  return 1\nend", "return f()"}},
+		{"incomplete", "a = 1\nfunction f()\n  return 1\n",
+			[]string{"a = 1", "function f()\n  return 1\n"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.expected, SplitStatements(c.script, validator))
+		})
+	}
+}
+
+func TestIsConsoleEvalError(t *testing.T) {
+	cases := []struct {
+		res      string
+		expected bool
+	}{
+		{"---\n- error: 'test'\n...\n", true},
+		{"---\n- error: null\n...\n", true},
+		{"---\n...\n", false},
+		{"---\n- 1\n...\n", false},
+		{"---\n- error: 'test'\n  code: 1\n...\n", false},
+		{"---\n- error: 'test'\n- 2\n...\n", false},
+		{"---\n- {}\n...\n", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.res, func(t *testing.T) {
+			assert.Equal(t, c.expected, isConsoleEvalError([]byte(c.res)))
+		})
+	}
+}
+
+// evalResultMock returns the predefined eval response.
+type evalResultMock []interface{}
+
+func (m evalResultMock) Eval(string, []interface{},
+	connector.RequestOpts) ([]interface{}, error) {
+	return m, nil
+}
+
+func TestEvalStmt_failed(t *testing.T) {
+	errorYAML := "---\n- error: 'test'\n...\n"
+	cases := []struct {
+		name     string
+		response evalResultMock
+		language Language
+		expected bool
+	}{
+		{"lua error", evalResultMock{errorYAML, true}, LuaLanguage, true},
+		{"lua error value", evalResultMock{errorYAML}, LuaLanguage, false},
+		{"sql error", evalResultMock{errorYAML}, SQLLanguage, true},
+		{"sql result", evalResultMock{"---\n- row_count: 1\n...\n"}, SQLLanguage, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := ConnectCtx{Language: c.language}
+			res, failed, err := evalStmt(c.response, ctx, "stmt", nil)
+			require.NoError(t, err)
+			assert.Equal(t, c.response[0], string(res))
+			assert.Equal(t, c.expected, failed)
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package connect
+
+import (
+	"strings"
+	"unicode"
+)
+
+// sqlTokenKind is a kind of an SQL token.
+type sqlTokenKind int
+
+const (
+	// sqlWord is a keyword or an unquoted identifier.
+	sqlWord sqlTokenKind = iota
+	// sqlString is a string literal: 'text'.
+	sqlString
+	// sqlQuotedIdent is a quoted identifier: "name".
+	sqlQuotedIdent
+	// sqlComment is a line (-- text) or a block (/* text */) comment.
+	sqlComment
+	// sqlPunct is any other symbol including a number.
+	sqlPunct
+)
+
+// sqlToken is a lexeme of an SQL statement.
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// sqlKeywords is a list of SQL keywords for the completion.
+var sqlKeywords = []string{
+	"ALTER", "ANALYZE", "AND", "AS", "ASC", "AUTOINCREMENT", "BEGIN", "BETWEEN",
+	"BY", "CASE", "CAST", "CHECK", "COLLATE", "COLUMN", "COMMIT", "CONSTRAINT",
+	"CREATE", "CROSS", "DEFAULT", "DELETE", "DESC", "DISTINCT", "DROP", "EACH",
+	"ELSE", "END", "ENGINE", "ESCAPE", "EXCEPT", "EXISTS", "EXPLAIN", "FALSE",
+	"FOR", "FOREIGN", "FROM", "FULL", "GROUP", "HAVING", "IF", "IN", "INDEX",
+	"INNER", "INSERT", "INTERSECT", "INTO", "IS", "JOIN", "KEY", "LEFT", "LIKE",
+	"LIMIT", "NOT", "NULL", "OFFSET", "ON", "OR", "ORDER", "OUTER", "PLAN",
+	"PRAGMA", "PRIMARY", "REFERENCES", "RELEASE", "RENAME", "REPLACE", "RIGHT",
+	"ROLLBACK", "ROW", "SAVEPOINT", "SELECT", "SET", "START", "TABLE", "THEN",
+	"TO", "TRANSACTION", "TRIGGER", "TRUE", "TRUNCATE", "UNION", "UNIQUE",
+	"UNKNOWN", "UPDATE", "USING", "VALUES", "VIEW", "WHEN", "WHERE", "WITH",
+}
+
+// lexSQL splits the SQL text into tokens. Whitespaces are skipped. It returns
+// false if the text ends inside a string literal, a quoted identifier or
+// a block comment.
+func lexSQL(text string) ([]sqlToken, bool) {
+	var tokens []sqlToken
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'' || r == '"':
+			kind := sqlString
+			if r == '"' {
+				kind = sqlQuotedIdent
+			}
+			// A quote inside is escaped by doubling it.
+			end := i + 1
+			closed := false
+			for end < len(runes) {
+				if runes[end] == r {
+					if end+1 < len(runes) && runes[end+1] == r {
+						end += 2
+						continue
+					}
+					closed = true
+					end++
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, sqlToken{kind, string(runes[i:end])})
+			if !closed {
+				return tokens, false
+			}
+			i = end
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			end := i
+			for end < len(runes) && runes[end] != '\n' {
+				end++
+			}
+			tokens = append(tokens, sqlToken{sqlComment, string(runes[i:end])})
+			i = end
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			end := i + 2
+			closed := false
+			for end < len(runes) {
+				if runes[end] == '*' && end+1 < len(runes) && runes[end+1] == '/' {
+					closed = true
+					end += 2
+					break
+				}
+				end++
+			}
+			tokens = append(tokens, sqlToken{sqlComment, string(runes[i:end])})
+			if !closed {
+				return tokens, false
+			}
+			i = end
+		case unicode.IsLetter(r) || r == '_':
+			end := i + 1
+			for end < len(runes) && (unicode.IsLetter(runes[end]) ||
+				unicode.IsDigit(runes[end]) || runes[end] == '_') {
+				end++
+			}
+			tokens = append(tokens, sqlToken{sqlWord, string(runes[i:end])})
+			i = end
+		default:
+			tokens = append(tokens, sqlToken{sqlPunct, string(r)})
+			i++
+		}
+	}
+	return tokens, true
+}
+
+// isSQLStmtCompleted returns true if the SQL text is empty or its last
+// statement is terminated by a top-level semicolon. A semicolon is not
+// a top-level one inside parentheses or inside a BEGIN ... END body of
+// a trigger.
+func isSQLStmtCompleted(text string) bool {
+	tokens, ok := lexSQL(text)
+	if !ok {
+		return false
+	}
+
+	completed := true
+	depth := 0
+	// words contains first words of the current statement to detect
+	// CREATE TRIGGER.
+	var words []string
+	isTrigger := func() bool {
+		return len(words) >= 2 && words[0] == "CREATE" &&
+			(words[1] == "TRIGGER" || len(words) >= 3 && words[2] == "TRIGGER")
+	}
+	for _, token := range tokens {
+		if token.kind == sqlComment {
+			continue
+		}
+		completed = false
+
+		switch token.kind {
+		case sqlWord:
+			word := strings.ToUpper(token.text)
+			if len(words) < 3 {
+				words = append(words, word)
+			}
+			if isTrigger() {
+				switch word {
+				case "BEGIN", "CASE":
+					depth++
+				case "END":
+					depth--
+				}
+			}
+		case sqlPunct:
+			switch token.text {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			case ";":
+				if depth <= 0 {
+					completed = true
+					depth = 0
+					words = nil
+				}
+			}
+		}
+	}
+	return completed
+}
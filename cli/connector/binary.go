@@ -12,10 +12,18 @@ import (
 	_ "github.com/tarantool/go-tarantool/uuid"
 )
 
+var (
+	errStreamsNotSupported = errors.New("the instance does not support IPROTO streams")
+	errNotBoundToStream    = errors.New("the connection is not bound to a stream")
+)
+
 // BinaryConnector implements Connector interface for a connection that sends
 // and receives data via IPROTO.
 type BinaryConnector struct {
 	conn tarantool.Connector
+	// stream is an IPROTO stream for requests, it is nil if the connector
+	// is not bound to a stream.
+	stream *tarantool.Stream
 }
 
 // NewBinaryConnector creates a new BinaryConnector object. The object will
@@ -39,10 +47,89 @@ func (conn *BinaryConnector) Eval(expr string, args []interface{},
 		evalReq = evalReq.Context(ctx)
 	}
 
+	return conn.do(evalReq, opts)
+}
+
+// Call sends a call request.
+func (conn *BinaryConnector) Call(funcName string, args []interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	// Create a request.
+	callReq := tarantool.NewCall17Request(funcName).Args(args)
+	if opts.ReadTimeout != 0 {
+		ctx := context.Background()
+		ctx, cancel := context.WithTimeout(ctx, opts.ReadTimeout)
+		defer cancel()
+
+		callReq = callReq.Context(ctx)
+	}
+
+	return conn.do(callReq, opts)
+}
+
+// Insert sends an insert request. The request is not bound to a stream.
+func (conn *BinaryConnector) Insert(space, tuple interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	return conn.waitTimeout(conn.conn.InsertAsync(space, tuple), opts)
+}
+
+// Replace sends a replace request. The request is not bound to a stream.
+func (conn *BinaryConnector) Replace(space, tuple interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	return conn.waitTimeout(conn.conn.ReplaceAsync(space, tuple), opts)
+}
+
+// Delete sends a delete request. The request is not bound to a stream.
+func (conn *BinaryConnector) Delete(space, index, key interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	return conn.waitTimeout(conn.conn.DeleteAsync(space, index, key), opts)
+}
+
+// Update sends an update request with 0-based field numbers in the
+// operations. The request is not bound to a stream.
+func (conn *BinaryConnector) Update(space, index, key, ops interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	return conn.waitTimeout(conn.conn.UpdateAsync(space, index, key, ops), opts)
+}
+
+// Upsert sends an upsert request with 0-based field numbers in the
+// operations. The request is not bound to a stream.
+func (conn *BinaryConnector) Upsert(space, tuple, ops interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	return conn.waitTimeout(conn.conn.UpsertAsync(space, tuple, ops), opts)
+}
+
+// do executes the request and returns the response data.
+func (conn *BinaryConnector) do(req tarantool.Request,
+	opts RequestOpts) ([]interface{}, error) {
 	// Execute the request.
+	var future *tarantool.Future
+	if conn.stream != nil {
+		future = conn.stream.Do(req)
+	} else {
+		future = conn.conn.Do(req)
+	}
+	return conn.wait(future, opts)
+}
+
+// waitTimeout waits for the response of a request without a context
+// within the read timeout and returns the response data.
+func (conn *BinaryConnector) waitTimeout(future *tarantool.Future,
+	opts RequestOpts) ([]interface{}, error) {
+	if opts.ReadTimeout != 0 {
+		select {
+		case <-future.WaitChan():
+		case <-time.After(opts.ReadTimeout):
+			return nil, errors.New("i/o timeout")
+		}
+	}
+	return conn.wait(future, opts)
+}
+
+// wait waits for the response of the request and returns the response data.
+func (conn *BinaryConnector) wait(future *tarantool.Future,
+	opts RequestOpts) ([]interface{}, error) {
 	var err error
 	var response *tarantool.Response
-	future := conn.conn.Do(evalReq)
 	if opts.PushCallback != nil {
 		var timeout time.Duration
 		if opts.ReadTimeout != 0 {
@@ -80,6 +167,76 @@ func (conn *BinaryConnector) Eval(expr string, args []interface{},
 	return response.Data, nil
 }
 
+// BindStream binds all subsequent requests to a new IPROTO stream. It returns
+// an error if the instance does not support streams.
+func (conn *BinaryConnector) BindStream() error {
+	type protocolInfoGetter interface {
+		ServerProtocolInfo() tarantool.ProtocolInfo
+	}
+	if getter, ok := conn.conn.(protocolInfoGetter); ok {
+		features := getter.ServerProtocolInfo().Features
+		if !hasFeature(features, tarantool.StreamsFeature) ||
+			!hasFeature(features, tarantool.TransactionsFeature) {
+			return errStreamsNotSupported
+		}
+	}
+
+	stream, err := conn.conn.NewStream()
+	if err != nil {
+		return err
+	}
+	conn.stream = stream
+	return nil
+}
+
+// Begin begins an interactive transaction in the stream.
+func (conn *BinaryConnector) Begin(opts TxnOpts) error {
+	if conn.stream == nil {
+		return errNotBoundToStream
+	}
+	req := tarantool.NewBeginRequest().
+		TxnIsolation(tarantool.TxnIsolationLevel(opts.Isolation)).
+		Timeout(opts.Timeout)
+	_, err := conn.do(req, RequestOpts{})
+	return err
+}
+
+// Commit commits the transaction in the stream.
+func (conn *BinaryConnector) Commit() error {
+	if conn.stream == nil {
+		return errNotBoundToStream
+	}
+	_, err := conn.do(tarantool.NewCommitRequest(), RequestOpts{})
+	return err
+}
+
+// Rollback rolls back the transaction in the stream.
+func (conn *BinaryConnector) Rollback() error {
+	if conn.stream == nil {
+		return errNotBoundToStream
+	}
+	_, err := conn.do(tarantool.NewRollbackRequest(), RequestOpts{})
+	return err
+}
+
+// Watch subscribes to updates of the key. The connection must be established
+// with the RequireWatchers option.
+func (conn *BinaryConnector) Watch(key string,
+	callback func(key string, value interface{})) (func(), error) {
+	watcher, err := conn.conn.NewWatcher(key, func(event tarantool.WatchEvent) {
+		callback(event.Key, event.Value)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return watcher.Unregister, nil
+}
+
+// ConnectedNow returns true if the connection is established.
+func (conn *BinaryConnector) ConnectedNow() bool {
+	return conn.conn != nil && conn.conn.ConnectedNow()
+}
+
 // Close closes the tarantool.Connector created from.
 func (conn *BinaryConnector) Close() error {
 	if conn.conn != nil {
@@ -88,6 +245,17 @@ func (conn *BinaryConnector) Close() error {
 	return nil
 }
 
+// hasFeature returns true if the feature is in the list.
+func hasFeature(features []tarantool.ProtocolFeature,
+	feature tarantool.ProtocolFeature) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
 // replaceContextDone replaces "context done" error by "i/o timeout" error.
 func replaceContextDone(err error) error {
 	if err == nil || err.Error() != "context is done" {
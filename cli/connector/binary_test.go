@@ -21,10 +21,41 @@ func (conn *binaryConnectorStub) Close() error {
 	return conn.err
 }
 
+type binaryConnectorFeaturesStub struct {
+	binaryConnectorStub
+	features []tarantool.ProtocolFeature
+	streams  int
+}
+
+func (conn *binaryConnectorFeaturesStub) ServerProtocolInfo() tarantool.ProtocolInfo {
+	return tarantool.ProtocolInfo{Features: conn.features}
+}
+
+func (conn *binaryConnectorFeaturesStub) NewStream() (*tarantool.Stream, error) {
+	conn.streams++
+	return &tarantool.Stream{Id: uint64(conn.streams)}, nil
+}
+
 func TestNewBinaryConnector_implementsEvaler(t *testing.T) {
 	var _ Evaler = NewBinaryConnector(nil)
 }
 
+func TestNewBinaryConnector_implementsCaller(t *testing.T) {
+	var _ Caller = NewBinaryConnector(nil)
+}
+
+func TestNewBinaryConnector_implementsDataManipulator(t *testing.T) {
+	var _ DataManipulator = NewBinaryConnector(nil)
+}
+
+func TestNewBinaryConnector_implementsWatcher(t *testing.T) {
+	var _ Watcher = NewBinaryConnector(nil)
+}
+
+func TestNewBinaryConnector_implementsStreamer(t *testing.T) {
+	var _ Streamer = NewBinaryConnector(nil)
+}
+
 func TestNewBinaryConnector_implementsConnector(t *testing.T) {
 	var _ Connector = NewBinaryConnector(nil)
 }
@@ -53,3 +84,42 @@ func TestBinaryConnector_Close_nil(t *testing.T) {
 
 	assert.NoError(t, conn.Close())
 }
+
+func TestBinaryConnector_BindStream(t *testing.T) {
+	stub := &binaryConnectorFeaturesStub{
+		features: []tarantool.ProtocolFeature{
+			tarantool.StreamsFeature,
+			tarantool.TransactionsFeature,
+		},
+	}
+	conn := NewBinaryConnector(stub)
+
+	assert.NoError(t, conn.BindStream())
+	assert.Equal(t, 1, stub.streams)
+}
+
+func TestBinaryConnector_BindStream_notSupported(t *testing.T) {
+	cases := [][]tarantool.ProtocolFeature{
+		nil,
+		{tarantool.StreamsFeature},
+		{tarantool.TransactionsFeature, tarantool.WatchersFeature},
+	}
+
+	for _, features := range cases {
+		stub := &binaryConnectorFeaturesStub{features: features}
+		conn := NewBinaryConnector(stub)
+
+		assert.EqualError(t, conn.BindStream(),
+			"the instance does not support IPROTO streams")
+		assert.Equal(t, 0, stub.streams)
+	}
+}
+
+func TestBinaryConnector_txn_notBound(t *testing.T) {
+	conn := NewBinaryConnector(&binaryConnectorStub{})
+
+	const errMsg = "the connection is not bound to a stream"
+	assert.EqualError(t, conn.Begin(TxnOpts{}), errMsg)
+	assert.EqualError(t, conn.Commit(), errMsg)
+	assert.EqualError(t, conn.Rollback(), errMsg)
+}
@@ -33,10 +33,59 @@ type Evaler interface {
 	Eval(expr string, args []interface{}, opts RequestOpts) ([]interface{}, error)
 }
 
+// Caller is an interface that wraps Call method.
+type Caller interface {
+	// Call calls a stored function with arguments.
+	Call(funcName string, args []interface{}, opts RequestOpts) ([]interface{}, error)
+}
+
+// DataManipulator is an interface that wraps methods to send IPROTO data
+// manipulation requests. They require only read/write privileges on the
+// space unlike an evaluation of Lua code.
+type DataManipulator interface {
+	// Insert inserts the tuple into the space.
+	Insert(space, tuple interface{}, opts RequestOpts) ([]interface{}, error)
+	// Replace inserts or replaces the tuple in the space.
+	Replace(space, tuple interface{}, opts RequestOpts) ([]interface{}, error)
+	// Delete deletes a tuple by the key of the index.
+	Delete(space, index, key interface{}, opts RequestOpts) ([]interface{}, error)
+	// Update updates a tuple found by the key of the index with the
+	// operations.
+	Update(space, index, key, ops interface{}, opts RequestOpts) ([]interface{}, error)
+	// Upsert updates a tuple with the operations or inserts the tuple if
+	// it does not exist.
+	Upsert(space, tuple, ops interface{}, opts RequestOpts) ([]interface{}, error)
+}
+
+// Watcher is an interface that wraps methods to subscribe to IPROTO
+// watchers.
+type Watcher interface {
+	// Watch subscribes to updates of the key. The callback is called with
+	// the current value of the key and then on each update. It returns
+	// a function to unsubscribe.
+	Watch(key string, callback func(key string, value interface{})) (func(), error)
+	// ConnectedNow returns true if the connection is established.
+	ConnectedNow() bool
+}
+
+// Streamer is an interface that wraps methods to execute requests in an
+// IPROTO stream with interactive transactions.
+type Streamer interface {
+	// BindStream binds all subsequent requests to a new IPROTO stream.
+	BindStream() error
+	// Begin begins an interactive transaction in the stream.
+	Begin(opts TxnOpts) error
+	// Commit commits the transaction in the stream.
+	Commit() error
+	// Rollback rolls back the transaction in the stream.
+	Rollback() error
+}
+
 // Connector is an interface that wraps all method required for a
 // connector.
 type Connector interface {
 	Evaler
+	Caller
 	Close() error
 }
 
@@ -104,13 +153,19 @@ func Connect(opts ConnectOpts) (Connector, error) {
 		greetingConn.Close()
 
 		addr := fmt.Sprintf("%s://%s", opts.Network, opts.Address)
-		conn, err := tarantool.Connect(addr, tarantool.Opts{
+		connOpts := tarantool.Opts{
 			User:       opts.Username,
 			Pass:       opts.Password,
 			Transport:  transport,
 			Ssl:        tarantool.SslOpts(opts.Ssl),
-			SkipSchema: true, // We don't need a schema for eval requests.
-		})
+			SkipSchema: !opts.LoadSchema, // We don't need a schema for eval requests.
+		}
+		if opts.RequireWatchers {
+			connOpts.RequiredProtocolInfo.Features = []tarantool.ProtocolFeature{
+				tarantool.WatchersFeature,
+			}
+		}
+		conn, err := tarantool.Connect(addr, connOpts)
 		if err != nil {
 			return nil, err
 		}
@@ -173,6 +173,38 @@ func TestBinaryConnector_Eval_pushCallback(t *testing.T) {
 	}
 }
 
+func TestConnect_Call(t *testing.T) {
+	connects := createTestConnects(t)
+	for _, c := range connects {
+		defer c.connect.Close()
+	}
+
+	for _, c := range connects {
+		t.Run(c.protocol.String(), func(t *testing.T) {
+			ret, err := c.connect.Call("test_call", []interface{}{"test1", "test2"},
+				RequestOpts{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, []interface{}{"test1", "test2"}, ret)
+		})
+	}
+}
+
+func TestConnect_Call_error(t *testing.T) {
+	connects := createTestConnects(t)
+	for _, c := range connects {
+		defer c.connect.Close()
+	}
+
+	for _, c := range connects {
+		t.Run(c.protocol.String(), func(t *testing.T) {
+			_, err := c.connect.Call("unknown_function", []interface{}{}, RequestOpts{})
+
+			assert.Error(t, err)
+		})
+	}
+}
+
 func TestConnect_binary(t *testing.T) {
 	conn, err := Connect(ConnectOpts{
 		Network:  "tcp",
@@ -189,6 +221,119 @@ func TestConnect_binary(t *testing.T) {
 	assert.Equal(t, []interface{}{"hello", "world"}, ret)
 }
 
+func TestConnect_binaryWatch(t *testing.T) {
+	test_helpers.SkipIfWatchersUnsupported(t)
+
+	conn, err := Connect(ConnectOpts{
+		Network:         "tcp",
+		Address:         server,
+		Username:        "test",
+		Password:        "password",
+		RequireWatchers: true,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	watcher, ok := conn.(Watcher)
+	require.True(t, ok)
+	assert.True(t, watcher.ConnectedNow())
+
+	values := make(chan interface{}, 2)
+	unwatch, err := watcher.Watch("test_key", func(key string, value interface{}) {
+		assert.Equal(t, "test_key", key)
+		values <- value
+	})
+	require.NoError(t, err)
+	defer unwatch()
+
+	assert.Nil(t, <-values)
+	_, err = conn.Eval("box.broadcast('test_key', 'test_value')",
+		[]interface{}{}, RequestOpts{})
+	require.NoError(t, err)
+	select {
+	case value := <-values:
+		assert.Equal(t, "test_value", value)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification received")
+	}
+}
+
+func TestConnect_binaryStream(t *testing.T) {
+	test_helpers.SkipIfStreamsUnsupported(t)
+
+	conn, err := Connect(ConnectOpts{
+		Network:  "tcp",
+		Address:  server,
+		Username: "test",
+		Password: "password",
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	streamer, ok := conn.(Streamer)
+	require.True(t, ok)
+	require.NoError(t, streamer.BindStream())
+
+	isInTxn := func() bool {
+		ret, err := conn.Eval("return box.is_in_txn()", []interface{}{}, RequestOpts{})
+		require.NoError(t, err)
+		return ret[0].(bool)
+	}
+
+	assert.False(t, isInTxn())
+	require.NoError(t, streamer.Begin(TxnOpts{Isolation: ReadCommittedTxnIsolation}))
+	assert.True(t, isInTxn())
+	require.NoError(t, streamer.Commit())
+	assert.False(t, isInTxn())
+
+	require.NoError(t, streamer.Begin(TxnOpts{}))
+	assert.True(t, isInTxn())
+	require.NoError(t, streamer.Rollback())
+	assert.False(t, isInTxn())
+}
+
+func TestConnect_binaryDataManipulator(t *testing.T) {
+	conn, err := Connect(ConnectOpts{
+		Network:    "tcp",
+		Address:    server,
+		Username:   "dml",
+		Password:   "password",
+		LoadSchema: true,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	manipulator, ok := conn.(DataManipulator)
+	require.True(t, ok)
+
+	// The user has no execute privilege.
+	_, err = conn.Eval("return 1", []interface{}{}, RequestOpts{})
+	require.Error(t, err)
+
+	ret, err := manipulator.Insert("test_dml", []interface{}{1, "a"}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{uint64(1), "a"}}, ret)
+
+	_, err = manipulator.Replace("test_dml", []interface{}{1, "b"}, RequestOpts{})
+	require.NoError(t, err)
+
+	ret, err = manipulator.Update("test_dml", 0, []interface{}{1},
+		[]interface{}{[]interface{}{"=", 1, "c"}}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{uint64(1), "c"}}, ret)
+
+	_, err = manipulator.Upsert("test_dml", []interface{}{2, "d"},
+		[]interface{}{[]interface{}{"=", 1, "e"}}, RequestOpts{})
+	require.NoError(t, err)
+
+	ret, err = manipulator.Delete("test_dml", 0, []interface{}{2}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{uint64(2), "d"}}, ret)
+
+	_, err = manipulator.Insert("test_dml", []interface{}{1, "a"}, RequestOpts{})
+	assert.ErrorContains(t, err, "Duplicate key exists")
+}
+
 func TestConnect_binaryTlsToNoTls(t *testing.T) {
 	_, err := Connect(ConnectOpts{
 		Network:  "tcp",
@@ -321,6 +466,47 @@ func TestPoolEval_error(t *testing.T) {
 	}
 }
 
+func TestPoolCall_success(t *testing.T) {
+	for _, tc := range poolCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			pool, err := ConnectPool(tc.Opts)
+			require.NoError(t, err)
+			require.NotNil(t, pool)
+			defer pool.Close()
+
+			ret, err := pool.Call("test_call", []any{"foo"}, RequestOpts{})
+			assert.NoError(t, err)
+			assert.Equal(t, ret, []any{"foo"})
+		})
+	}
+}
+
+func TestRolePool_success(t *testing.T) {
+	for _, tc := range poolCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			pool, err := ConnectRolePool(tc.Opts, RolePoolOpts{Mode: ModeRW})
+			require.NoError(t, err)
+			require.NotNil(t, pool)
+			defer pool.Close()
+
+			assert.Empty(t, pool.Members(ModeRO))
+			assert.NotEmpty(t, pool.Members(ModeRW))
+
+			ret, err := pool.Call("test_call", []any{"foo"}, RequestOpts{})
+			assert.NoError(t, err)
+			assert.Equal(t, ret, []any{"foo"})
+
+			ret, err = pool.EvalMode(ModePreferRO, "return box.info.ro", []any{},
+				RequestOpts{})
+			assert.NoError(t, err)
+			assert.Equal(t, ret, []any{false})
+
+			_, err = pool.EvalMode(ModeRO, "return 1", []any{}, RequestOpts{})
+			assert.EqualError(t, err, `no available instance in "ro" mode`)
+		})
+	}
+}
+
 func runTestMain(m *testing.M) int {
 	inst, err := test_helpers.StartTarantool(test_helpers.StartOpts{
 		InitScript:   "testdata/config.lua",
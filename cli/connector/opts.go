@@ -18,6 +18,13 @@ type ConnectOpts struct {
 	Password string
 	// Ssl options for a connection.
 	Ssl SslOpts
+	// RequireWatchers requires IPROTO watchers support from the instance.
+	// It is used only for a binary protocol connection.
+	RequireWatchers bool
+	// LoadSchema loads the schema on connect to resolve space names in data
+	// manipulation requests. It is used only for a binary protocol
+	// connection.
+	LoadSchema bool
 }
 
 // SslOpts is a way to configure SSL connection.
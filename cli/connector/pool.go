@@ -39,6 +39,21 @@ func ConnectPool(opts []ConnectOpts) (*Pool, error) {
 // Eval executes the expression on each connectable instance until
 // success.
 func (pool *Pool) Eval(expr string, args []any, opts RequestOpts) ([]any, error) {
+	return pool.do(func(conn Connector) ([]any, error) {
+		return conn.Eval(expr, args, opts)
+	})
+}
+
+// Call calls the function on each connectable instance until success.
+func (pool *Pool) Call(funcName string, args []any, opts RequestOpts) ([]any, error) {
+	return pool.do(func(conn Connector) ([]any, error) {
+		return conn.Call(funcName, args, opts)
+	})
+}
+
+// do executes the request function on each connectable instance until
+// success.
+func (pool *Pool) do(request func(conn Connector) ([]any, error)) ([]any, error) {
 	var err error
 	for i := 0; i < len(pool.opts); i++ {
 		if pool.current == nil {
@@ -51,7 +66,7 @@ func (pool *Pool) Eval(expr string, args []any, opts RequestOpts) ([]any, error)
 		}
 
 		var ret []any
-		ret, err = pool.current.Eval(expr, args, opts)
+		ret, err = request(pool.current)
 		if err == nil {
 			return ret, nil
 		}
@@ -63,7 +78,7 @@ func (pool *Pool) Eval(expr string, args []any, opts RequestOpts) ([]any, error)
 
 	if err == nil {
 		err = errFailedToConnect
-	} // Else it contains a last error from pool.current.Eval().
+	} // Else it contains a last error from the request.
 	return nil, err
 }
 
@@ -0,0 +1,422 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	anyModeStr      = "any"
+	rwModeStr       = "rw"
+	roModeStr       = "ro"
+	preferRoModeStr = "prefer_ro"
+)
+
+const (
+	// DefaultCheckInterval is a default interval between health checks of
+	// the pool members.
+	DefaultCheckInterval = time.Second
+	// DefaultCheckTimeout is a default timeout of a health check request.
+	DefaultCheckTimeout = 3 * time.Second
+)
+
+// checkRoExpr is a Lua expression to get a mode of an instance.
+const checkRoExpr = "return box.info.ro"
+
+// Mode defines a mode of an instance required for a request.
+type Mode int
+
+const (
+	// ModeAny is any healthy instance.
+	ModeAny Mode = iota
+	// ModeRW is a read-write instance.
+	ModeRW
+	// ModeRO is a read-only instance.
+	ModeRO
+	// ModePreferRO is a read-only instance if there is one, otherwise
+	// a read-write instance.
+	ModePreferRO
+)
+
+// ParseMode parses a mode string representation. It supports mixed case
+// letters.
+func ParseMode(str string) (Mode, bool) {
+	switch strings.ToLower(str) {
+	case anyModeStr:
+		return ModeAny, true
+	case rwModeStr:
+		return ModeRW, true
+	case roModeStr:
+		return ModeRO, true
+	case preferRoModeStr:
+		return ModePreferRO, true
+	}
+	return ModeAny, false
+}
+
+// String returns a string representation of the mode.
+func (mode Mode) String() string {
+	switch mode {
+	case ModeAny:
+		return anyModeStr
+	case ModeRW:
+		return rwModeStr
+	case ModeRO:
+		return roModeStr
+	case ModePreferRO:
+		return preferRoModeStr
+	default:
+		panic("Unknown mode")
+	}
+}
+
+// RolePoolOpts describes options of a role-aware connection pool.
+type RolePoolOpts struct {
+	// Mode is a mode of an instance used for Eval and Call requests.
+	Mode Mode
+	// CheckInterval is an interval between health checks of the members.
+	// DefaultCheckInterval is used if it is zero.
+	CheckInterval time.Duration
+	// CheckTimeout is a timeout of a health check request.
+	// DefaultCheckTimeout is used if it is zero.
+	CheckTimeout time.Duration
+	// Connect is a function to connect to a member. Connect is used if it is
+	// nil.
+	Connect func(opts ConnectOpts) (Connector, error)
+}
+
+// MemberInfo describes a state of a pool member.
+type MemberInfo struct {
+	// Opts are options of a connection to the member.
+	Opts ConnectOpts
+	// RO is true if the member is read-only.
+	RO bool
+}
+
+// poolMember is a member of the role-aware pool.
+type poolMember struct {
+	opts ConnectOpts
+	// connMutex serializes health checks of the member and requests via
+	// a connection that does not support concurrent requests.
+	connMutex sync.Mutex
+	// conn, healthy and ro are protected by the pool mutex.
+	conn    Connector
+	healthy bool
+	ro      bool
+}
+
+// RolePool is a connection pool that tracks modes of the members and routes
+// requests according to a required mode. It checks the members and
+// reconnects to them in the background.
+type RolePool struct {
+	opts    RolePoolOpts
+	members []*poolMember
+	mutex   sync.RWMutex
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// ConnectRolePool creates a role-aware connection pool object. It makes sure
+// that it can connect to at least one instance.
+func ConnectRolePool(opts []ConnectOpts, poolOpts RolePoolOpts) (*RolePool, error) {
+	if poolOpts.CheckInterval == 0 {
+		poolOpts.CheckInterval = DefaultCheckInterval
+	}
+	if poolOpts.CheckTimeout == 0 {
+		poolOpts.CheckTimeout = DefaultCheckTimeout
+	}
+	if poolOpts.Connect == nil {
+		poolOpts.Connect = Connect
+	}
+
+	pool := &RolePool{
+		opts: poolOpts,
+		done: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		pool.members = append(pool.members, &poolMember{opts: opt})
+	}
+
+	pool.checkMembers()
+	if len(pool.Members(ModeAny)) == 0 {
+		pool.closeMembers()
+		return nil, errFailedToConnect
+	}
+
+	pool.wg.Add(1)
+	go pool.checker()
+	return pool, nil
+}
+
+// Members returns a list of healthy members in the mode. The read-only
+// members go first for ModePreferRO.
+func (pool *RolePool) Members(mode Mode) []MemberInfo {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	var members []MemberInfo
+	for _, member := range pool.pick(mode) {
+		members = append(members, MemberInfo{
+			Opts: member.opts,
+			RO:   member.ro,
+		})
+	}
+	return members
+}
+
+// Eval executes the expression on an instance in the pool mode.
+func (pool *RolePool) Eval(expr string, args []any, opts RequestOpts) ([]any, error) {
+	return pool.EvalMode(pool.opts.Mode, expr, args, opts)
+}
+
+// Call calls the function on an instance in the pool mode.
+func (pool *RolePool) Call(funcName string, args []any, opts RequestOpts) ([]any, error) {
+	return pool.CallMode(pool.opts.Mode, funcName, args, opts)
+}
+
+// EvalMode executes the expression on an instance in the mode.
+func (pool *RolePool) EvalMode(mode Mode, expr string, args []any,
+	opts RequestOpts) ([]any, error) {
+	return pool.do(mode, func(conn Connector) ([]any, error) {
+		return conn.Eval(expr, args, opts)
+	})
+}
+
+// CallMode calls the function on an instance in the mode.
+func (pool *RolePool) CallMode(mode Mode, funcName string, args []any,
+	opts RequestOpts) ([]any, error) {
+	return pool.do(mode, func(conn Connector) ([]any, error) {
+		return conn.Call(funcName, args, opts)
+	})
+}
+
+// Close stops the background checks and closes the pool.
+func (pool *RolePool) Close() error {
+	select {
+	case <-pool.done:
+		return nil
+	default:
+	}
+	close(pool.done)
+	pool.wg.Wait()
+	return pool.closeMembers()
+}
+
+// do executes the request function on a member in the mode. If the request
+// fails because the member is unavailable or it has changed the mode, the
+// request is retried once on another member.
+func (pool *RolePool) do(mode Mode, request func(conn Connector) ([]any, error)) ([]any, error) {
+	member := pool.first(mode)
+	if member == nil {
+		// The state could be outdated, e.g. right after a switchover.
+		pool.checkMembers()
+		if member = pool.first(mode); member == nil {
+			return nil, fmt.Errorf("no available instance in %q mode", mode)
+		}
+	}
+
+	ret, err := pool.request(member, request)
+	if err == nil {
+		return ret, nil
+	}
+
+	pool.checkMember(member)
+	if pool.matches(member, mode) {
+		// The member is fine, so it is an error of the request.
+		return nil, err
+	}
+
+	pool.checkMembers()
+	if member = pool.first(mode); member == nil {
+		return nil, err
+	}
+	return pool.request(member, request)
+}
+
+// request executes the request function via the member connection. The
+// member lock is held during the request only for a connection that does not
+// support concurrent requests.
+func (pool *RolePool) request(member *poolMember,
+	request func(conn Connector) ([]any, error)) ([]any, error) {
+	pool.mutex.RLock()
+	conn := member.conn
+	pool.mutex.RUnlock()
+	if conn == nil {
+		return nil, errors.New("the instance is not connected")
+	}
+
+	if !isConcurrent(conn) {
+		member.connMutex.Lock()
+		defer member.connMutex.Unlock()
+	}
+	return request(conn)
+}
+
+// pick returns healthy members in the mode. It must be called under the
+// pool mutex.
+func (pool *RolePool) pick(mode Mode) []*poolMember {
+	var ro, rw []*poolMember
+	for _, member := range pool.members {
+		if !member.healthy {
+			continue
+		}
+		if member.ro {
+			ro = append(ro, member)
+		} else {
+			rw = append(rw, member)
+		}
+	}
+
+	switch mode {
+	case ModeRW:
+		return rw
+	case ModeRO:
+		return ro
+	case ModePreferRO:
+		return append(ro, rw...)
+	default:
+		var members []*poolMember
+		for _, member := range pool.members {
+			if member.healthy {
+				members = append(members, member)
+			}
+		}
+		return members
+	}
+}
+
+// first returns the first healthy member in the mode or nil.
+func (pool *RolePool) first(mode Mode) *poolMember {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	if members := pool.pick(mode); len(members) > 0 {
+		return members[0]
+	}
+	return nil
+}
+
+// matches returns true if the member is healthy and fits the mode.
+func (pool *RolePool) matches(member *poolMember, mode Mode) bool {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	for _, picked := range pool.pick(mode) {
+		if picked == member {
+			return true
+		}
+	}
+	return false
+}
+
+// checker checks the members periodically until the pool is closed.
+func (pool *RolePool) checker() {
+	defer pool.wg.Done()
+
+	ticker := time.NewTicker(pool.opts.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pool.done:
+			return
+		case <-ticker.C:
+			pool.checkMembers()
+		}
+	}
+}
+
+// checkMembers checks all members concurrently.
+func (pool *RolePool) checkMembers() {
+	var wg sync.WaitGroup
+	for _, member := range pool.members {
+		wg.Add(1)
+		go func(member *poolMember) {
+			defer wg.Done()
+			pool.checkMember(member)
+		}(member)
+	}
+	wg.Wait()
+}
+
+// checkMember reconnects to the member if needed and updates its mode. The
+// check is skipped if the member is busy: it is checked by another goroutine
+// or it serves a request via a connection that does not support concurrent
+// requests.
+func (pool *RolePool) checkMember(member *poolMember) {
+	if !member.connMutex.TryLock() {
+		return
+	}
+	defer member.connMutex.Unlock()
+
+	pool.mutex.RLock()
+	conn := member.conn
+	pool.mutex.RUnlock()
+
+	if conn == nil {
+		var err error
+		if conn, err = pool.opts.Connect(member.opts); err != nil {
+			pool.setMemberState(member, nil, false, false)
+			return
+		}
+	}
+
+	ro, err := checkRO(conn, pool.opts.CheckTimeout)
+	if err != nil {
+		conn.Close()
+		pool.setMemberState(member, nil, false, false)
+		return
+	}
+	pool.setMemberState(member, conn, true, ro)
+}
+
+// setMemberState updates the member state under the pool mutex.
+func (pool *RolePool) setMemberState(member *poolMember, conn Connector,
+	healthy, ro bool) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	member.conn = conn
+	member.healthy = healthy
+	member.ro = ro
+}
+
+// closeMembers closes connections to all members.
+func (pool *RolePool) closeMembers() error {
+	var errs []error
+	for _, member := range pool.members {
+		member.connMutex.Lock()
+		if member.conn != nil {
+			if err := member.conn.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		pool.setMemberState(member, nil, false, false)
+		member.connMutex.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// isConcurrent returns true if the connection supports concurrent requests.
+func isConcurrent(conn Connector) bool {
+	_, text := conn.(*TextConnector)
+	return !text
+}
+
+// checkRO returns true if the instance is read-only.
+func checkRO(conn Connector, timeout time.Duration) (bool, error) {
+	ret, err := conn.Eval(checkRoExpr, []any{}, RequestOpts{ReadTimeout: timeout})
+	if err != nil {
+		return false, err
+	}
+	if len(ret) != 1 {
+		return false, fmt.Errorf("unexpected response: %v", ret)
+	}
+	ro, ok := ret[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("unexpected response: %v", ret)
+	}
+	return ro, nil
+}
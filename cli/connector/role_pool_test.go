@@ -0,0 +1,337 @@
+package connector_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/tt/cli/connector"
+)
+
+// fakeInstance is a state of a fake instance.
+type fakeInstance struct {
+	ro       bool
+	down     bool
+	requests int
+}
+
+// fakeCluster is a set of fake instances by addresses.
+type fakeCluster struct {
+	mutex     sync.Mutex
+	instances map[string]*fakeInstance
+	// release unblocks "slow" requests.
+	release chan struct{}
+}
+
+func newFakeCluster(instances map[string]*fakeInstance) *fakeCluster {
+	return &fakeCluster{instances: instances, release: make(chan struct{})}
+}
+
+func (cluster *fakeCluster) set(addr string, ro, down bool) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	cluster.instances[addr].ro = ro
+	cluster.instances[addr].down = down
+}
+
+func (cluster *fakeCluster) requests(addr string) int {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	return cluster.instances[addr].requests
+}
+
+func (cluster *fakeCluster) connect(opts ConnectOpts) (Connector, error) {
+	cluster.mutex.Lock()
+	defer cluster.mutex.Unlock()
+	if cluster.instances[opts.Address].down {
+		return nil, errors.New("connection refused")
+	}
+	return &fakeConnector{cluster: cluster, addr: opts.Address}, nil
+}
+
+// fakeConnector is a connection to a fake instance.
+type fakeConnector struct {
+	cluster *fakeCluster
+	addr    string
+}
+
+func (conn *fakeConnector) Eval(expr string, args []interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	if expr == "slow" {
+		<-conn.cluster.release
+	}
+	conn.cluster.mutex.Lock()
+	defer conn.cluster.mutex.Unlock()
+
+	instance := conn.cluster.instances[conn.addr]
+	if instance.down {
+		return nil, errors.New("connection lost")
+	}
+	if expr == "return box.info.ro" {
+		return []interface{}{instance.ro}, nil
+	}
+
+	instance.requests++
+	if expr == "write" && instance.ro {
+		return nil, errors.New("Can't modify data on a read-only instance")
+	}
+	if expr == "error" {
+		return nil, errors.New("request error")
+	}
+	return []interface{}{conn.addr}, nil
+}
+
+func (conn *fakeConnector) Call(funcName string, args []interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	return conn.Eval(funcName, args, opts)
+}
+
+func (conn *fakeConnector) Close() error {
+	return nil
+}
+
+func connectFakePool(t *testing.T, cluster *fakeCluster, mode Mode) *RolePool {
+	t.Helper()
+
+	pool, err := ConnectRolePool([]ConnectOpts{
+		{Network: TCPNetwork, Address: "a"},
+		{Network: TCPNetwork, Address: "b"},
+		{Network: TCPNetwork, Address: "c"},
+	}, RolePoolOpts{
+		Mode:          mode,
+		CheckInterval: 10 * time.Millisecond,
+		Connect:       cluster.connect,
+	})
+	require.NoError(t, err)
+	return pool
+}
+
+func memberAddrs(members []MemberInfo) []string {
+	addrs := []string{}
+	for _, member := range members {
+		addrs = append(addrs, member.Opts.Address)
+	}
+	return addrs
+}
+
+func TestNewRolePool_implementsConnector(t *testing.T) {
+	var _ Connector = &RolePool{}
+}
+
+func TestParseMode(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected Mode
+		ok       bool
+	}{
+		{"any", ModeAny, true},
+		{"rw", ModeRW, true},
+		{"RW", ModeRW, true},
+		{"ro", ModeRO, true},
+		{"prefer_ro", ModePreferRO, true},
+		{"Prefer_RO", ModePreferRO, true},
+		{"master", ModeAny, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			mode, ok := ParseMode(c.str)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.expected, mode)
+		})
+	}
+}
+
+func TestMode_String(t *testing.T) {
+	assert.Equal(t, "any", ModeAny.String())
+	assert.Equal(t, "rw", ModeRW.String())
+	assert.Equal(t, "ro", ModeRO.String())
+	assert.Equal(t, "prefer_ro", ModePreferRO.String())
+	assert.PanicsWithValue(t, "Unknown mode", func() { _ = Mode(666).String() })
+}
+
+func TestConnectRolePool_failed_to_connect(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {down: true},
+	})
+	pool, err := ConnectRolePool([]ConnectOpts{{Address: "a"}},
+		RolePoolOpts{Connect: cluster.connect})
+	assert.Nil(t, pool)
+	assert.EqualError(t, err, "failed to connect to any instance")
+
+	pool, err = ConnectRolePool(nil, RolePoolOpts{Connect: cluster.connect})
+	assert.Nil(t, pool)
+	assert.EqualError(t, err, "failed to connect to any instance")
+}
+
+func TestRolePool_Members(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: false},
+		"b": {ro: true},
+		"c": {down: true},
+	})
+	pool := connectFakePool(t, cluster, ModeAny)
+	defer pool.Close()
+
+	assert.Equal(t, []string{"a", "b"}, memberAddrs(pool.Members(ModeAny)))
+	assert.Equal(t, []string{"a"}, memberAddrs(pool.Members(ModeRW)))
+	assert.Equal(t, []string{"b"}, memberAddrs(pool.Members(ModeRO)))
+	assert.Equal(t, []string{"b", "a"}, memberAddrs(pool.Members(ModePreferRO)))
+	assert.Equal(t, []MemberInfo{{Opts: ConnectOpts{Network: TCPNetwork, Address: "b"},
+		RO: true}}, pool.Members(ModeRO))
+}
+
+func TestRolePool_EvalMode(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: true},
+		"b": {ro: false},
+		"c": {ro: true},
+	})
+	pool := connectFakePool(t, cluster, ModeRW)
+	defer pool.Close()
+
+	cases := []struct {
+		mode     Mode
+		expected string
+	}{
+		{ModeAny, "a"},
+		{ModeRW, "b"},
+		{ModeRO, "a"},
+		{ModePreferRO, "a"},
+	}
+	for _, c := range cases {
+		t.Run(c.mode.String(), func(t *testing.T) {
+			ret, err := pool.EvalMode(c.mode, "return", []any{}, RequestOpts{})
+			require.NoError(t, err)
+			assert.Equal(t, []any{c.expected}, ret)
+		})
+	}
+
+	ret, err := pool.Eval("return", []any{}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"b"}, ret)
+
+	ret, err = pool.CallMode(ModeRO, "return", []any{}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a"}, ret)
+}
+
+func TestRolePool_preferRO_fallback(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: false},
+		"b": {down: true},
+		"c": {down: true},
+	})
+	pool := connectFakePool(t, cluster, ModePreferRO)
+	defer pool.Close()
+
+	ret, err := pool.Eval("return", []any{}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a"}, ret)
+
+	_, err = pool.EvalMode(ModeRO, "return", []any{}, RequestOpts{})
+	assert.EqualError(t, err, `no available instance in "ro" mode`)
+}
+
+func TestRolePool_request_error(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: false},
+		"b": {ro: true},
+		"c": {ro: true},
+	})
+	pool := connectFakePool(t, cluster, ModeRW)
+	defer pool.Close()
+
+	// An error of a healthy member is not retried.
+	_, err := pool.Eval("error", []any{}, RequestOpts{})
+	assert.EqualError(t, err, "request error")
+	assert.Equal(t, 1, cluster.requests("a"))
+}
+
+func TestRolePool_switchover(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: false},
+		"b": {ro: true},
+		"c": {ro: true},
+	})
+	pool := connectFakePool(t, cluster, ModeRW)
+	defer pool.Close()
+
+	// The master has been changed between health checks.
+	cluster.set("a", true, false)
+	cluster.set("c", false, false)
+	ret, err := pool.Eval("write", []any{}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"c"}, ret)
+
+	// The master is down.
+	cluster.set("c", false, true)
+	cluster.set("b", false, false)
+	ret, err = pool.Eval("write", []any{}, RequestOpts{})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"b"}, ret)
+}
+
+func TestRolePool_reconnect(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: true},
+		"b": {down: true},
+		"c": {down: true},
+	})
+	pool := connectFakePool(t, cluster, ModeRW)
+	defer pool.Close()
+
+	assert.Equal(t, []string{"a"}, memberAddrs(pool.Members(ModeAny)))
+	cluster.set("b", false, false)
+	assert.Eventually(t, func() bool {
+		return len(pool.Members(ModeRW)) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"b"}, memberAddrs(pool.Members(ModeRW)))
+
+	cluster.set("a", true, true)
+	assert.Eventually(t, func() bool {
+		return len(pool.Members(ModeAny)) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRolePool_slowRequest(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: false},
+		"b": {ro: true},
+		"c": {ro: true},
+	})
+	pool := connectFakePool(t, cluster, ModeRW)
+	defer pool.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		pool.Eval("slow", []any{}, RequestOpts{})
+	}()
+
+	// The health checks are not blocked by the request.
+	cluster.set("a", true, false)
+	assert.Eventually(t, func() bool {
+		return len(pool.Members(ModeRW)) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	close(cluster.release)
+	<-done
+}
+
+func TestRolePool_Close(t *testing.T) {
+	cluster := newFakeCluster(map[string]*fakeInstance{
+		"a": {ro: false},
+		"b": {ro: true},
+		"c": {ro: true},
+	})
+	pool := connectFakePool(t, cluster, ModeAny)
+
+	assert.NoError(t, pool.Close())
+	assert.NoError(t, pool.Close())
+	assert.Empty(t, pool.Members(ModeAny))
+}
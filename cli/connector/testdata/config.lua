@@ -8,8 +8,16 @@ box.cfg{
 box.once("init", function()
     box.schema.user.create('test', {password = 'password'})
     box.schema.user.grant('test', 'execute', 'universe')
+    box.schema.user.create('dml', {password = 'password'})
+    local space = box.schema.space.create('test_dml')
+    space:create_index('primary')
+    box.schema.user.grant('dml', 'read,write', 'space', 'test_dml')
 end)
 
+function test_call(...)
+    return ...
+end
+
 require("console").listen("unix/:./console.control")
 -- Set listen only when every other thing is configured.
 box.cfg{
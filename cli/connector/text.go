@@ -29,6 +29,18 @@ func (conn *TextConnector) Eval(expr string, args []interface{},
 	return evalPlainTextConn(conn.conn, expr, args, evalOpts)
 }
 
+// Call sends a call request. The function is called via the plain text
+// protocol as an evaluated expression.
+func (conn *TextConnector) Call(funcName string, args []interface{},
+	opts RequestOpts) ([]interface{}, error) {
+	callOpts := EvalPlainTextOpts{
+		PushCallback: opts.PushCallback,
+		ReadTimeout:  opts.ReadTimeout,
+		ResData:      opts.ResData,
+	}
+	return callPlainTextConn(conn.conn, funcName, args, callOpts)
+}
+
 // Close closes the net.Conn created from.
 func (conn *TextConnector) Close() error {
 	if conn.conn != nil {
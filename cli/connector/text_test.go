@@ -25,6 +25,10 @@ func TestNewTextConnector_implementsEvaler(t *testing.T) {
 	var _ Evaler = NewTextConnector(nil)
 }
 
+func TestNewTextConnector_implementsCaller(t *testing.T) {
+	var _ Caller = NewTextConnector(nil)
+}
+
 func TestNewTextConnector_implementsConnector(t *testing.T) {
 	var _ Connector = NewTextConnector(nil)
 }
@@ -0,0 +1,71 @@
+package connector
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	defaultTxnIsolationStr       = "default"
+	readCommittedTxnIsolationStr = "read-committed"
+	readConfirmedTxnIsolationStr = "read-confirmed"
+	bestEffortTxnIsolationStr    = "best-effort"
+)
+
+// TxnIsolation is an isolation level of a transaction.
+type TxnIsolation int
+
+const (
+	// DefaultTxnIsolation is an isolation level from the instance
+	// configuration.
+	DefaultTxnIsolation TxnIsolation = iota
+	// ReadCommittedTxnIsolation makes visible all transactions that started
+	// commit.
+	ReadCommittedTxnIsolation
+	// ReadConfirmedTxnIsolation makes visible all transactions that finished
+	// commit.
+	ReadConfirmedTxnIsolation
+	// BestEffortTxnIsolation is a serializable isolation if possible.
+	BestEffortTxnIsolation
+)
+
+// ParseTxnIsolation parses an isolation level string representation. It
+// supports mixed case letters.
+func ParseTxnIsolation(str string) (TxnIsolation, bool) {
+	switch strings.ToLower(str) {
+	case defaultTxnIsolationStr:
+		return DefaultTxnIsolation, true
+	case readCommittedTxnIsolationStr:
+		return ReadCommittedTxnIsolation, true
+	case readConfirmedTxnIsolationStr:
+		return ReadConfirmedTxnIsolation, true
+	case bestEffortTxnIsolationStr:
+		return BestEffortTxnIsolation, true
+	}
+	return DefaultTxnIsolation, false
+}
+
+// String returns a string representation of the isolation level.
+func (level TxnIsolation) String() string {
+	switch level {
+	case DefaultTxnIsolation:
+		return defaultTxnIsolationStr
+	case ReadCommittedTxnIsolation:
+		return readCommittedTxnIsolationStr
+	case ReadConfirmedTxnIsolation:
+		return readConfirmedTxnIsolationStr
+	case BestEffortTxnIsolation:
+		return bestEffortTxnIsolationStr
+	default:
+		panic("Unknown transaction isolation level")
+	}
+}
+
+// TxnOpts describes options of an interactive transaction.
+type TxnOpts struct {
+	// Isolation is an isolation level of the transaction.
+	Isolation TxnIsolation
+	// Timeout is a timeout of the transaction. The instance default is used
+	// if it is zero.
+	Timeout time.Duration
+}
@@ -0,0 +1,43 @@
+package connector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/tarantool/tt/cli/connector"
+)
+
+func TestParseTxnIsolation(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected TxnIsolation
+		ok       bool
+	}{
+		{"default", DefaultTxnIsolation, true},
+		{"read-committed", ReadCommittedTxnIsolation, true},
+		{"Read-Committed", ReadCommittedTxnIsolation, true},
+		{"read-confirmed", ReadConfirmedTxnIsolation, true},
+		{"best-effort", BestEffortTxnIsolation, true},
+		{"BEST-EFFORT", BestEffortTxnIsolation, true},
+		{"serializable", DefaultTxnIsolation, false},
+		{"", DefaultTxnIsolation, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			level, ok := ParseTxnIsolation(c.str)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.expected, level)
+		})
+	}
+}
+
+func TestTxnIsolation_String(t *testing.T) {
+	assert.Equal(t, "default", DefaultTxnIsolation.String())
+	assert.Equal(t, "read-committed", ReadCommittedTxnIsolation.String())
+	assert.Equal(t, "read-confirmed", ReadConfirmedTxnIsolation.String())
+	assert.Equal(t, "best-effort", BestEffortTxnIsolation.String())
+	assert.PanicsWithValue(t, "Unknown transaction isolation level",
+		func() { _ = TxnIsolation(666).String() })
+}
@@ -0,0 +1,471 @@
+package formatter
+
+import (
+	"strings"
+)
+
+// ColorTheme defines a set of supported color themes for the syntax
+// highlighting.
+type ColorTheme int
+
+const (
+	DarkColorTheme ColorTheme = iota
+	LightColorTheme
+)
+
+const (
+	darkColorThemeStr  = "dark"
+	lightColorThemeStr = "light"
+)
+
+const (
+	// DefaultColorTheme is a default color theme.
+	DefaultColorTheme ColorTheme = DarkColorTheme
+)
+
+// palette contains ANSI SGR parameters for the types of tokens. An empty
+// value means no color.
+type palette struct {
+	key     string
+	str     string
+	number  string
+	null    string
+	boolean string
+	err     string
+	punct   string
+}
+
+// palettes contains palettes of the color themes.
+var palettes = map[ColorTheme]palette{
+	DarkColorTheme: {
+		key:     "36",
+		str:     "32",
+		number:  "33",
+		null:    "1;35",
+		boolean: "35",
+		err:     "1;31",
+		punct:   "90",
+	},
+	LightColorTheme: {
+		key:     "34",
+		str:     "32",
+		number:  "36",
+		null:    "1;35",
+		boolean: "35",
+		err:     "1;31",
+		punct:   "90",
+	},
+}
+
+// ParseColorTheme parses a color theme string representation. It supports
+// mixed case letters.
+func ParseColorTheme(str string) (ColorTheme, bool) {
+	switch strings.ToLower(str) {
+	case darkColorThemeStr:
+		return DarkColorTheme, true
+	case lightColorThemeStr:
+		return LightColorTheme, true
+	}
+	return DefaultColorTheme, false
+}
+
+// String returns a string representation of the color theme.
+func (theme ColorTheme) String() string {
+	switch theme {
+	case DarkColorTheme:
+		return darkColorThemeStr
+	case LightColorTheme:
+		return lightColorThemeStr
+	default:
+		panic("Unknown color theme")
+	}
+}
+
+// Highlight returns the output of the format with highlighted syntax. Only
+// yaml and lua formats are highlighted, the output of other formats is
+// returned as is.
+func Highlight(format Format, output string, theme ColorTheme) string {
+	switch format {
+	case YamlFormat:
+		return highlightYaml(output, palettes[theme])
+	case LuaFormat:
+		return highlightCode(output, palettes[theme])
+	default:
+		return output
+	}
+}
+
+// paint writes the text wrapped into the color escape sequences.
+func paint(sb *strings.Builder, code string, text string) {
+	if code == "" || text == "" {
+		sb.WriteString(text)
+		return
+	}
+	sb.WriteString("\x1b[" + code + "m" + text + "\x1b[0m")
+}
+
+// isNumber returns true if the plain scalar is a number.
+func isNumber(str string) bool {
+	if str == "" {
+		return false
+	}
+	switch strings.ToLower(strings.TrimLeft(str, "+-")) {
+	case "inf", ".inf", "nan", ".nan":
+		return true
+	}
+	if str[0] == '-' || str[0] == '+' {
+		str = str[1:]
+	}
+	if str == "" || (str[0] < '0' || str[0] > '9') && str[0] != '.' {
+		return false
+	}
+	for i, r := range str {
+		switch {
+		case r >= '0' && r <= '9', r == '.', r == '_':
+		case r == 'e' || r == 'E':
+		case (r == '+' || r == '-') && i > 0 && (str[i-1] == 'e' || str[i-1] == 'E'):
+		case strings.HasPrefix(strings.ToLower(str), "0x") &&
+			(r == 'x' || r == 'X' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// scalarCode returns a color of a plain scalar.
+func (p palette) scalarCode(str string, nullStr string) string {
+	switch lower := strings.ToLower(str); {
+	case lower == nullStr:
+		return p.null
+	case lower == "true" || lower == "false":
+		return p.boolean
+	case isNumber(str):
+		return p.number
+	default:
+		return p.str
+	}
+}
+
+// findQuoteEnd returns an index after the closing quote in the text or -1.
+// A quote is escaped by doubling for single quotes and by a backslash for
+// double quotes.
+func findQuoteEnd(text string, start int, quote byte) int {
+	for i := start; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote:
+			if quote == '\'' && i+1 < len(text) && text[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// yamlHighlighter highlights a YAML text line by line.
+type yamlHighlighter struct {
+	p  palette
+	sb strings.Builder
+	// quote is a quote of a multi-line quoted scalar or 0.
+	quote     byte
+	quoteCode string
+	// blockIndent is an indent of a node with a block scalar or -1. The lines
+	// with a greater indent belong to the scalar.
+	blockIndent int
+	blockCode   string
+	// errorIndent is an indent of an error key with a nested value or -1.
+	errorIndent int
+	// pendingErr is true if the next scalar is a value of an error key.
+	pendingErr bool
+}
+
+// highlightYaml returns the YAML text with highlighted syntax.
+func highlightYaml(text string, p palette) string {
+	h := yamlHighlighter{p: p, blockIndent: -1, errorIndent: -1}
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			h.sb.WriteByte('\n')
+		}
+		h.line(line)
+	}
+	return h.sb.String()
+}
+
+// line highlights a line of the YAML text.
+func (h *yamlHighlighter) line(line string) {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := len(line) - len(trimmed)
+
+	if h.quote != 0 {
+		end := findQuoteEnd(line, 0, h.quote)
+		if end < 0 {
+			paint(&h.sb, h.quoteCode, line)
+			return
+		}
+		paint(&h.sb, h.quoteCode, line[:end])
+		h.quote = 0
+		h.flow(line, end, indent)
+		return
+	}
+
+	if h.blockIndent >= 0 {
+		if trimmed == "" || indent > h.blockIndent {
+			paint(&h.sb, h.blockCode, line)
+			return
+		}
+		h.blockIndent = -1
+	}
+	if h.errorIndent >= 0 {
+		if trimmed == "" || indent > h.errorIndent {
+			paint(&h.sb, h.p.err, line)
+			return
+		}
+		h.errorIndent = -1
+	}
+
+	if trimmed == "---" || trimmed == "..." {
+		h.sb.WriteString(line[:indent])
+		paint(&h.sb, h.p.punct, trimmed)
+		return
+	}
+
+	h.sb.WriteString(line[:indent])
+	pos := indent
+	nodeIndent := indent
+	// Sequence entries.
+	for pos < len(line) && line[pos] == '-' &&
+		(pos+1 == len(line) || line[pos+1] == ' ') {
+		nodeIndent = pos
+		paint(&h.sb, h.p.punct, "-")
+		pos++
+		for pos < len(line) && line[pos] == ' ' {
+			h.sb.WriteByte(' ')
+			pos++
+		}
+	}
+	if pos < len(line) && line[pos] != '-' {
+		nodeIndent = pos
+	}
+	h.flow(line, pos, nodeIndent)
+}
+
+// flow highlights a rest of the line starting from the position. The node
+// indent is used for block scalars and nested errors.
+func (h *yamlHighlighter) flow(line string, pos int, nodeIndent int) {
+	depth := 0
+	for pos < len(line) {
+		c := line[pos]
+		switch {
+		case c == ' ':
+			h.sb.WriteByte(c)
+			pos++
+		case c == '[' || c == '{':
+			depth++
+			h.paintPunct(line[pos : pos+1])
+			pos++
+		case c == ']' || c == '}':
+			depth--
+			h.paintPunct(line[pos : pos+1])
+			pos++
+		case c == ',' && depth > 0:
+			h.paintPunct(line[pos : pos+1])
+			pos++
+		case (c == '|' || c == '>') && depth == 0:
+			paint(&h.sb, h.p.punct, line[pos:])
+			h.blockIndent = nodeIndent
+			h.blockCode = h.valueCode(h.p.str)
+			return
+		case c == '\'' || c == '"':
+			end := findQuoteEnd(line, pos+1, c)
+			isKey := end > 0 && isYamlKeyEnd(line, end)
+			code := h.p.key
+			if !isKey {
+				code = h.valueCode(h.p.str)
+			}
+			if end < 0 {
+				paint(&h.sb, code, line[pos:])
+				h.quote, h.quoteCode = c, code
+				return
+			}
+			paint(&h.sb, code, line[pos:end])
+			pos = end
+		case c == ':':
+			h.paintPunct(":")
+			pos++
+		default:
+			end := findPlainEnd(line, pos, depth)
+			scalar := strings.TrimRight(line[pos:end], " ")
+			if isYamlKeyEnd(line, pos+len(scalar)) {
+				if scalar == "error" {
+					paint(&h.sb, h.p.err, scalar)
+					h.pendingErr = true
+					if strings.TrimSpace(line[pos+len(scalar)+1:]) == "" {
+						h.errorIndent = nodeIndent
+					}
+				} else {
+					paint(&h.sb, h.p.key, scalar)
+				}
+			} else {
+				paint(&h.sb, h.valueCode(h.p.scalarCode(scalar, "null")), scalar)
+			}
+			h.sb.WriteString(line[pos+len(scalar) : end])
+			pos = end
+		}
+	}
+	h.pendingErr = false
+}
+
+// paintPunct paints a punctuation symbol.
+func (h *yamlHighlighter) paintPunct(text string) {
+	if text != ":" {
+		h.pendingErr = false
+	}
+	paint(&h.sb, h.p.punct, text)
+}
+
+// valueCode returns a color of a value. It is the error color for a value of
+// an error key.
+func (h *yamlHighlighter) valueCode(code string) string {
+	if h.pendingErr {
+		h.pendingErr = false
+		return h.p.err
+	}
+	return code
+}
+
+// isYamlKeyEnd returns true if a mapping key ends at the position.
+func isYamlKeyEnd(line string, pos int) bool {
+	return pos < len(line) && line[pos] == ':' &&
+		(pos+1 == len(line) || line[pos+1] == ' ')
+}
+
+// findPlainEnd returns an end of a plain scalar starting from the position.
+// A plain scalar in a flow collection ends with a flow indicator.
+func findPlainEnd(line string, pos int, depth int) int {
+	for i := pos; i < len(line); i++ {
+		if isYamlKeyEnd(line, i) {
+			return i
+		}
+		if depth > 0 && strings.IndexByte(",[]{}", line[i]) >= 0 {
+			return i
+		}
+	}
+	return len(line)
+}
+
+// highlightCode returns the Lua text with highlighted syntax.
+func highlightCode(text string, p palette) string {
+	var sb strings.Builder
+
+	// errDepth is a depth of a table with a value of an error key.
+	errDepth := 0
+	pendingErr := false
+	valueCode := func(code string) string {
+		if pendingErr || errDepth > 0 {
+			pendingErr = false
+			return p.err
+		}
+		return code
+	}
+	isKeyEnd := func(pos int) bool {
+		for pos < len(text) && (text[pos] == ' ' || text[pos] == ']') {
+			pos++
+		}
+		return pos < len(text) && text[pos] == '=' &&
+			(pos+1 == len(text) || text[pos+1] != '=')
+	}
+
+	for pos := 0; pos < len(text); {
+		c := text[pos]
+		switch {
+		case c == ' ' || c == '\n' || c == '\t':
+			sb.WriteByte(c)
+			pos++
+		case c == '{':
+			if pendingErr || errDepth > 0 {
+				pendingErr = false
+				errDepth++
+				paint(&sb, p.err, "{")
+			} else {
+				paint(&sb, p.punct, "{")
+			}
+			pos++
+		case c == '}':
+			if errDepth > 0 {
+				errDepth--
+				paint(&sb, p.err, "}")
+			} else {
+				paint(&sb, p.punct, "}")
+			}
+			pos++
+		case c == '"' || c == '\'':
+			end := findQuoteEnd(text, pos+1, c)
+			if end < 0 {
+				end = len(text)
+			}
+			str := text[pos:end]
+			code := valueCode(p.str)
+			if isKeyEnd(end) && errDepth == 0 {
+				code = p.key
+				if strings.Trim(str, `"'`) == "error" {
+					code = p.err
+					pendingErr = true
+				}
+			}
+			paint(&sb, code, str)
+			pos = end
+		case c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			end := pos + 1
+			for end < len(text) && (text[end] == '_' || text[end] == '.' ||
+				text[end] >= 'a' && text[end] <= 'z' || text[end] >= 'A' && text[end] <= 'Z' ||
+				text[end] >= '0' && text[end] <= '9') {
+				end++
+			}
+			word := text[pos:end]
+			switch {
+			case isKeyEnd(end) && errDepth == 0:
+				if word == "error" {
+					paint(&sb, p.err, word)
+					pendingErr = true
+				} else {
+					paint(&sb, p.key, word)
+				}
+			case word == "nil" || word == "box.NULL":
+				paint(&sb, valueCode(p.null), word)
+			case word == "true" || word == "false":
+				paint(&sb, valueCode(p.boolean), word)
+			default:
+				paint(&sb, valueCode(""), word)
+			}
+			pos = end
+		case c == '-' || c == '+' || c == '.' || c >= '0' && c <= '9':
+			end := pos + 1
+			for end < len(text) && (strings.IndexByte(".xX_", text[end]) >= 0 ||
+				text[end] >= '0' && text[end] <= '9' ||
+				text[end] >= 'a' && text[end] <= 'z' || text[end] >= 'A' && text[end] <= 'Z' ||
+				(text[end] == '+' || text[end] == '-') &&
+					(text[end-1] == 'e' || text[end-1] == 'E')) {
+				end++
+			}
+			code := p.punct
+			if isNumber(text[pos:end]) {
+				code = valueCode(p.number)
+			}
+			paint(&sb, code, text[pos:end])
+			pos = end
+		default:
+			// Other punctuation symbols: [ ] , ; = :
+			code := p.punct
+			if errDepth > 0 {
+				code = p.err
+			}
+			paint(&sb, code, text[pos:pos+1])
+			pos++
+		}
+	}
+	return sb.String()
+}
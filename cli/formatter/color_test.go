@@ -0,0 +1,127 @@
+package formatter_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tarantool/tt/cli/formatter"
+)
+
+// colorMarks replaces escape sequences of the dark theme with readable marks.
+var colorMarks = strings.NewReplacer(
+	"\x1b[0m", ">",
+	"\x1b[36m", "<key:",
+	"\x1b[32m", "<str:",
+	"\x1b[33m", "<num:",
+	"\x1b[1;35m", "<null:",
+	"\x1b[35m", "<bool:",
+	"\x1b[1;31m", "<err:",
+	"\x1b[90m", "<p:",
+)
+
+func TestFormatter_ParseColorTheme(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected formatter.ColorTheme
+		ok       bool
+	}{
+		{"dark", formatter.DarkColorTheme, true},
+		{"Light", formatter.LightColorTheme, true},
+		{"on", formatter.DefaultColorTheme, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			theme, ok := formatter.ParseColorTheme(c.str)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.expected, theme)
+		})
+	}
+}
+
+func TestFormatter_ColorTheme_String(t *testing.T) {
+	assert.Equal(t, "dark", formatter.DarkColorTheme.String())
+	assert.Equal(t, "light", formatter.LightColorTheme.String())
+	assert.PanicsWithValue(t, "Unknown color theme", func() {
+		_ = formatter.ColorTheme(2023).String()
+	})
+}
+
+func TestFormatter_Highlight(t *testing.T) {
+	cases := []struct {
+		name   string
+		format formatter.Format
+		input  string
+		output string
+	}{
+		{
+			"yaml scalars",
+			formatter.YamlFormat,
+			"---\n- 1\n- foo bar\n- null\n- true\n- -1.5e+10\n- 'it''s'\n...\n",
+			"<p:--->\n<p:-> <num:1>\n<p:-> <str:foo bar>\n<p:-> <null:null>\n" +
+				"<p:-> <bool:true>\n<p:-> <num:-1.5e+10>\n<p:-> <str:'it''s'>\n<p:...>\n",
+		},
+		{
+			"yaml maps",
+			formatter.YamlFormat,
+			"---\n- key: value\n  flow: {a: 1, b: 'x, y', c: [1, 2]}\n...\n",
+			"<p:--->\n<p:-> <key:key><p::> <str:value>\n" +
+				"  <key:flow><p::> <p:{><key:a><p::> <num:1><p:,> <key:b><p::> <str:'x, y'>" +
+				"<p:,> <key:c><p::> <p:[><num:1><p:,> <num:2><p:]><p:}>\n<p:...>\n",
+		},
+		{
+			"yaml block scalar",
+			formatter.YamlFormat,
+			"---\n- text: |\n    a\n    b\n  other: 2\n...\n",
+			"<p:--->\n<p:-> <key:text><p::> <p:|>\n<str:    a>\n<str:    b>\n" +
+				"  <key:other><p::> <num:2>\n<p:...>\n",
+		},
+		{
+			"yaml multi-line string",
+			formatter.YamlFormat,
+			"---\n- 'long string\n  continued'\n- 1\n...\n",
+			"<p:--->\n<p:-> <str:'long string>\n<str:  continued'>\n<p:-> <num:1>\n<p:...>\n",
+		},
+		{
+			"yaml error",
+			formatter.YamlFormat,
+			"---\n- error: 'Some error: here'\n...\n",
+			"<p:--->\n<p:-> <err:error><p::> <err:'Some error: here'>\n<p:...>\n",
+		},
+		{
+			"yaml structured error",
+			formatter.YamlFormat,
+			"---\n- error:\n    type: ClientError\n    message: x\n- ok\n...\n",
+			"<p:--->\n<p:-> <err:error><p::>\n<err:    type: ClientError>\n" +
+				"<err:    message: x>\n<p:-> <str:ok>\n<p:...>\n",
+		},
+		{
+			"lua",
+			formatter.LuaFormat,
+			`1, "foo", nil, true, {a = 1, [2] = -3.5, error = "msg"}, {error = {code = 1}};` + "\n",
+			`<num:1><p:,> <str:"foo"><p:,> <null:nil><p:,> <bool:true><p:,> ` +
+				`<p:{><key:a> <p:=> <num:1><p:,> <p:[><num:2><p:]> <p:=> <num:-3.5><p:,> ` +
+				`<err:error> <p:=> <err:"msg"><p:}><p:,> ` +
+				`<p:{><err:error> <p:=> <err:{><err:code> <err:=> <err:1><err:}><p:}><p:;>` + "\n",
+		},
+		{
+			"table",
+			formatter.TableFormat,
+			"| col1 |\n",
+			"| col1 |\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			output := formatter.Highlight(c.format, c.input, formatter.DarkColorTheme)
+			assert.Equal(t, c.output, colorMarks.Replace(output))
+		})
+	}
+}
+
+func TestFormatter_Highlight_light(t *testing.T) {
+	output := formatter.Highlight(formatter.YamlFormat, "- a: 1", formatter.LightColorTheme)
+	assert.Equal(t, "\x1b[90m-\x1b[0m \x1b[34ma\x1b[0m\x1b[90m:\x1b[0m \x1b[36m1\x1b[0m", output)
+}
@@ -0,0 +1,146 @@
+package formatter
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/exp/slices"
+)
+
+// DefaultFlattenArrayMax is a default maximum length of an array to expand
+// into indexed columns in the flatten mode.
+const DefaultFlattenArrayMax = 10
+
+// flattenKeySeparator separates parts of a flattened column name.
+const flattenKeySeparator = "."
+
+// isFlattenable returns true if the value could be expanded into columns.
+// Empty containers and arrays longer than the limit are not expanded.
+func isFlattenable(val any, arrayMax int) bool {
+	switch x := val.(type) {
+	case unorderedMap[any]:
+		return x.len() > 0
+	case map[any]any:
+		return len(x) > 0
+	case []any:
+		return len(x) > 0 && len(x) <= arrayMax
+	}
+	return false
+}
+
+// forEachFlattenChild calls the function for each nested value of the map or
+// the array with its dotted key.
+func forEachFlattenChild(key string, val any, f func(subKey string, subVal any)) {
+	switch x := val.(type) {
+	case unorderedMap[any]:
+		x.forEach(func(subKey, subVal any) {
+			f(key+flattenKeySeparator+fmt.Sprint(subKey), subVal)
+		})
+	case map[any]any:
+		forEachFlattenChild(key, castMapToUMap(x), f)
+	case []any:
+		for i, item := range x {
+			f(key+flattenKeySeparator+strconv.Itoa(i+1), item)
+		}
+	}
+}
+
+// flattenKey returns a flattened name of a top-level key. A numeric key is
+// prefixed in the same way as in a table header.
+func flattenKey(key any) string {
+	keyStr := fmt.Sprint(key)
+	if _, err := strconv.ParseInt(keyStr, 10, 64); err == nil {
+		keyStr = "col" + keyStr
+	}
+	return keyStr
+}
+
+// collectFlattenLeaves collects dotted keys of the values that could not be
+// expanded. A key is a leaf if it could not be expanded in at least one map,
+// so a column is either a value or its nested values but never both.
+func collectFlattenLeaves(leaves map[string]bool, key string, val any, arrayMax int) {
+	if !isFlattenable(val, arrayMax) {
+		leaves[key] = true
+		return
+	}
+	forEachFlattenChild(key, val, func(subKey string, subVal any) {
+		collectFlattenLeaves(leaves, subKey, subVal, arrayMax)
+	})
+}
+
+// flattenValue expands nested maps and arrays of the value into the
+// destination map with dotted keys. Leaf values are not expanded.
+func flattenValue(dst *unorderedMap[any], key string, val any, leaves map[string]bool,
+	arrayMax int) {
+	if leaves[key] || !isFlattenable(val, arrayMax) {
+		dst.insert(key, val)
+		return
+	}
+	forEachFlattenChild(key, val, func(subKey string, subVal any) {
+		flattenValue(dst, subKey, subVal, leaves, arrayMax)
+	})
+}
+
+// flattenMap expands nested values of the map. A not expanded top-level
+// value keeps its original key.
+func flattenMap(src unorderedMap[any], leaves map[string]bool,
+	arrayMax int) unorderedMap[any] {
+	dst := createUnorderedMap[any](src.len())
+	src.forEach(func(key, val any) {
+		keyStr := flattenKey(key)
+		if leaves[keyStr] || !isFlattenable(val, arrayMax) {
+			dst.insert(key, val)
+			return
+		}
+		flattenValue(&dst, keyStr, val, leaves, arrayMax)
+	})
+	return dst
+}
+
+// flattenMaps expands nested values of the maps and makes a union of
+// the keys. A missing key of a map has an empty value.
+func flattenMaps(maps []unorderedMap[any], opts Opts) []unorderedMap[any] {
+	arrayMax := opts.FlattenArrayMax
+	if arrayMax == 0 {
+		arrayMax = DefaultFlattenArrayMax
+	}
+
+	leaves := map[string]bool{}
+	for _, src := range maps {
+		src.forEach(func(key, val any) {
+			collectFlattenLeaves(leaves, flattenKey(key), val, arrayMax)
+		})
+	}
+
+	// A new key is placed after a previous known key of the same map to keep
+	// nested keys of a column together or appended otherwise.
+	var keys []any
+	flattened := make([]unorderedMap[any], 0, len(maps))
+	for _, src := range maps {
+		dst := flattenMap(src, leaves, arrayMax)
+		pos := len(keys)
+		dst.forEach(func(key, _ any) {
+			if i := slices.Index(keys, key); i >= 0 {
+				pos = i + 1
+			} else {
+				keys = slices.Insert(keys, pos, key)
+				pos++
+			}
+		})
+		flattened = append(flattened, dst)
+	}
+
+	result := make([]unorderedMap[any], 0, len(flattened))
+	for _, src := range flattened {
+		dst := createUnorderedMap[any](len(keys))
+		for _, key := range keys {
+			if val, ok := src.innerMap[key]; ok {
+				dst.insert(key, val)
+			} else {
+				dst.insert(key, "")
+			}
+		}
+		result = append(result, dst)
+	}
+	return result
+}
@@ -332,3 +332,73 @@ func TestFormatter_MakeOutputFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatter_MakeOutputFlatten(t *testing.T) {
+	cases := []struct {
+		name         string
+		outputFormat formatter.Format
+		input        string
+		output       string
+	}{
+		{
+			"nested maps",
+			formatter.TableFormat,
+			"---\n- {id: 1, address: {city: Moscow, geo: {lat: 55, lon: 37}}}\n" +
+				"- {id: 2, address: {city: Paris}, tags: [a, b]}\n...\n",
+			"+--------------+-----------------+-----------------+----+--------+--------+\n" +
+				"| address.city | address.geo.lat | address.geo.lon | id | tags.1 | tags.2 |\n" +
+				"+--------------+-----------------+-----------------+----+--------+--------+\n" +
+				"| Moscow       | 55              | 37              | 1  |        |        |\n" +
+				"+--------------+-----------------+-----------------+----+--------+--------+\n" +
+				"| Paris        |                 |                 | 2  | a      | b      |\n" +
+				"+--------------+-----------------+-----------------+----+--------+--------+\n",
+		},
+		{
+			"arrays",
+			formatter.TableFormat,
+			"---\n- - [1, {name: a}, [1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11], []]\n" +
+				"  - [2, {name: b, age: 3}, [1], {}]\n...\n",
+			"+------+----------+-----------+---------------------------+------+\n" +
+				"| col1 | col2.age | col2.name | col3                      | col4 |\n" +
+				"+------+----------+-----------+---------------------------+------+\n" +
+				"| 1    |          | a         | [1,2,3,4,5,6,7,8,9,10,11] | []   |\n" +
+				"+------+----------+-----------+---------------------------+------+\n" +
+				"| 2    | 3        | b         | [1]                       | {}   |\n" +
+				"+------+----------+-----------+---------------------------+------+\n",
+		},
+		{
+			"scalar and map",
+			formatter.TableFormat,
+			"---\n- {a: 1, b: {c: {d: 2}}}\n- {a: {x: 3}, b: {c: 4}}\n...\n",
+			"+---------+---------+\n" +
+				"| a       | b.c     |\n" +
+				"+---------+---------+\n" +
+				"| 1       | {\"d\":2} |\n" +
+				"+---------+---------+\n" +
+				"| {\"x\":3} | 4       |\n" +
+				"+---------+---------+\n",
+		},
+		{
+			"transposed",
+			formatter.TTableFormat,
+			"---\n- {a: {b: 1}}\n- {c: 2}\n...\n",
+			"+-----+---+---+\n" +
+				"| a.b | 1 |   |\n" +
+				"+-----+---+---+\n" +
+				"| c   |   | 2 |\n" +
+				"+-----+---+---+\n",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			output, err := formatter.MakeOutput(c.outputFormat, c.input, formatter.Opts{
+				Graphics:     true,
+				TableDialect: formatter.DefaultTableDialect,
+				Flatten:      true,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, c.output, output, "Unexpected render output")
+		})
+	}
+}
@@ -8,4 +8,10 @@ type Opts struct {
 	ColumnWidthMax int
 	// TableDialect sets a current table dialect.
 	TableDialect TableDialect
+	// Flatten sets on/off expanding of nested maps and arrays into separate
+	// columns for tables.
+	Flatten bool
+	// FlattenArrayMax is a maximum length of an array to expand in
+	// the flatten mode. DefaultFlattenArrayMax is used if it is zero.
+	FlattenArrayMax int
 }
@@ -278,9 +278,13 @@ func createMarkdownTable(table []string, columns int, opts Opts) string {
 	return result
 }
 
-// renderEqualMaps returns maps with equal keys as single table string.
+// renderEqualMaps returns maps with equal keys as single table string. The maps
+// could have different keys in the flatten mode.
 func renderEqualMaps(maps []unorderedMap[any], transpose bool, opts Opts) (string, error) {
 	t := newTableWriter(opts)
+	if opts.Flatten {
+		maps = flattenMaps(maps, opts)
+	}
 
 	var commonKeys []any
 	maps[0].forEach(func(mapKey, _ any) {
@@ -378,7 +382,9 @@ func renderBatch(batch []any, transpose bool, opts Opts) (string, error) {
 		mapsBatchs[batchPointer] = append(mapsBatchs[batchPointer], anyMaps[0])
 
 		for i := 0; i < len(anyMaps)-1; i++ {
-			if !isMapKeysEqual(anyMaps[i], anyMaps[i+1]) {
+			// Maps with different keys are rendered as a single table with
+			// a union of the keys in the flatten mode.
+			if !opts.Flatten && !isMapKeysEqual(anyMaps[i], anyMaps[i+1]) {
 				batchPointer++
 			}
 			mapsBatchs[batchPointer] = append(mapsBatchs[batchPointer], anyMaps[i+1])
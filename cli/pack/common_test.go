@@ -249,7 +249,8 @@ func Test_createNewOpts(t *testing.T) {
 					Rocks:   "",
 					Install: "distfiles",
 				},
-				EE: &config.EEOpts{},
+				EE:      &config.EEOpts{},
+				Console: &config.ConsoleOpts{},
 				Templates: []config.TemplateOpts{
 					{Path: "templates"},
 				},
@@ -286,7 +287,8 @@ func Test_createNewOpts(t *testing.T) {
 					Rocks:   "",
 					Install: "distfiles",
 				},
-				EE: &config.EEOpts{},
+				EE:      &config.EEOpts{},
+				Console: &config.ConsoleOpts{},
 				Templates: []config.TemplateOpts{
 					{Path: "templates"},
 				},
@@ -323,7 +325,8 @@ func Test_createNewOpts(t *testing.T) {
 					Rocks:   "",
 					Install: "distfiles",
 				},
-				EE: &config.EEOpts{},
+				EE:      &config.EEOpts{},
+				Console: &config.ConsoleOpts{},
 				Templates: []config.TemplateOpts{
 					{Path: "templates"},
 				},
@@ -366,7 +369,8 @@ func Test_createNewOpts(t *testing.T) {
 					Rocks:   "",
 					Install: "distfiles",
 				},
-				EE: &config.EEOpts{},
+				EE:      &config.EEOpts{},
+				Console: &config.ConsoleOpts{},
 				Templates: []config.TemplateOpts{
 					{Path: "templates"},
 				},
@@ -0,0 +1,96 @@
+package profile
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// saltLen is a length of a random salt for the key derivation.
+	saltLen = 16
+	// keyLen is a length of the derived key, it selects AES-256.
+	keyLen = 32
+	// scrypt parameters recommended for interactive logins.
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// errWrongPassphrase is returned if the profiles could not be decrypted.
+var errWrongPassphrase = errors.New("failed to decrypt the profiles: wrong passphrase")
+
+// encryptedData is an encrypted content of the profiles file.
+type encryptedData struct {
+	// Salt is a base64-encoded salt for the key derivation.
+	Salt string `yaml:"salt"`
+	// Nonce is a base64-encoded AES-GCM nonce.
+	Nonce string `yaml:"nonce"`
+	// Data is a base64-encoded ciphertext.
+	Data string `yaml:"data"`
+}
+
+// newCipher creates an AES-GCM cipher with a key derived from the passphrase.
+func newCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive a key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt encrypts the data with the passphrase.
+func encrypt(data []byte, passphrase string) (encryptedData, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return encryptedData{}, fmt.Errorf("failed to generate a salt: %w", err)
+	}
+	gcm, err := newCipher(passphrase, salt)
+	if err != nil {
+		return encryptedData{}, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return encryptedData{}, fmt.Errorf("failed to generate a nonce: %w", err)
+	}
+
+	return encryptedData{
+		Salt:  base64.StdEncoding.EncodeToString(salt),
+		Nonce: base64.StdEncoding.EncodeToString(nonce),
+		Data:  base64.StdEncoding.EncodeToString(gcm.Seal(nil, nonce, data, nil)),
+	}, nil
+}
+
+// decrypt decrypts the data with the passphrase.
+func decrypt(encrypted encryptedData, passphrase string) ([]byte, error) {
+	var decoded [3][]byte
+	for i, str := range []string{encrypted.Salt, encrypted.Nonce, encrypted.Data} {
+		var err error
+		if decoded[i], err = base64.StdEncoding.DecodeString(str); err != nil {
+			return nil, fmt.Errorf("failed to decode the encrypted profiles: %w", err)
+		}
+	}
+	salt, nonce, ciphertext := decoded[0], decoded[1], decoded[2]
+
+	gcm, err := newCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("failed to decode the encrypted profiles: invalid nonce")
+	}
+	data, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errWrongPassphrase
+	}
+	return data, nil
+}
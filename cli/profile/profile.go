@@ -0,0 +1,223 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// FileEnv is an environment variable with a path to the profiles file.
+	FileEnv = "TT_CLI_PROFILES_FILE"
+	// PassphraseEnv is an environment variable with a passphrase for
+	// the encrypted profiles file.
+	PassphraseEnv = "TT_CLI_PROFILES_PASSPHRASE"
+
+	// configHomeEnv is an environment variable with a base directory for
+	// user-specific configuration files.
+	configHomeEnv = "XDG_CONFIG_HOME"
+	// fileName is a default name of the profiles file.
+	fileName = "profiles.yaml"
+
+	// filePerm is a permission of the profiles file. It contains
+	// credentials, so it must be readable only by the owner.
+	filePerm = 0600
+	// dirPerm is a permission of a created directory for the profiles file.
+	dirPerm = 0700
+)
+
+// Profile is a saved set of connection options.
+type Profile struct {
+	// URI is a URI of an instance or a configuration storage.
+	URI string `yaml:"uri"`
+	// Username is a username for Tarantool.
+	Username string `yaml:"username,omitempty"`
+	// Password is a password for Tarantool.
+	Password string `yaml:"password,omitempty"`
+	// SslKeyFile is a path to a private SSL key file.
+	SslKeyFile string `yaml:"sslkeyfile,omitempty"`
+	// SslCertFile is a path to an SSL certificate file.
+	SslCertFile string `yaml:"sslcertfile,omitempty"`
+	// SslCaFile is a path to a trusted certificate authorities (CA) file.
+	SslCaFile string `yaml:"sslcafile,omitempty"`
+	// SslCiphers is a colon-separated list of SSL cipher suites.
+	SslCiphers string `yaml:"sslciphers,omitempty"`
+	// EtcdUsername is a username for etcd.
+	EtcdUsername string `yaml:"etcd_username,omitempty"`
+	// EtcdPassword is a password for etcd.
+	EtcdPassword string `yaml:"etcd_password,omitempty"`
+}
+
+// PassphraseFunc returns a passphrase to decrypt the profiles file.
+type PassphraseFunc func() (string, error)
+
+// fileContent is a content of the profiles file. Only one of the fields
+// is set.
+type fileContent struct {
+	// Profiles are plain profiles by names.
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+	// Encrypted are encrypted profiles.
+	Encrypted *encryptedData `yaml:"encrypted,omitempty"`
+}
+
+// Store is a set of the profiles loaded from a file.
+type Store struct {
+	path       string
+	passphrase string
+	profiles   map[string]Profile
+}
+
+// GetDefaultPath returns a path to the profiles file. The path could be
+// overridden with FileEnv environment variable.
+func GetDefaultPath() (string, error) {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path, nil
+	}
+	if configHome := os.Getenv(configHomeEnv); configHome != "" {
+		return filepath.Join(configHome, "tt", fileName), nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get a home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".config", "tt", fileName), nil
+}
+
+// Load loads the profiles from the file. The store is empty if the file
+// does not exist. The passphrase function is called only if the file is
+// encrypted.
+func Load(path string, getPassphrase PassphraseFunc) (*Store, error) {
+	store := &Store{
+		path:     path,
+		profiles: map[string]Profile{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read the profiles file: %w", err)
+	}
+
+	var content fileContent
+	if err := yaml.Unmarshal(data, &content); err != nil {
+		return nil, fmt.Errorf("failed to parse the profiles file %q: %w", path, err)
+	}
+
+	if content.Encrypted != nil {
+		if store.passphrase, err = getPassphrase(); err != nil {
+			return nil, err
+		}
+		data, err = decrypt(*content.Encrypted, store.passphrase)
+		if err != nil {
+			return nil, err
+		}
+		content = fileContent{}
+		if err := yaml.Unmarshal(data, &content); err != nil {
+			return nil, fmt.Errorf("failed to parse the decrypted profiles: %w", err)
+		}
+	}
+
+	for name, profile := range content.Profiles {
+		store.profiles[name] = profile
+	}
+	return store, nil
+}
+
+// Encrypted returns true if the store is saved encrypted.
+func (store *Store) Encrypted() bool {
+	return store.passphrase != ""
+}
+
+// SetPassphrase sets a passphrase to encrypt the store on save.
+func (store *Store) SetPassphrase(passphrase string) {
+	store.passphrase = passphrase
+}
+
+// Get returns a profile by the name.
+func (store *Store) Get(name string) (Profile, error) {
+	profile, ok := store.profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+// Names returns sorted names of the profiles.
+func (store *Store) Names() []string {
+	names := make([]string, 0, len(store.profiles))
+	for name := range store.profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Add adds the profile. An existing profile is replaced only if force
+// is true.
+func (store *Store) Add(name string, profile Profile, force bool) error {
+	if _, ok := store.profiles[name]; ok && !force {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+	store.profiles[name] = profile
+	return nil
+}
+
+// Remove removes the profile.
+func (store *Store) Remove(name string) error {
+	if _, ok := store.profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	delete(store.profiles, name)
+	return nil
+}
+
+// Save writes the profiles into the file. It is readable only by the owner.
+func (store *Store) Save() error {
+	content := fileContent{Profiles: store.profiles}
+	data, err := yaml.Marshal(content)
+	if err != nil {
+		return fmt.Errorf("failed to encode the profiles: %w", err)
+	}
+
+	if store.Encrypted() {
+		encrypted, err := encrypt(data, store.passphrase)
+		if err != nil {
+			return err
+		}
+		if data, err = yaml.Marshal(fileContent{Encrypted: &encrypted}); err != nil {
+			return fmt.Errorf("failed to encode the profiles: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(store.path), dirPerm); err != nil {
+		return fmt.Errorf("failed to create a directory for the profiles file: %w", err)
+	}
+	// Write to a temporary file first to avoid a partially written file and
+	// to set the permission even if the file already exists.
+	tmpFile, err := os.CreateTemp(filepath.Dir(store.path), fileName+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write the profiles file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if err := tmpFile.Chmod(filePerm); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write the profiles file: %w", err)
+	}
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("failed to write the profiles file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to write the profiles file: %w", err)
+	}
+	if err := os.Rename(tmpFile.Name(), store.path); err != nil {
+		return fmt.Errorf("failed to write the profiles file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package profile_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/tt/cli/profile"
+)
+
+var testProfile = Profile{
+	URI:          "localhost:3013",
+	Username:     "admin",
+	Password:     "secret-pass",
+	SslCaFile:    "ca.crt",
+	EtcdUsername: "root",
+	EtcdPassword: "etcd-pass",
+}
+
+func noPassphrase() (string, error) {
+	return "", errors.New("passphrase must not be requested")
+}
+
+func makePassphrase(passphrase string) PassphraseFunc {
+	return func() (string, error) {
+		return passphrase, nil
+	}
+}
+
+func TestGetDefaultPath(t *testing.T) {
+	t.Setenv(FileEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	path, err := GetDefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/xdg/tt/profiles.yaml", path)
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/user")
+	path, err = GetDefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/home/user/.config/tt/profiles.yaml", path)
+
+	t.Setenv(FileEnv, "/custom/profiles.yaml")
+	path, err = GetDefaultPath()
+	require.NoError(t, err)
+	assert.Equal(t, "/custom/profiles.yaml", path)
+}
+
+func TestLoad_not_exist(t *testing.T) {
+	store, err := Load(filepath.Join(t.TempDir(), "profiles.yaml"), noPassphrase)
+	require.NoError(t, err)
+	assert.Empty(t, store.Names())
+	assert.False(t, store.Encrypted())
+}
+
+func TestStore_Add_Get_Remove(t *testing.T) {
+	store, err := Load(filepath.Join(t.TempDir(), "profiles.yaml"), noPassphrase)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Add("b", testProfile, false))
+	require.NoError(t, store.Add("a", Profile{URI: "/tmp/socket"}, false))
+	assert.EqualError(t, store.Add("a", testProfile, false), `profile "a" already exists`)
+	require.NoError(t, store.Add("a", testProfile, true))
+	assert.Equal(t, []string{"a", "b"}, store.Names())
+
+	profile, err := store.Get("a")
+	require.NoError(t, err)
+	assert.Equal(t, testProfile, profile)
+
+	require.NoError(t, store.Remove("a"))
+	assert.EqualError(t, store.Remove("a"), `profile "a" not found`)
+	_, err = store.Get("a")
+	assert.EqualError(t, err, `profile "a" not found`)
+}
+
+func TestStore_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dir", "profiles.yaml")
+	store, err := Load(path, noPassphrase)
+	require.NoError(t, err)
+	require.NoError(t, store.Add("prod", testProfile, false))
+	require.NoError(t, store.Save())
+
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "secret-pass")
+
+	loaded, err := Load(path, noPassphrase)
+	require.NoError(t, err)
+	assert.False(t, loaded.Encrypted())
+	profile, err := loaded.Get("prod")
+	require.NoError(t, err)
+	assert.Equal(t, testProfile, profile)
+
+	// The permission is restored for an existing file.
+	require.NoError(t, os.Chmod(path, 0644))
+	require.NoError(t, loaded.Save())
+	stat, err = os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), stat.Mode().Perm())
+}
+
+func TestStore_Save_encrypted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	store, err := Load(path, noPassphrase)
+	require.NoError(t, err)
+	require.NoError(t, store.Add("prod", testProfile, false))
+	store.SetPassphrase("passphrase")
+	assert.True(t, store.Encrypted())
+	require.NoError(t, store.Save())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "secret-pass")
+	assert.NotContains(t, string(data), "localhost")
+
+	loaded, err := Load(path, makePassphrase("passphrase"))
+	require.NoError(t, err)
+	assert.True(t, loaded.Encrypted())
+	profile, err := loaded.Get("prod")
+	require.NoError(t, err)
+	assert.Equal(t, testProfile, profile)
+
+	_, err = Load(path, makePassphrase("wrong"))
+	assert.EqualError(t, err, "failed to decrypt the profiles: wrong passphrase")
+
+	_, err = Load(path, func() (string, error) {
+		return "", errors.New("no passphrase")
+	})
+	assert.EqualError(t, err, "no passphrase")
+
+	// The store could be decrypted back.
+	loaded.SetPassphrase("")
+	require.NoError(t, loaded.Save())
+	loaded, err = Load(path, noPassphrase)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod"}, loaded.Names())
+}
+
+func TestLoad_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("profiles: ["), 0600))
+	_, err := Load(path, noPassphrase)
+	assert.ErrorContains(t, err, "failed to parse the profiles file")
+}
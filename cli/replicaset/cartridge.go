@@ -128,14 +128,18 @@ func getCartridgeReplicasets(topology cartridgeTopology) Replicasets {
 
 // Promote promotes a cartridge instance.
 func (c *CartridgeInstance) Promote(ctx PromoteCtx) error {
+	if ctx.Switchover {
+		return newErrSwitchoverByInstanceNotSupported(OrchestratorCartridge)
+	}
 	replicasets, err := c.Discovery(UseCache)
 	if err != nil {
 		return err
 	}
-	uuid, _, err := getCartridgeInstanceInfo(c.evaler)
+	info, err := getCartridgeInstanceInfo(c.evaler)
 	if err != nil {
 		return err
 	}
+	uuid := info.UUID
 	var (
 		inst  cartridgeInstance
 		found bool
@@ -204,6 +208,11 @@ func (c *CartridgeInstance) BootstrapVShard(ctx VShardBootstrapCtx) error {
 	return nil
 }
 
+// GetVShardStatus is not supported for a single instance by the Cartridge orchestrator.
+func (c *CartridgeInstance) GetVShardStatus() (VShardStatus, error) {
+	return VShardStatus{}, newErrVShardStatusByInstanceNotSupported(OrchestratorCartridge)
+}
+
 // CartridgeApplication is an application with the Cartridge orchestrator.
 type CartridgeApplication struct {
 	cachedDiscoverer
@@ -269,9 +278,10 @@ func (c *CartridgeApplication) Promote(ctx PromoteCtx) error {
 		return err
 	}
 	var (
-		targetInstance Instance
-		inst           cartridgeInstance
-		found          bool
+		targetReplicaset Replicaset
+		targetInstance   Instance
+		inst             cartridgeInstance
+		found            bool
 	)
 loop:
 	for _, replicaset := range replicasets.Replicasets {
@@ -282,6 +292,7 @@ loop:
 					InstanceUUID:   instance.UUID,
 					Failover:       replicaset.Failover,
 				}
+				targetReplicaset = replicaset
 				targetInstance = instance
 				found = true
 				break loop
@@ -295,8 +306,37 @@ loop:
 		return fmt.Errorf("target instance should be online")
 	}
 
-	return cartridgePromote(MakeInstanceEvalFunc(targetInstance.InstanceCtx),
-		inst, ctx.Force, ctx.Timeout)
+	evaler := MakeInstanceEvalFunc(targetInstance.InstanceCtx)
+	if !ctx.Switchover {
+		return cartridgePromote(evaler, inst, ctx.Force, ctx.Timeout)
+	}
+
+	if inst.Failover == FailoverElection {
+		return newErrSwitchoverByFailoverNotSupported(inst.Failover)
+	}
+	return switchover(switchoverCtx{
+		Replicaset: targetReplicaset,
+		Candidate:  targetInstance,
+		Timeout:    ctx.Timeout,
+		Evaler: func(instance Instance) connector.Evaler {
+			return MakeInstanceEvalFunc(instance.InstanceCtx)
+		},
+		Promote: func() error {
+			return cartridgePromote(evaler, inst, ctx.Force, ctx.Timeout)
+		},
+		Rollback: func(masters []Instance) error {
+			if len(masters) == 0 {
+				return nil
+			}
+			master := cartridgeInstance{
+				ReplicasetUUID: inst.ReplicasetUUID,
+				InstanceUUID:   masters[0].UUID,
+				Failover:       inst.Failover,
+			}
+			return cartridgePromote(MakeInstanceEvalFunc(masters[0].InstanceCtx),
+				master, ctx.Force, ctx.Timeout)
+		},
+	})
 }
 
 // Demote is not supported for an application by the Cartridge orchestrator.
@@ -661,46 +701,63 @@ func (c *CartridgeApplication) BootstrapVShard(ctx VShardBootstrapCtx) error {
 	return nil
 }
 
+// GetVShardStatus returns a vshard status for an application by the Cartridge
+// orchestrator.
+func (c *CartridgeApplication) GetVShardStatus() (VShardStatus, error) {
+	replicasets, err := c.Discovery(UseCache)
+	if err != nil {
+		return VShardStatus{}, fmt.Errorf("failed to discovery: %w", err)
+	}
+	return getVShardStatus(replicasets, getDiscoveredEvalers(replicasets))
+}
+
+// cartridgeInstanceInfo describes an additional instance information.
+type cartridgeInstanceInfo struct {
+	// UUID of the instance.
+	UUID string
+	// RW is true when the instance is in RW mode.
+	RW bool
+	// Replication is a replication state of the instance.
+	Replication *Replication
+}
+
 // getCartridgeInstanceInfo returns an additional instance information.
-func getCartridgeInstanceInfo(
-	evaler connector.Evaler) (uuid string, rw bool, err error) {
-	info := []struct {
-		UUID string
-		RW   bool
-	}{}
+func getCartridgeInstanceInfo(evaler connector.Evaler) (cartridgeInstanceInfo, error) {
+	info := []cartridgeInstanceInfo{}
 
 	args := []any{}
 	opts := connector.RequestOpts{}
-	data, err := evaler.Eval(cartridgeGetInstanceInfoBody, args, opts)
+	data, err := evaler.Eval(withReplicationInfo(cartridgeGetInstanceInfoBody), args, opts)
 	if err != nil {
-		return "", false, err
+		return cartridgeInstanceInfo{}, err
 	}
 
 	if err := mapstructure.Decode(data, &info); err != nil {
-		return "", false, fmt.Errorf("failed to parse a response: %w", err)
+		return cartridgeInstanceInfo{}, fmt.Errorf("failed to parse a response: %w", err)
 	}
 	if len(info) != 1 {
-		return "", false, fmt.Errorf("unexpected response")
+		return cartridgeInstanceInfo{}, fmt.Errorf("unexpected response")
 	}
-	return info[0].UUID, info[0].RW, nil
+	return info[0], nil
 }
 
 // updateCartridgeInstance receives and updates an additional instance
 // information about the instance in the replicasets.
 func updateCartridgeInstance(evaler connector.Evaler,
 	ictx *running.InstanceCtx, replicasets Replicasets) (Replicasets, error) {
-	uuid, rw, err := getCartridgeInstanceInfo(evaler)
+	info, err := getCartridgeInstanceInfo(evaler)
 	if err != nil {
 		return replicasets, err
 	}
 	for _, replicaset := range replicasets.Replicasets {
 		for i, _ := range replicaset.Instances {
-			if replicaset.Instances[i].UUID == uuid {
-				if rw {
+			if replicaset.Instances[i].UUID == info.UUID {
+				if info.RW {
 					replicaset.Instances[i].Mode = ModeRW
 				} else {
 					replicaset.Instances[i].Mode = ModeRead
 				}
+				replicaset.Instances[i].Replication = info.Replication
 				if ictx != nil {
 					replicaset.Instances[i].InstanceCtx = *ictx
 					replicaset.Instances[i].InstanceCtxFound = true
@@ -18,12 +18,14 @@ var _ replicaset.Promoter = &replicaset.CartridgeInstance{}
 var _ replicaset.Demoter = &replicaset.CartridgeInstance{}
 var _ replicaset.Expeller = &replicaset.CartridgeInstance{}
 var _ replicaset.VShardBootstrapper = &replicaset.CartridgeInstance{}
+var _ replicaset.VShardStatusGetter = &replicaset.CartridgeInstance{}
 var _ replicaset.Bootstrapper = &replicaset.CartridgeInstance{}
 
 var _ replicaset.Discoverer = &replicaset.CartridgeApplication{}
 var _ replicaset.Promoter = &replicaset.CartridgeApplication{}
 var _ replicaset.Demoter = &replicaset.CartridgeApplication{}
 var _ replicaset.Expeller = &replicaset.CartridgeApplication{}
+var _ replicaset.VShardStatusGetter = &replicaset.CartridgeApplication{}
 var _ replicaset.Bootstrapper = &replicaset.CartridgeApplication{}
 
 func TestCartridgeApplication_Demote(t *testing.T) {
@@ -499,6 +501,104 @@ func TestCartridgeInstance_Discovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "replication",
+			Evaler: &instanceMockEvaler{
+				Ret: [][]any{
+					[]any{
+						map[any]any{
+							"failover": "disabled",
+							"provider": "tarantool",
+							"replicasets": []any{
+								map[any]any{
+									"uuid":  "somereplicasetuuid",
+									"alias": "somealias",
+									"instances": []any{
+										map[any]any{
+											"alias": "instance",
+											"uuid":  "someinstanceuuid",
+											"uri":   "anyuri",
+										},
+									},
+								},
+							},
+						},
+					},
+					[]any{
+						map[any]any{
+							"uuid": "someinstanceuuid",
+							"rw":   true,
+							"replication": map[any]any{
+								"vclock": map[any]any{
+									uint64(1): uint64(10),
+									uint64(2): uint64(5),
+								},
+								"electionterm":  uint64(2),
+								"electionstate": "leader",
+								"upstreams": []any{
+									map[any]any{
+										"uuid":    "otherinstanceuuid",
+										"status":  "stopped",
+										"idle":    12.5,
+										"message": "connection refused",
+									},
+								},
+								"downstreams": []any{
+									map[any]any{
+										"uuid":   "otherinstanceuuid",
+										"status": "follow",
+										"lag":    0.5,
+										"idle":   0.1,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected: replicaset.Replicasets{
+				State:        replicaset.StateBootstrapped,
+				Orchestrator: replicaset.OrchestratorCartridge,
+				Replicasets: []replicaset.Replicaset{
+					replicaset.Replicaset{
+						UUID:          "somereplicasetuuid",
+						Alias:         "somealias",
+						Master:        replicaset.MasterSingle,
+						Failover:      replicaset.FailoverOff,
+						StateProvider: replicaset.StateProviderTarantool,
+						Instances: []replicaset.Instance{
+							replicaset.Instance{
+								Alias: "instance",
+								UUID:  "someinstanceuuid",
+								URI:   "anyuri",
+								Mode:  replicaset.ModeRW,
+								Replication: &replicaset.Replication{
+									VClock:        map[uint64]uint64{1: 10, 2: 5},
+									ElectionTerm:  2,
+									ElectionState: "leader",
+									Upstreams: []replicaset.ReplicationLink{
+										{
+											UUID:    "otherinstanceuuid",
+											Status:  "stopped",
+											Idle:    12.5,
+											Message: "connection refused",
+										},
+									},
+									Downstreams: []replicaset.ReplicationLink{
+										{
+											UUID:   "otherinstanceuuid",
+											Status: "follow",
+											Lag:    0.5,
+											Idle:   0.1,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
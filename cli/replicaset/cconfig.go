@@ -46,6 +46,8 @@ type cconfigTopology struct {
 	InstanceUUID string
 	// InstanceRW is true when the current instance is in RW mode.
 	InstanceRW bool
+	// Replication is a replication state of the current instance.
+	Replication *Replication
 }
 
 // cconfigInstance describes an instance in the cluster config.
@@ -96,6 +98,9 @@ func (c *CConfigInstance) discovery() (Replicasets, error) {
 
 // Promote promotes an instance.
 func (c *CConfigInstance) Promote(ctx PromoteCtx) error {
+	if ctx.Switchover {
+		return newErrSwitchoverByInstanceNotSupported(OrchestratorCentralizedConfig)
+	}
 	return cconfigPromoteElection(c.evaler, ctx.Timeout)
 }
 
@@ -127,6 +132,11 @@ func (c *CConfigInstance) BootstrapVShard(ctx VShardBootstrapCtx) error {
 	return nil
 }
 
+// GetVShardStatus is not supported for a single instance by the centralized config orchestrator.
+func (c *CConfigInstance) GetVShardStatus() (VShardStatus, error) {
+	return VShardStatus{}, newErrVShardStatusByInstanceNotSupported(OrchestratorCentralizedConfig)
+}
+
 // CConfigApplication is an application with the centralized config
 // orchestrator.
 type CConfigApplication struct {
@@ -235,7 +245,7 @@ func getCConfigInstanceTopology(evaler connector.Evaler) (cconfigTopology, error
 
 	args := []any{}
 	opts := connector.RequestOpts{}
-	data, err := evaler.Eval(cconfigGetInstanceTopologyBody, args, opts)
+	data, err := evaler.Eval(withReplicationInfo(cconfigGetInstanceTopologyBody), args, opts)
 	if err != nil {
 		return topology, err
 	}
@@ -255,6 +265,7 @@ func getCConfigInstanceTopology(evaler connector.Evaler) (cconfigTopology, error
 			} else {
 				topology.Instances[i].Mode = ModeRead
 			}
+			topology.Instances[i].Replication = topology.Replication
 		}
 	}
 
@@ -323,6 +334,9 @@ func updateCConfigInstances(replicaset *Replicaset, topology cconfigTopology) {
 			if instance.Mode == ModeUnknown {
 				instance.Mode = tinstance.Mode
 			}
+			if instance.Replication == nil {
+				instance.Replication = tinstance.Replication
+			}
 			if !instance.InstanceCtxFound {
 				instance.InstanceCtx = tinstance.InstanceCtx
 				instance.InstanceCtxFound = tinstance.InstanceCtxFound
@@ -364,6 +378,10 @@ func (c *CConfigApplication) Promote(ctx PromoteCtx) error {
 		log.Warn(msg)
 	}
 
+	if ctx.Switchover {
+		return c.switchover(targetReplicaset, targetInstance, instances, ctx)
+	}
+
 	isConfigPublished, err := c.promote(targetInstance, ctx)
 	// Check the config was published.
 	if isConfigPublished {
@@ -451,6 +469,16 @@ func (c *CConfigApplication) BootstrapVShard(ctx VShardBootstrapCtx) error {
 	return nil
 }
 
+// GetVShardStatus returns a vshard status for an application by the centralized config
+// orchestrator.
+func (c *CConfigApplication) GetVShardStatus() (VShardStatus, error) {
+	replicasets, err := c.Discovery(UseCache)
+	if err != nil {
+		return VShardStatus{}, fmt.Errorf("failed to discovery: %w", err)
+	}
+	return getVShardStatus(replicasets, getDiscoveredEvalers(replicasets))
+}
+
 // Bootstrap is not supported for an application by the centralized config
 // orchestrator.
 func (c *CConfigApplication) Bootstrap(BootstrapCtx) error {
@@ -566,6 +594,86 @@ func (c *CConfigApplication) promote(instance Instance,
 	return true, nil
 }
 
+// switchover promotes an instance in the application with a guarded
+// switchover. The current masters are demoted in the same config patch.
+func (c *CConfigApplication) switchover(replicaset Replicaset, instance Instance,
+	instances []running.InstanceCtx, ctx PromoteCtx) error {
+	clusterCfgPath := instance.InstanceCtx.ClusterConfigPath
+	clusterCfg, err := cluster.GetClusterConfig(
+		libcluster.NewCollectorFactory(c.collectors), clusterCfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster config: %w", err)
+	}
+
+	inst, err := getCConfigInstance(&clusterCfg, ctx.InstName)
+	if err != nil {
+		return err
+	}
+	if inst.failover != FailoverOff && inst.failover != FailoverManual {
+		return newErrSwitchoverByFailoverNotSupported(inst.failover)
+	}
+
+	collector, publisher, err := cconfigCreateCollectorAndDataPublisher(
+		c.collectors, c.publishers, clusterCfgPath)
+	if err != nil {
+		return err
+	}
+	original, err := collector.Collect()
+	if err != nil {
+		return fmt.Errorf("failed to collect a configuration to update: %w", err)
+	}
+
+	var masters []cconfigInstance
+	for _, master := range replicaset.Instances {
+		if master.UUID != instance.UUID && master.Mode == ModeRW {
+			masterInst, err := getCConfigInstance(&clusterCfg, master.Alias)
+			if err != nil {
+				return err
+			}
+			masters = append(masters, masterInst)
+		}
+	}
+
+	return switchover(switchoverCtx{
+		Replicaset: replicaset,
+		Candidate:  instance,
+		Timeout:    ctx.Timeout,
+		Evaler: func(instance Instance) connector.Evaler {
+			return MakeInstanceEvalFunc(instance.InstanceCtx)
+		},
+		Promote: func() error {
+			err := patchLocalCConfig(
+				clusterCfgPath,
+				c.collectors,
+				c.publishers,
+				func(config *libcluster.Config) (*libcluster.Config, error) {
+					config, err := patchCConfigPromote(config, inst)
+					if err != nil || inst.failover != FailoverOff {
+						return config, err
+					}
+					for _, master := range masters {
+						if config, err = patchCConfigDemote(config, master); err != nil {
+							return nil, err
+						}
+					}
+					return config, nil
+				},
+			)
+			if err != nil {
+				return err
+			}
+			return reloadCConfig(instances)
+		},
+		Rollback: func([]Instance) error {
+			err := libcluster.NewYamlConfigPublisher(publisher).Publish(original)
+			if err != nil {
+				return fmt.Errorf("failed to restore the configuration: %w", err)
+			}
+			return reloadCConfig(instances)
+		},
+	})
+}
+
 // demote demotes an instance in the application and returns true
 // if the instance config was published.
 func (c *CConfigApplication) demote(instance Instance,
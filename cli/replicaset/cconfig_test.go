@@ -17,6 +17,7 @@ var _ replicaset.Promoter = &replicaset.CConfigInstance{}
 var _ replicaset.Demoter = &replicaset.CConfigInstance{}
 var _ replicaset.Expeller = &replicaset.CConfigInstance{}
 var _ replicaset.VShardBootstrapper = &replicaset.CConfigInstance{}
+var _ replicaset.VShardStatusGetter = &replicaset.CConfigInstance{}
 var _ replicaset.Bootstrapper = &replicaset.CConfigInstance{}
 
 var _ replicaset.Discoverer = &replicaset.CConfigApplication{}
@@ -24,6 +25,7 @@ var _ replicaset.Promoter = &replicaset.CConfigApplication{}
 var _ replicaset.Demoter = &replicaset.CConfigApplication{}
 var _ replicaset.Expeller = &replicaset.CConfigApplication{}
 var _ replicaset.VShardBootstrapper = &replicaset.CConfigApplication{}
+var _ replicaset.VShardStatusGetter = &replicaset.CConfigApplication{}
 var _ replicaset.Bootstrapper = &replicaset.CConfigApplication{}
 
 func TestCconfigApplication_Bootstrap(t *testing.T) {
@@ -288,6 +290,104 @@ func TestCConfigInstance_Discovery(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "replication",
+			Evaler: &instanceMockEvaler{
+				Ret: [][]any{
+					[]any{
+						map[any]any{
+							"uuid":         "somereplicasetuuid",
+							"alias":        "somealias",
+							"instanceuuid": "someinstanceuuid",
+							"instancerw":   true,
+							"failover":     "election",
+							"replication": map[any]any{
+								"vclock": map[any]any{
+									uint64(1): uint64(10),
+									uint64(2): uint64(5),
+								},
+								"electionterm":  uint64(2),
+								"electionstate": "leader",
+								"upstreams": []any{
+									map[any]any{
+										"uuid":    "otherinstanceuuid",
+										"status":  "stopped",
+										"idle":    12.5,
+										"message": "connection refused",
+									},
+								},
+								"downstreams": []any{
+									map[any]any{
+										"uuid":   "otherinstanceuuid",
+										"status": "follow",
+										"lag":    0.5,
+										"idle":   0.1,
+									},
+								},
+							},
+							"instances": []any{
+								map[any]any{
+									"alias": "instance",
+									"uuid":  "someinstanceuuid",
+									"uri":   "anyuri",
+								},
+								map[any]any{
+									"alias": "other",
+									"uuid":  "otherinstanceuuid",
+									"uri":   "otheruri",
+								},
+							},
+						},
+					},
+				},
+			},
+			Expected: replicaset.Replicasets{
+				State:        replicaset.StateBootstrapped,
+				Orchestrator: replicaset.OrchestratorCentralizedConfig,
+				Replicasets: []replicaset.Replicaset{
+					replicaset.Replicaset{
+						UUID:     "somereplicasetuuid",
+						Alias:    "somealias",
+						Master:   replicaset.MasterUnknown,
+						Failover: replicaset.FailoverElection,
+						Instances: []replicaset.Instance{
+							replicaset.Instance{
+								Alias: "instance",
+								UUID:  "someinstanceuuid",
+								URI:   "anyuri",
+								Mode:  replicaset.ModeRW,
+								Replication: &replicaset.Replication{
+									VClock:        map[uint64]uint64{1: 10, 2: 5},
+									ElectionTerm:  2,
+									ElectionState: "leader",
+									Upstreams: []replicaset.ReplicationLink{
+										{
+											UUID:    "otherinstanceuuid",
+											Status:  "stopped",
+											Idle:    12.5,
+											Message: "connection refused",
+										},
+									},
+									Downstreams: []replicaset.ReplicationLink{
+										{
+											UUID:   "otherinstanceuuid",
+											Status: "follow",
+											Lag:    0.5,
+											Idle:   0.1,
+										},
+									},
+								},
+							},
+							replicaset.Instance{
+								Alias: "other",
+								UUID:  "otherinstanceuuid",
+								URI:   "otheruri",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -65,7 +65,7 @@ func Bootstrap(ctx BootstapCtx) error {
 		if err != nil {
 			return err
 		}
-		statusReplicasets(replicasets)
+		statusReplicasets(replicasets, false)
 		fmt.Println()
 		log.Info("Done.")
 	}
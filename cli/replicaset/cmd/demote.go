@@ -52,7 +52,7 @@ func Demote(ctx DemoteCtx) error {
 	if err != nil {
 		return err
 	}
-	statusReplicasets(replicasets)
+	statusReplicasets(replicasets, false)
 	fmt.Println()
 
 	if ctx.InstName != "" {
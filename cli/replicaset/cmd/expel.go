@@ -52,7 +52,7 @@ func Expel(expelCtx ExpelCtx) error {
 	if err != nil {
 		return err
 	}
-	statusReplicasets(replicasets)
+	statusReplicasets(replicasets, false)
 
 	fmt.Println("")
 	log.Infof("Expel instance: %s", expelCtx.Instance)
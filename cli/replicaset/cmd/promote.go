@@ -32,6 +32,8 @@ type PromoteCtx struct {
 	// Timeout describes a timeout in seconds.
 	// We keep int as it can be passed to the target instance.
 	Timeout int
+	// Switchover is true if a guarded switchover is requested.
+	Switchover bool
 }
 
 // Promote promotes an instance.
@@ -61,7 +63,7 @@ func Promote(ctx PromoteCtx) error {
 	if err != nil {
 		return err
 	}
-	statusReplicasets(replicasets)
+	statusReplicasets(replicasets, false)
 	fmt.Println()
 
 	if ctx.InstName != "" {
@@ -69,9 +71,10 @@ func Promote(ctx PromoteCtx) error {
 	}
 
 	err = orchestrator.Promote(replicaset.PromoteCtx{
-		InstName: ctx.InstName,
-		Force:    ctx.Force,
-		Timeout:  ctx.Timeout,
+		InstName:   ctx.InstName,
+		Force:      ctx.Force,
+		Timeout:    ctx.Timeout,
+		Switchover: ctx.Switchover,
 	})
 	if err == nil {
 		log.Info("Done.")
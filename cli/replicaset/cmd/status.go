@@ -1,9 +1,13 @@
 package replicasetcmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v2"
 
 	"github.com/tarantool/tt/cli/connector"
 	"github.com/tarantool/tt/cli/replicaset"
@@ -21,8 +25,20 @@ type StatusCtx struct {
 	Conn connector.Connector
 	// Orchestrator is a forced orchestator choice.
 	Orchestrator replicaset.Orchestrator
+	// Replication is true if replication details of instances should be
+	// shown.
+	Replication bool
+	// Format is an output format: table, json or yaml.
+	Format string
 }
 
+// StatusFormats contains supported output formats of the status.
+var StatusFormats = []string{"table", "json", "yaml"}
+
+// replicationLagThreshold is a replication lag in seconds after which a
+// link is flagged as lagging.
+const replicationLagThreshold = 1.0
+
 // Status shows a replicaset status.
 func Status(statusCtx StatusCtx) error {
 	orchestratorType, err := getOrchestratorType(statusCtx.Orchestrator,
@@ -49,11 +65,14 @@ func Status(statusCtx StatusCtx) error {
 		return err
 	}
 
-	return statusReplicasets(replicasets)
+	if statusCtx.Format != "" && statusCtx.Format != "table" {
+		return encodeReplicasets(replicasets, statusCtx.Format)
+	}
+	return statusReplicasets(replicasets, statusCtx.Replication)
 }
 
 // statusReplicasets show the current status of known replicasets.
-func statusReplicasets(replicasets replicaset.Replicasets) error {
+func statusReplicasets(replicasets replicaset.Replicasets, replication bool) error {
 	if replicasets.State == replicaset.StateUnknown {
 		return fmt.Errorf("unknown or empty replicasets configuration")
 	}
@@ -68,8 +87,144 @@ func statusReplicasets(replicasets replicaset.Replicasets) error {
 		fmt.Println()
 	}
 	for _, replicaset := range replicasets.Replicasets {
-		fmt.Print(replicasetToString(replicaset))
+		fmt.Print(replicasetToString(replicaset, replication))
+	}
+	return nil
+}
+
+// replicasetsDoc is a representation of replicasets for encoding.
+type replicasetsDoc struct {
+	Orchestrator string          `yaml:"orchestrator" json:"orchestrator"`
+	State        string          `yaml:"state" json:"state"`
+	Replicasets  []replicasetDoc `yaml:"replicasets" json:"replicasets"`
+}
+
+// replicasetDoc is a representation of a replicaset for encoding.
+type replicasetDoc struct {
+	UUID          string        `yaml:"uuid" json:"uuid"`
+	LeaderUUID    string        `yaml:"leader_uuid" json:"leader_uuid"`
+	Alias         string        `yaml:"alias" json:"alias"`
+	Roles         []string      `yaml:"roles" json:"roles"`
+	Master        string        `yaml:"master" json:"master"`
+	Failover      string        `yaml:"failover" json:"failover"`
+	StateProvider string        `yaml:"state_provider" json:"state_provider"`
+	Instances     []instanceDoc `yaml:"instances" json:"instances"`
+}
+
+// instanceDoc is a representation of an instance for encoding.
+type instanceDoc struct {
+	Alias       string          `yaml:"alias" json:"alias"`
+	UUID        string          `yaml:"uuid" json:"uuid"`
+	URI         string          `yaml:"uri" json:"uri"`
+	Mode        string          `yaml:"mode" json:"mode"`
+	Replication *replicationDoc `yaml:"replication" json:"replication"`
+}
+
+// replicationDoc is a representation of an instance replication state for
+// encoding.
+type replicationDoc struct {
+	VClock        map[uint64]uint64 `yaml:"vclock" json:"vclock"`
+	ElectionTerm  uint64            `yaml:"election_term" json:"election_term"`
+	ElectionState string            `yaml:"election_state" json:"election_state"`
+	Upstreams     []linkDoc         `yaml:"upstreams" json:"upstreams"`
+	Downstreams   []linkDoc         `yaml:"downstreams" json:"downstreams"`
+}
+
+// linkDoc is a representation of a replication link for encoding.
+type linkDoc struct {
+	UUID    string  `yaml:"uuid" json:"uuid"`
+	Status  string  `yaml:"status" json:"status"`
+	Lag     float64 `yaml:"lag" json:"lag"`
+	Idle    float64 `yaml:"idle" json:"idle"`
+	Message string  `yaml:"message" json:"message"`
+}
+
+// newLinkDocs returns representations of replication links for encoding.
+func newLinkDocs(links []replicaset.ReplicationLink) []linkDoc {
+	docs := []linkDoc{}
+	for _, link := range links {
+		docs = append(docs, linkDoc{
+			UUID:    link.UUID,
+			Status:  link.Status,
+			Lag:     link.Lag,
+			Idle:    link.Idle,
+			Message: link.Message,
+		})
+	}
+	return docs
+}
+
+// newReplicasetsDoc returns a representation of replicasets for encoding.
+func newReplicasetsDoc(replicasets replicaset.Replicasets) replicasetsDoc {
+	doc := replicasetsDoc{
+		Orchestrator: replicasets.Orchestrator.String(),
+		State:        replicasets.State.String(),
+		Replicasets:  []replicasetDoc{},
+	}
+	for _, replicaset := range replicasets.Replicasets {
+		replicasetDoc := replicasetDoc{
+			UUID:          replicaset.UUID,
+			LeaderUUID:    replicaset.LeaderUUID,
+			Alias:         replicaset.Alias,
+			Roles:         append([]string{}, replicaset.Roles...),
+			Master:        replicaset.Master.String(),
+			Failover:      replicaset.Failover.String(),
+			StateProvider: replicaset.StateProvider.String(),
+			Instances:     []instanceDoc{},
+		}
+		for _, instance := range replicaset.Instances {
+			instanceDoc := instanceDoc{
+				Alias: instance.Alias,
+				UUID:  instance.UUID,
+				URI:   instance.URI,
+				Mode:  instance.Mode.String(),
+			}
+			if replication := instance.Replication; replication != nil {
+				vclock := map[uint64]uint64{}
+				for id, lsn := range replication.VClock {
+					vclock[id] = lsn
+				}
+				instanceDoc.Replication = &replicationDoc{
+					VClock:        vclock,
+					ElectionTerm:  replication.ElectionTerm,
+					ElectionState: replication.ElectionState,
+					Upstreams:     newLinkDocs(replication.Upstreams),
+					Downstreams:   newLinkDocs(replication.Downstreams),
+				}
+			}
+			replicasetDoc.Instances = append(replicasetDoc.Instances, instanceDoc)
+		}
+		doc.Replicasets = append(doc.Replicasets, replicasetDoc)
+	}
+	return doc
+}
+
+// encodeReplicasets prints known replicasets encoded in the format.
+func encodeReplicasets(replicasets replicaset.Replicasets, format string) error {
+	if replicasets.State == replicaset.StateUnknown {
+		return fmt.Errorf("unknown or empty replicasets configuration")
+	}
+
+	replicasets = sortAliases(replicasets)
+	doc := newReplicasetsDoc(replicasets)
+
+	var (
+		encoded []byte
+		err     error
+	)
+	switch format {
+	case "json":
+		encoded, err = json.MarshalIndent(doc, "", "  ")
+		encoded = append(encoded, '\n')
+	case "yaml":
+		encoded, err = yaml.Marshal(doc)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode replicasets: %w", err)
 	}
+	fmt.Print(string(encoded))
 	return nil
 }
 
@@ -107,7 +262,7 @@ func sortAliases(replicasets replicaset.Replicasets) replicaset.Replicasets {
 }
 
 // replicasetToString returns a string representation of a replicaset.
-func replicasetToString(replicas replicaset.Replicaset) string {
+func replicasetToString(replicas replicaset.Replicaset, replication bool) string {
 	ret := "• " + replicas.Alias + "\n"
 	ret += "  Failover: " + replicas.Failover.String() + "\n"
 	if replicas.StateProvider != replicaset.StateProviderUnknown {
@@ -126,6 +281,9 @@ func replicasetToString(replicas replicaset.Replicaset) string {
 			ret += "    • "
 		}
 		ret += instanceToString(instance) + "\n"
+		if instance.Replication != nil {
+			ret += replicationToString(replicas, *instance.Replication, replication)
+		}
 	}
 	return ret
 }
@@ -134,3 +292,85 @@ func replicasetToString(replicas replicaset.Replicaset) string {
 func instanceToString(instance replicaset.Instance) string {
 	return instance.Alias + " " + instance.URI + " " + instance.Mode.String()
 }
+
+// replicationToString returns a string representation of a replication state
+// of an instance. Only broken or lagging links are shown if details are not
+// requested.
+func replicationToString(replicas replicaset.Replicaset,
+	replication replicaset.Replication, details bool) string {
+	aliases := map[string]string{}
+	for _, instance := range replicas.Instances {
+		aliases[instance.UUID] = instance.Alias
+	}
+	peer := func(link replicaset.ReplicationLink) string {
+		if alias, ok := aliases[link.UUID]; ok && alias != "" {
+			return alias
+		}
+		return link.UUID
+	}
+
+	var ret strings.Builder
+	if !details {
+		for _, links := range []struct {
+			direction string
+			links     []replicaset.ReplicationLink
+		}{
+			{"upstream from", replication.Upstreams},
+			{"downstream to", replication.Downstreams},
+		} {
+			for _, link := range links.links {
+				if problem := linkProblem(link); problem != "" {
+					fmt.Fprintf(&ret, "      ⚠ %s %s: %s\n", links.direction, peer(link),
+						problem)
+				}
+			}
+		}
+		return ret.String()
+	}
+
+	if replication.ElectionState != "" {
+		fmt.Fprintf(&ret, "      Election: %s, term %d\n", replication.ElectionState,
+			replication.ElectionTerm)
+	}
+	fmt.Fprintf(&ret, "      VClock:   %s\n", replicaset.FormatVClock(replication.VClock))
+	if len(replication.Upstreams) == 0 && len(replication.Downstreams) == 0 {
+		return ret.String()
+	}
+
+	w := tabwriter.NewWriter(&ret, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "      LINK\tPEER\tSTATUS\tLAG\tIDLE")
+	for _, links := range []struct {
+		direction string
+		links     []replicaset.ReplicationLink
+	}{
+		{"upstream", replication.Upstreams},
+		{"downstream", replication.Downstreams},
+	} {
+		for _, link := range links.links {
+			fmt.Fprintf(w, "      %s\t%s\t%s\t%.3fs\t%.3fs", links.direction, peer(link),
+				link.Status, link.Lag, link.Idle)
+			if problem := linkProblem(link); problem != "" {
+				fmt.Fprintf(w, "\t⚠ %s", problem)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	w.Flush()
+	return ret.String()
+}
+
+// linkProblem returns a description of a broken or lagging link or an empty
+// string if the link is healthy.
+func linkProblem(link replicaset.ReplicationLink) string {
+	if link.IsBroken() {
+		problem := "broken, status " + link.Status
+		if link.Message != "" {
+			problem += ": " + link.Message
+		}
+		return problem
+	}
+	if link.IsLagging(replicationLagThreshold) {
+		return fmt.Sprintf("lagging for %.3fs", link.Lag)
+	}
+	return ""
+}
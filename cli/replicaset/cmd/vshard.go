@@ -2,6 +2,9 @@ package replicasetcmd
 
 import (
 	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/apex/log"
 	"github.com/tarantool/tt/cli/connector"
@@ -13,8 +16,14 @@ import (
 const (
 	// VShardBootstrapDefaultTimeout is a default timeout for vshard bootstrapping.
 	VShardBootstrapDefaultTimeout = 10
+	// VShardWaitBalancedDefaultTimeout is a default timeout for waiting until
+	// the cluster is balanced.
+	VShardWaitBalancedDefaultTimeout = 60
 )
 
+// vshardPollInterval is an interval between vshard status checks.
+var vshardPollInterval = time.Second
+
 // VShardCmdCtx describes context for vshard commands.
 type VShardCmdCtx struct {
 	// IsApplication true if an application passed.
@@ -32,26 +41,29 @@ type VShardCmdCtx struct {
 	// Timeout describes a timeout in seconds.
 	// We keep int as it can be passed to the target instance.
 	Timeout int
+	// WaitBalanced is true if the status command should wait until the
+	// cluster is balanced.
+	WaitBalanced bool
 }
 
-// BootstrapVShard bootstraps vshard in the cluster.
-func BootstrapVShard(ctx VShardCmdCtx) error {
+// makeVShardOrchestrator creates an orchestrator for the vshard command.
+func makeVShardOrchestrator(ctx VShardCmdCtx) (replicasetOrchestrator, error) {
 	orchestratorType, err := getOrchestratorType(ctx.Orchestrator, ctx.Conn, ctx.RunningCtx)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	var orchestrator replicasetOrchestrator
 	if ctx.IsApplication {
-		if orchestrator, err = makeApplicationOrchestrator(
-			orchestratorType, ctx.RunningCtx, ctx.Collectors, ctx.Publishers); err != nil {
-			return err
-		}
-	} else {
-		if orchestrator, err = makeInstanceOrchestrator(
-			orchestratorType, ctx.Conn); err != nil {
-			return err
-		}
+		return makeApplicationOrchestrator(
+			orchestratorType, ctx.RunningCtx, ctx.Collectors, ctx.Publishers)
+	}
+	return makeInstanceOrchestrator(orchestratorType, ctx.Conn)
+}
+
+// BootstrapVShard bootstraps vshard in the cluster.
+func BootstrapVShard(ctx VShardCmdCtx) error {
+	orchestrator, err := makeVShardOrchestrator(ctx)
+	if err != nil {
+		return err
 	}
 
 	log.Info("Discovery application...")
@@ -62,7 +74,7 @@ func BootstrapVShard(ctx VShardCmdCtx) error {
 	if err != nil {
 		return err
 	}
-	statusReplicasets(replicasets)
+	statusReplicasets(replicasets, false)
 
 	fmt.Println("")
 	log.Info("Bootstrapping vshard")
@@ -73,3 +85,102 @@ func BootstrapVShard(ctx VShardCmdCtx) error {
 	}
 	return err
 }
+
+// VShardStatus shows a vshard status of the cluster: bucket counters of the
+// storages and the routers, the rebalancer and the alerts. It waits until
+// the cluster is balanced if requested.
+func VShardStatus(ctx VShardCmdCtx) error {
+	orchestrator, err := makeVShardOrchestrator(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := orchestrator.GetVShardStatus()
+	if err != nil {
+		return err
+	}
+	if ctx.WaitBalanced && !status.Balanced {
+		log.Info("Waiting for the cluster to be balanced...")
+		deadline := time.Now().Add(time.Duration(ctx.Timeout) * time.Second)
+		for !status.Balanced && time.Now().Before(deadline) {
+			time.Sleep(vshardPollInterval)
+			if status, err = orchestrator.GetVShardStatus(); err != nil {
+				return err
+			}
+		}
+	}
+
+	fmt.Print(vshardStatusToString(status))
+	if ctx.WaitBalanced && !status.Balanced {
+		if status.RebalancerError != "" {
+			return fmt.Errorf("timeout: the cluster is not balanced in %d seconds,"+
+				" the rebalancer error: %s", ctx.Timeout, status.RebalancerError)
+		}
+		return fmt.Errorf("timeout: the cluster is not balanced in %d seconds", ctx.Timeout)
+	}
+	return nil
+}
+
+// vshardStatusToString returns a string representation of the vshard status.
+func vshardStatusToString(status replicaset.VShardStatus) string {
+	var ret strings.Builder
+
+	fmt.Fprintln(&ret, "Storages:")
+	w := tabwriter.NewWriter(&ret, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  REPLICASET\tMASTER\tWEIGHT\tACTIVE\tPINNED\tSENDING"+
+		"\tRECEIVING\tGARBAGE\tTOTAL")
+	for _, storage := range status.Storages {
+		buckets := storage.Buckets
+		fmt.Fprintf(w, "  %s\t%s\t%g\t%d\t%d\t%d\t%d\t%d\t%d\n", storage.Alias,
+			storage.Master, storage.Weight, buckets.Active, buckets.Pinned,
+			buckets.Sending, buckets.Receiving, buckets.Garbage, buckets.Total)
+	}
+	w.Flush()
+
+	fmt.Fprintln(&ret, "Routers:")
+	w = tabwriter.NewWriter(&ret, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  ROUTER\tAVAILABLE RW\tAVAILABLE RO\tUNREACHABLE\tUNKNOWN")
+	for _, router := range status.Routers {
+		buckets := router.Buckets
+		fmt.Fprintf(w, "  %s\t%d\t%d\t%d\t%d\n", router.Alias, buckets.AvailableRW,
+			buckets.AvailableRO, buckets.Unreachable, buckets.Unknown)
+	}
+	w.Flush()
+
+	fmt.Fprintf(&ret, "Bucket count: %d\n", status.BucketCount)
+	rebalancer := "not found"
+	if status.Rebalancer != "" {
+		rebalancer = status.Rebalancer
+		if status.RebalancerState != "" {
+			rebalancer += ", " + status.RebalancerState
+		}
+		if status.RebalancerError != "" {
+			rebalancer += ", error: " + status.RebalancerError
+		}
+	}
+	fmt.Fprintf(&ret, "Rebalancer: %s\n", rebalancer)
+	balanced := "no"
+	if status.Balanced {
+		balanced = "yes"
+	}
+	fmt.Fprintf(&ret, "Balanced: %s\n", balanced)
+
+	var alerts []string
+	for _, storage := range status.Storages {
+		for _, alert := range storage.Alerts {
+			alerts = append(alerts, fmt.Sprintf("  ⚠ %s: %s: %s", storage.Alias, alert.Code,
+				alert.Message))
+		}
+	}
+	for _, router := range status.Routers {
+		for _, alert := range router.Alerts {
+			alerts = append(alerts, fmt.Sprintf("  ⚠ %s: %s: %s", router.Alias, alert.Code,
+				alert.Message))
+		}
+	}
+	if len(alerts) > 0 {
+		fmt.Fprintln(&ret, "Alerts:")
+		fmt.Fprintln(&ret, strings.Join(alerts, "\n"))
+	}
+	return ret.String()
+}
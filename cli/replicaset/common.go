@@ -14,8 +14,18 @@ var (
 
 	//go:embed lua/wait_ro.lua
 	waitROBody string
+
+	//go:embed lua/get_replication_info.lua
+	getReplicationInfoFunc string
 )
 
+// withReplicationInfo prepends the definition of get_replication_info(box_info)
+// function to the body. The function returns the replication state of the
+// instance: vclock, election and links.
+func withReplicationInfo(body string) string {
+	return getReplicationInfoFunc + "\n" + body
+}
+
 // waitRW waits until the instance becomes rw.
 func waitRW(eval connector.Evaler, timeout int) error {
 	var opts connector.RequestOpts
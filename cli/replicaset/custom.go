@@ -2,16 +2,36 @@ package replicaset
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/apex/log"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/tarantool/tt/cli/connector"
 	"github.com/tarantool/tt/cli/running"
 )
 
-//go:embed lua/custom/get_instance_topology_body.lua
-var customGetInstanceTopologyBody string
+var (
+	//go:embed lua/custom/get_instance_topology_body.lua
+	customGetInstanceTopologyBody string
+
+	//go:embed lua/custom/promote_election.lua
+	customPromoteElectionBody string
+
+	//go:embed lua/custom/demote_election.lua
+	customDemoteElectionBody string
+
+	//go:embed lua/custom/demote_manual.lua
+	customDemoteManualBody string
+
+	//go:embed lua/custom/wait_other_leader.lua
+	customWaitOtherLeaderBody string
+
+	customGetElectionModeBody = "return box.cfg.election_mode"
+	customSetCandidateBody    = "box.cfg{election_mode = 'candidate'}"
+)
 
 // customTopology used to export topology information from a Tarantool instance
 // with a custom orchestrator.
@@ -28,6 +48,8 @@ type customTopology struct {
 	InstanceUUID string
 	// InstanceRW is true when the current instance is in RW mode.
 	InstanceRW bool
+	// Replication is a replication state of the current instance.
+	Replication *Replication
 }
 
 // CustomInstance is an instance with custom/unknown orchestrator. In this
@@ -68,14 +90,23 @@ func (c *CustomInstance) discovery() (Replicasets, error) {
 	}), nil
 }
 
-// Promote is not supported for a single instance by the Custom orchestrator.
+// Promote promotes an instance via box.ctl.promote(). A single instance can be
+// promoted only if elections are enabled in the replicaset.
 func (c *CustomInstance) Promote(ctx PromoteCtx) error {
-	return newErrPromoteByInstanceNotSupported(OrchestratorCustom)
+	if ctx.Switchover {
+		return newErrSwitchoverByInstanceNotSupported(OrchestratorCustom)
+	}
+	return customPromoteElection(c.evaler, ctx.Timeout)
 }
 
-// Demote is not supported for a single instance by the Custom orchestrator.
+// Demote demotes an instance via elections. A single instance can be demoted
+// only if elections are enabled in the replicaset.
 func (c *CustomInstance) Demote(ctx DemoteCtx) error {
-	return newErrDemoteByInstanceNotSupported(OrchestratorCustom)
+	mode, err := customGetElectionMode(c.evaler)
+	if err != nil {
+		return err
+	}
+	return customDemoteElection(c.evaler, mode, ctx.Timeout)
 }
 
 // Expel is not supported for a single instance by the Custom orchestrator.
@@ -88,6 +119,11 @@ func (c *CustomInstance) BootstrapVShard(VShardBootstrapCtx) error {
 	return newErrBootstrapVShardByInstanceNotSupported(OrchestratorCustom)
 }
 
+// GetVShardStatus is not supported for a single instance by the Custom orchestrator.
+func (c *CustomInstance) GetVShardStatus() (VShardStatus, error) {
+	return VShardStatus{}, newErrVShardStatusByInstanceNotSupported(OrchestratorCustom)
+}
+
 // Bootstrap is not supported for a single instance by the Custom orchestrator.
 func (c *CustomInstance) Bootstrap(BootstrapCtx) error {
 	return newErrBootstrapByInstanceNotSupported(OrchestratorCustom)
@@ -137,14 +173,101 @@ func (c *CustomApplication) discovery() (Replicasets, error) {
 	return mergeCustomTopologies(topologies)
 }
 
-// Promote is not supported for an application by the Custom orchestrator.
+// Promote promotes an instance in the application. The instance is promoted
+// via box.ctl.promote() if elections are enabled. Otherwise, the current
+// masters are made read-only and the instance is made writable after it
+// catches up with them.
 func (c *CustomApplication) Promote(ctx PromoteCtx) error {
-	return newErrPromoteByAppNotSupported(OrchestratorCustom)
+	targetReplicaset, targetInstance, err := c.findTarget(ctx.InstName, ctx.Force)
+	if err != nil {
+		return err
+	}
+
+	evaler := MakeInstanceEvalFunc(targetInstance.InstanceCtx)
+	mode, err := customGetElectionMode(evaler)
+	if err != nil {
+		return err
+	}
+	if customIsElection(mode) {
+		if ctx.Switchover {
+			return newErrSwitchoverByFailoverNotSupported(FailoverElection)
+		}
+		return customPromoteElection(evaler, ctx.Timeout)
+	}
+
+	return switchover(switchoverCtx{
+		Replicaset: targetReplicaset,
+		Candidate:  targetInstance,
+		Timeout:    ctx.Timeout,
+		Evaler: func(instance Instance) connector.Evaler {
+			return MakeInstanceEvalFunc(instance.InstanceCtx)
+		},
+		Promote: func() error {
+			return setReadOnly(evaler, false)
+		},
+		Rollback: func([]Instance) error {
+			return setReadOnly(evaler, true)
+		},
+	})
 }
 
-// Demote is not supported for an application by the Custom orchestrator.
+// Demote demotes an instance in the application. The instance must be the
+// leader if elections are enabled: it is demoted via box.ctl.demote() in
+// the manual election mode or becomes a voter until an other instance is
+// elected in the candidate mode. Otherwise, the instance is made read-only.
 func (c *CustomApplication) Demote(ctx DemoteCtx) error {
-	return newErrDemoteByAppNotSupported(OrchestratorCustom)
+	_, targetInstance, err := c.findTarget(ctx.InstName, ctx.Force)
+	if err != nil {
+		return err
+	}
+
+	evaler := MakeInstanceEvalFunc(targetInstance.InstanceCtx)
+	mode, err := customGetElectionMode(evaler)
+	if err != nil {
+		return err
+	}
+	if customIsElection(mode) {
+		return customDemoteElection(evaler, mode, ctx.Timeout)
+	}
+
+	if err := setReadOnly(evaler, true); err != nil {
+		return fmt.Errorf("failed to make %q read-only: %w", ctx.InstName, err)
+	}
+	return waitRO(evaler, ctx.Timeout)
+}
+
+// findTarget returns an online instance and its replicaset. All instances in
+// the replicaset should be online unless force is set.
+func (c *CustomApplication) findTarget(instName string,
+	force bool) (Replicaset, Instance, error) {
+	replicasets, err := c.Discovery(UseCache)
+	if err != nil {
+		return Replicaset{}, Instance{}, fmt.Errorf("failed to get replicasets: %w", err)
+	}
+	targetReplicaset, targetInstance, found := findInstanceByAlias(replicasets, instName)
+	if !found {
+		return Replicaset{}, Instance{},
+			fmt.Errorf("instance %q not found in a replicaset", instName)
+	}
+	if !targetInstance.InstanceCtxFound {
+		return Replicaset{}, Instance{}, fmt.Errorf("instance %q should be online", instName)
+	}
+
+	var unfound []string
+	for _, inst := range targetReplicaset.Instances {
+		if !inst.InstanceCtxFound {
+			unfound = append(unfound, inst.Alias)
+		}
+	}
+	if len(unfound) > 0 {
+		msg := fmt.Sprintf("could not connect to: %s", strings.Join(unfound, ","))
+		if !force {
+			return Replicaset{}, Instance{}, fmt.Errorf(
+				"all instances in the target replicaset should be online, %s", msg)
+		}
+		log.Warn(msg)
+	}
+	return targetReplicaset, targetInstance, nil
 }
 
 // Expel is not supported for an application by the Custom orchestrator.
@@ -157,6 +280,11 @@ func (c *CustomApplication) BootstrapVShard(VShardBootstrapCtx) error {
 	return newErrBootstrapVShardByAppNotSupported(OrchestratorCustom)
 }
 
+// GetVShardStatus is not supported for an application by the Custom orchestrator.
+func (c *CustomApplication) GetVShardStatus() (VShardStatus, error) {
+	return VShardStatus{}, newErrVShardStatusByAppNotSupported(OrchestratorCustom)
+}
+
 // Bootstrap is not supported for an application by the Custom orchestrator.
 func (c *CustomApplication) Bootstrap(BootstrapCtx) error {
 	return newErrBootstrapByAppNotSupported(OrchestratorCustom)
@@ -169,7 +297,7 @@ func getCustomInstanceTopology(name string,
 
 	args := []any{}
 	opts := connector.RequestOpts{}
-	data, err := evaler.Eval(customGetInstanceTopologyBody, args, opts)
+	data, err := evaler.Eval(withReplicationInfo(customGetInstanceTopologyBody), args, opts)
 	if err != nil {
 		return topology, err
 	}
@@ -189,6 +317,7 @@ func getCustomInstanceTopology(name string,
 			} else {
 				topology.Instances[i].Mode = ModeRead
 			}
+			topology.Instances[i].Replication = topology.Replication
 			if topology.Instances[i].Alias == "" {
 				topology.Instances[i].Alias = name
 			}
@@ -250,6 +379,9 @@ func updateCustomInstances(replicaset *Replicaset, topology customTopology) {
 			if instance.Mode == ModeUnknown {
 				instance.Mode = tinstance.Mode
 			}
+			if instance.Replication == nil {
+				instance.Replication = tinstance.Replication
+			}
 			if !instance.InstanceCtxFound {
 				instance.InstanceCtx = tinstance.InstanceCtx
 				instance.InstanceCtxFound = tinstance.InstanceCtxFound
@@ -259,3 +391,65 @@ func updateCustomInstances(replicaset *Replicaset, topology customTopology) {
 		}
 	}
 }
+
+// customGetElectionMode returns the election mode of the instance.
+func customGetElectionMode(evaler connector.Evaler) (ElectionMode, error) {
+	data, err := evaler.Eval(customGetElectionModeBody, []any{}, connector.RequestOpts{})
+	if err != nil {
+		return ElectionModeUnknown, fmt.Errorf("failed to get election mode: %w", err)
+	}
+	if len(data) != 1 {
+		return ElectionModeUnknown, fmt.Errorf("unexpected response: %v", data)
+	}
+	// box.cfg.election_mode is nil for Tarantool without elections.
+	mode, _ := data[0].(string)
+	return ParseElectionMode(mode), nil
+}
+
+// customIsElection returns true if elections are enabled in the mode.
+func customIsElection(mode ElectionMode) bool {
+	return mode != ElectionModeUnknown && mode != ElectionModeOff
+}
+
+// customPromoteElection promotes an instance via box.ctl.promote().
+func customPromoteElection(evaler connector.Evaler, timeout int) error {
+	_, err := evaler.Eval(customPromoteElectionBody, []any{}, connector.RequestOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to promote via election: %w", err)
+	}
+	return waitRW(evaler, timeout)
+}
+
+// customDemoteElection demotes the leader. In the manual mode it calls
+// box.ctl.demote() and waits until the instance becomes read-only. In
+// the candidate mode it makes the leader a voter, waits until it becomes
+// read-only and an other leader is elected and makes it a candidate back.
+func customDemoteElection(evaler connector.Evaler, mode ElectionMode, timeout int) error {
+	switch mode {
+	case ElectionModeManual:
+		_, err := evaler.Eval(customDemoteManualBody, []any{}, connector.RequestOpts{})
+		if err != nil {
+			return fmt.Errorf("failed to demote via election: %w", err)
+		}
+		return waitRO(evaler, timeout)
+	case ElectionModeCandidate:
+	default:
+		return fmt.Errorf("unexpected election_mode: %q, \"candidate\" or \"manual\" expected",
+			mode)
+	}
+
+	_, err := evaler.Eval(customDemoteElectionBody, []any{}, connector.RequestOpts{})
+	if err != nil {
+		return fmt.Errorf("failed to demote via election: %w", err)
+	}
+	err = waitRO(evaler, timeout)
+	if err == nil {
+		_, err = evaler.Eval(customWaitOtherLeaderBody, []any{timeout},
+			connector.RequestOpts{})
+	}
+	_, restoreErr := evaler.Eval(customSetCandidateBody, []any{}, connector.RequestOpts{})
+	if restoreErr != nil {
+		restoreErr = fmt.Errorf("failed to restore election_mode: %w", restoreErr)
+	}
+	return errors.Join(err, restoreErr)
+}
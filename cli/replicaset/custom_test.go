@@ -16,6 +16,7 @@ var _ replicaset.Promoter = &replicaset.CustomInstance{}
 var _ replicaset.Demoter = &replicaset.CustomInstance{}
 var _ replicaset.Expeller = &replicaset.CustomInstance{}
 var _ replicaset.VShardBootstrapper = &replicaset.CustomInstance{}
+var _ replicaset.VShardStatusGetter = &replicaset.CustomInstance{}
 var _ replicaset.Bootstrapper = &replicaset.CustomInstance{}
 
 var _ replicaset.Discoverer = &replicaset.CustomApplication{}
@@ -23,20 +24,19 @@ var _ replicaset.Promoter = &replicaset.CustomApplication{}
 var _ replicaset.Demoter = &replicaset.CustomApplication{}
 var _ replicaset.Expeller = &replicaset.CustomApplication{}
 var _ replicaset.VShardBootstrapper = &replicaset.CustomApplication{}
+var _ replicaset.VShardStatusGetter = &replicaset.CustomApplication{}
 var _ replicaset.Bootstrapper = &replicaset.CustomApplication{}
 
-func TestCustomApplication_Promote(t *testing.T) {
+func TestCustomApplication_Promote_noInstances(t *testing.T) {
 	app := replicaset.NewCustomApplication(running.RunningCtx{})
-	err := app.Promote(replicaset.PromoteCtx{})
-	assert.EqualError(t, err,
-		`promote is not supported for an application by "custom" orchestrator`)
+	err := app.Promote(replicaset.PromoteCtx{InstName: "foo"})
+	assert.EqualError(t, err, "failed to get replicasets: no instances to connect")
 }
 
-func TestCustomApplication_Demote(t *testing.T) {
+func TestCustomApplication_Demote_noInstances(t *testing.T) {
 	app := replicaset.NewCustomApplication(running.RunningCtx{})
-	err := app.Demote(replicaset.DemoteCtx{})
-	assert.EqualError(t, err,
-		`demote is not supported for an application by "custom" orchestrator`)
+	err := app.Demote(replicaset.DemoteCtx{InstName: "foo"})
+	assert.EqualError(t, err, "failed to get replicasets: no instances to connect")
 }
 
 func TestCustomApplication_Expel(t *testing.T) {
@@ -53,6 +53,13 @@ func TestCustomApplication_BootstrapVShard(t *testing.T) {
 		`bootstrap vshard is not supported for an application by "custom" orchestrator`)
 }
 
+func TestCustomApplication_GetVShardStatus(t *testing.T) {
+	instance := replicaset.NewCustomApplication(running.RunningCtx{})
+	_, err := instance.GetVShardStatus()
+	assert.EqualError(t, err,
+		`vshard status is not supported for an application by "custom" orchestrator`)
+}
+
 func TestCustomApplication_Bootstrap(t *testing.T) {
 	instance := replicaset.NewCustomApplication(running.RunningCtx{})
 	err := instance.Bootstrap(replicaset.BootstrapCtx{})
@@ -409,17 +416,67 @@ func TestCustomInstance_Discovery_errors(t *testing.T) {
 }
 
 func TestCustomInstance_Promote(t *testing.T) {
-	inst := replicaset.NewCustomInstance(nil)
+	evaler := &instanceMockEvaler{
+		Ret:   [][]any{nil, nil},
+		Error: []error{nil, nil},
+	}
+	inst := replicaset.NewCustomInstance(evaler)
 	err := inst.Promote(replicaset.PromoteCtx{})
-	assert.EqualError(t, err,
-		`promote is not supported for a single instance by "custom" orchestrator`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, evaler.Called)
+}
+
+func TestCustomInstance_Promote_error(t *testing.T) {
+	err := errors.New("some error")
+	evaler := &instanceMockEvaler{
+		Error: []error{err},
+	}
+	inst := replicaset.NewCustomInstance(evaler)
+	actual := inst.Promote(replicaset.PromoteCtx{})
+	require.ErrorIs(t, actual, err)
+	assert.ErrorContains(t, actual, "failed to promote via election")
 }
 
-func TestCustomInstance_Demote(t *testing.T) {
+func TestCustomInstance_Promote_switchover(t *testing.T) {
 	inst := replicaset.NewCustomInstance(nil)
+	err := inst.Promote(replicaset.PromoteCtx{Switchover: true})
+	assert.EqualError(t, err, `switchover is not supported for a single instance`+
+		` by "custom" orchestrator, specify an instance of an application`)
+}
+
+func TestCustomInstance_Demote_manual(t *testing.T) {
+	evaler := &instanceMockEvaler{
+		Ret:   [][]any{{"manual"}, nil, nil},
+		Error: []error{nil, nil, nil},
+	}
+	inst := replicaset.NewCustomInstance(evaler)
+	err := inst.Demote(replicaset.DemoteCtx{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, evaler.Called)
+}
+
+func TestCustomInstance_Demote_manualError(t *testing.T) {
+	err := errors.New("some error")
+	evaler := &instanceMockEvaler{
+		Ret:   [][]any{{"manual"}, nil},
+		Error: []error{nil, err},
+	}
+	inst := replicaset.NewCustomInstance(evaler)
+	actual := inst.Demote(replicaset.DemoteCtx{})
+	require.ErrorIs(t, actual, err)
+	assert.ErrorContains(t, actual, "failed to demote via election")
+}
+
+func TestCustomInstance_Demote_electionOff(t *testing.T) {
+	evaler := &instanceMockEvaler{
+		Ret:   [][]any{{"off"}},
+		Error: []error{nil},
+	}
+	inst := replicaset.NewCustomInstance(evaler)
 	err := inst.Demote(replicaset.DemoteCtx{})
 	assert.EqualError(t, err,
-		`demote is not supported for a single instance by "custom" orchestrator`)
+		`unexpected election_mode: "off", "candidate" or "manual" expected`)
+	assert.Equal(t, 1, evaler.Called)
 }
 
 func TestCustomInstance_Expel(t *testing.T) {
@@ -436,6 +493,13 @@ func TestCustomInstance_BootstrapVShard(t *testing.T) {
 		`bootstrap vshard is not supported for a single instance by "custom" orchestrator`)
 }
 
+func TestCustomInstance_GetVShardStatus(t *testing.T) {
+	instance := replicaset.NewCustomInstance(nil)
+	_, err := instance.GetVShardStatus()
+	assert.EqualError(t, err,
+		`vshard status is not supported for a single instance by "custom" orchestrator`)
+}
+
 func TestCustomInstance_Bootstrap(t *testing.T) {
 	instance := replicaset.NewCustomInstance(nil)
 	err := instance.Bootstrap(replicaset.BootstrapCtx{})
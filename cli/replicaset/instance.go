@@ -14,6 +14,9 @@ type Instance struct {
 	URI string
 	// Mode of the instance.
 	Mode Mode
+	// Replication is a replication state of the instance. It is nil if the
+	// instance is not connected.
+	Replication *Replication
 	// InstanceCtx is an instance application context. It is configured if
 	// InstanceCtxFound == true.
 	InstanceCtx running.InstanceCtx
@@ -0,0 +1,501 @@
+package replicaset
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/exp/slices"
+
+	"github.com/tarantool/tt/cli/cluster"
+	"github.com/tarantool/tt/cli/cmdcontext"
+	"github.com/tarantool/tt/cli/config"
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/running"
+	"github.com/tarantool/tt/cli/util"
+	libcluster "github.com/tarantool/tt/lib/cluster"
+)
+
+var (
+	//go:embed lua/cconfig/get_joined_body.lua
+	cconfigGetJoinedBody string
+
+	getInstanceUUIDBody = "return box.info.uuid"
+
+	deleteClusterMemberBody = "box.space._cluster.index.uuid:delete({...})"
+)
+
+// JoinDefaultTimeout is a default timeout for an instance to join in seconds.
+const JoinDefaultTimeout = 60
+
+// joinPollInterval is an interval between checks of the joining instance.
+var joinPollInterval = 100 * time.Millisecond
+
+// JoinCtx describes context to join a new instance into a replicaset.
+type JoinCtx struct {
+	// AppName is an application name the instance joins to.
+	AppName string
+	// InstName is a name of the new instance.
+	InstName string
+	// ReplicasetName is a name of the replicaset to join.
+	ReplicasetName string
+	// URI is an iproto listen URI of the new instance. The URI is inherited
+	// from the cluster config if it is empty.
+	URI string
+	// DryRun is true if the config changes should be shown only.
+	DryRun bool
+	// Publishers is data publisher factory.
+	Publishers libcluster.DataPublisherFactory
+	// Collectors is data collector factory.
+	Collectors libcluster.DataCollectorFactory
+	// Timeout is a timeout of each joining step in seconds.
+	Timeout int
+}
+
+// Join adds a new instance into a replicaset of an application with the
+// centralized config: it patches the local cluster config, adds the instance
+// into the instances config, starts the instance and waits until it joins
+// and catches up with the master. The changes are rolled back if the instance
+// fails to start or join: the instance is stopped and removed from the
+// replicaset and its data directories are removed. A remote cluster config
+// source is not supported.
+func Join(cmdCtx cmdcontext.CmdCtx, cliOpts config.CliOpts, ctx JoinCtx) error {
+	apps, err := running.CollectInstancesForApps([]string{ctx.AppName}, &cliOpts,
+		cmdCtx.Cli.ConfigDir, cmdCtx.Integrity)
+	if err != nil {
+		return fmt.Errorf("cannot collect application instances info: %s", err)
+	}
+	instances := apps[ctx.AppName]
+	if len(instances) == 0 {
+		return fmt.Errorf("no instances found in the application %q", ctx.AppName)
+	}
+	for _, instance := range instances {
+		if instance.InstName == ctx.InstName {
+			return fmt.Errorf("instance %q already exists in the application", ctx.InstName)
+		}
+	}
+	clusterCfgPath := instances[0].ClusterConfigPath
+	if clusterCfgPath == "" {
+		return fmt.Errorf("join is supported only for an application with the centralized config")
+	}
+	remote, err := hasRemoteCConfigSource(ctx.Collectors, clusterCfgPath)
+	if err != nil {
+		return err
+	}
+	if remote {
+		return fmt.Errorf("join is not supported for an application with a remote cluster" +
+			" config source: etcd or a Tarantool config storage")
+	}
+
+	clusterCfg, err := cluster.GetClusterConfig(libcluster.NewCollectorFactory(ctx.Collectors),
+		clusterCfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster config: %w", err)
+	}
+	if _, _, found := libcluster.FindInstance(clusterCfg, ctx.InstName); found {
+		return fmt.Errorf("instance %q already exists in the cluster configuration",
+			ctx.InstName)
+	}
+	groupName, found := libcluster.FindGroupByReplicaset(clusterCfg, ctx.ReplicasetName)
+	if !found {
+		return fmt.Errorf("replicaset %q not found in the cluster configuration",
+			ctx.ReplicasetName)
+	}
+	inst := cconfigInstance{
+		groupName:      groupName,
+		replicasetName: ctx.ReplicasetName,
+		name:           ctx.InstName,
+	}
+	patchFunc := func(config *libcluster.Config) (*libcluster.Config, error) {
+		return patchCConfigJoin(config, inst, ctx.URI)
+	}
+	instancesCfgPath, err := util.GetYamlFileName(
+		filepath.Join(instances[0].AppDir, "instances.yml"), true)
+	if err != nil {
+		return err
+	}
+
+	if ctx.DryRun {
+		diff, err := diffLocalCConfig(clusterCfgPath, ctx.Collectors, patchFunc)
+		if err != nil {
+			return err
+		}
+		fmt.Print(diff)
+		log.Infof("The instance %q would be added to %q", ctx.InstName, instancesCfgPath)
+		return nil
+	}
+
+	if cmdCtx.Cli.TarantoolCli.Executable == "" {
+		return fmt.Errorf("cannot start: tarantool binary is not found")
+	}
+	replicasets, err := NewCConfigApplication(running.RunningCtx{Instances: instances},
+		ctx.Collectors, ctx.Publishers).Discovery(SkipCache)
+	if err != nil {
+		return fmt.Errorf("failed to discovery: %w", err)
+	}
+	var (
+		master *Instance
+		online []running.InstanceCtx
+	)
+	for _, replicaset := range replicasets.Replicasets {
+		for i, instance := range replicaset.Instances {
+			if !instance.InstanceCtxFound {
+				continue
+			}
+			online = append(online, instance.InstanceCtx)
+			if replicaset.Alias == ctx.ReplicasetName && instance.Mode == ModeRW {
+				master = &replicaset.Instances[i]
+			}
+		}
+	}
+	if master == nil {
+		return fmt.Errorf("no online master found in the replicaset %q", ctx.ReplicasetName)
+	}
+
+	changes := joinChanges{
+		clusterCfgPath:   clusterCfgPath,
+		instancesCfgPath: instancesCfgPath,
+		master:           MakeInstanceEvalFunc(master.InstanceCtx),
+		collectors:       ctx.Collectors,
+		publishers:       ctx.Publishers,
+	}
+	if err := changes.apply(ctx.InstName, patchFunc); err != nil {
+		return changes.rollback(err)
+	}
+
+	instCtx, err := findJoinedInstance(cmdCtx, cliOpts, ctx)
+	if err != nil {
+		return changes.rollback(err)
+	}
+	changes.dirs, err = missingInstanceDirs(instCtx)
+	if err != nil {
+		return changes.rollback(err)
+	}
+	if err := startJoinedInstance(cmdCtx, instCtx); err != nil {
+		return changes.rollback(err)
+	}
+	changes.instance = &instCtx
+
+	log.Infof("Wait until %q joins the replicaset %q", ctx.InstName, ctx.ReplicasetName)
+	evaler := MakeInstanceEvalFunc(instCtx)
+	if err := waitCConfigJoined(evaler, ctx.Timeout); err != nil {
+		return changes.rollback(
+			fmt.Errorf("instance %q has not joined the replicaset: %w", ctx.InstName, err))
+	}
+	if changes.uuid, err = getInstanceUUID(evaler); err != nil {
+		return changes.rollback(
+			fmt.Errorf("failed to get UUID of %q: %w", ctx.InstName, err))
+	}
+	vclock, err := getVClock(MakeInstanceEvalFunc(master.InstanceCtx))
+	if err != nil {
+		return changes.rollback(
+			fmt.Errorf("failed to get vclock of %q: %w", master.Alias, err))
+	}
+	log.Infof("Wait until %q catches up with vclock %s", ctx.InstName, FormatVClock(vclock))
+	if err := waitVClock(evaler, vclock, ctx.Timeout); err != nil {
+		return changes.rollback(fmt.Errorf("instance %q has not caught up with the master: %w",
+			ctx.InstName, err))
+	}
+
+	log.Info("Reload the configuration of the running instances")
+	if err := reloadCConfig(online); err != nil {
+		return err
+	}
+	log.Info("Done.")
+	return nil
+}
+
+// hasRemoteCConfigSource returns true if the local cluster config refers to
+// a remote config source: etcd or a Tarantool config storage.
+func hasRemoteCConfigSource(collectors libcluster.DataCollectorFactory,
+	path string) (bool, error) {
+	collector, err := libcluster.NewCollectorFactory(collectors).NewFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to create a configuration collector: %w", err)
+	}
+	config, err := collector.Collect()
+	if err != nil {
+		return false, fmt.Errorf("failed to collect the cluster config: %w", err)
+	}
+	clusterCfg, err := libcluster.MakeClusterConfig(config)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse the cluster config: %w", err)
+	}
+	return len(clusterCfg.Config.Etcd.Endpoints) > 0 ||
+		len(clusterCfg.Config.Storage.Endpoints) > 0, nil
+}
+
+// joinChanges describes changes of the local configuration made by a join.
+// It keeps the original content to roll back the changes on a failure.
+type joinChanges struct {
+	// clusterCfgPath is a path of the local cluster config.
+	clusterCfgPath string
+	// clusterCfg is an original cluster config, nil if it is not patched.
+	clusterCfg []byte
+	// instancesCfgPath is a path of the instances config.
+	instancesCfgPath string
+	// instancesCfg is an original content of the instances config, nil if
+	// it is not changed.
+	instancesCfg []byte
+	// instancesCfgMode is a file mode of the instances config.
+	instancesCfgMode os.FileMode
+	// instance is the started instance, nil if it is not started.
+	instance *running.InstanceCtx
+	// dirs are directories of the instance created on start.
+	dirs []string
+	// uuid is a UUID of the joined instance, empty if it has not joined.
+	uuid string
+	// master evaluates requests on the master of the replicaset.
+	master connector.Evaler
+	// collectors is data collector factory.
+	collectors libcluster.DataCollectorFactory
+	// publishers is data publisher factory.
+	publishers libcluster.DataPublisherFactory
+}
+
+// apply patches the local cluster config and adds the instance into the
+// instances config.
+func (changes *joinChanges) apply(instName string,
+	patchFunc func(*libcluster.Config) (*libcluster.Config, error)) error {
+	info, err := os.Stat(changes.instancesCfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the instances config: %w", err)
+	}
+	instancesCfg, err := os.ReadFile(changes.instancesCfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to read the instances config: %w", err)
+	}
+
+	log.Infof("Patch the cluster config %q", changes.clusterCfgPath)
+	var clusterCfg []byte
+	err = patchLocalCConfig(changes.clusterCfgPath, changes.collectors, changes.publishers,
+		func(config *libcluster.Config) (*libcluster.Config, error) {
+			clusterCfg = []byte(config.String())
+			return patchFunc(config)
+		})
+	if err != nil {
+		return err
+	}
+	changes.clusterCfg = clusterCfg
+
+	log.Infof("Add the instance to %q", changes.instancesCfgPath)
+	changes.instancesCfg, changes.instancesCfgMode = instancesCfg, info.Mode().Perm()
+	return appendInstancesConfig(changes.instancesCfgPath, instName)
+}
+
+// rollback stops the started instance, removes it from the replicaset and
+// restores the local configuration. The created directories of the instance
+// are removed if it is stopped, a log of the instance is kept. It returns
+// the error of the join with errors of the rollback, if any.
+func (changes *joinChanges) rollback(joinErr error) error {
+	errs := []error{joinErr}
+	stopped := true
+	if changes.instance != nil {
+		if err := running.Stop(changes.instance); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop the instance: %w", err))
+			stopped = false
+		}
+	}
+	if changes.uuid != "" {
+		_, err := changes.master.Eval(deleteClusterMemberBody, []any{changes.uuid},
+			connector.RequestOpts{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to remove the instance from the"+
+				" replicaset, run on the master: box.space._cluster.index.uuid:delete({%q}):"+
+				" %w", changes.uuid, err))
+		}
+	}
+	if changes.instancesCfg != nil {
+		err := os.WriteFile(changes.instancesCfgPath, changes.instancesCfg,
+			changes.instancesCfgMode)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore the instances config: %w", err))
+		}
+	}
+	if changes.clusterCfg != nil {
+		err := patchLocalCConfig(changes.clusterCfgPath, changes.collectors,
+			changes.publishers, func(*libcluster.Config) (*libcluster.Config, error) {
+				return libcluster.NewYamlCollector(changes.clusterCfg).Collect()
+			})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore the cluster config: %w", err))
+		}
+	}
+	if stopped {
+		for _, dir := range changes.dirs {
+			if err := os.RemoveAll(dir); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove %q: %w", dir, err))
+			}
+		}
+	}
+	if changes.instance != nil && changes.instance.Log != "" {
+		log.Infof("The log of the instance is kept: %s", changes.instance.Log)
+	}
+	if len(errs) == 1 && changes.clusterCfg != nil {
+		log.Info("The changes are rolled back")
+	}
+	return errors.Join(errs...)
+}
+
+// findJoinedInstance returns the context of the new instance.
+func findJoinedInstance(cmdCtx cmdcontext.CmdCtx, cliOpts config.CliOpts,
+	ctx JoinCtx) (running.InstanceCtx, error) {
+	apps, err := running.CollectInstancesForApps([]string{ctx.AppName}, &cliOpts,
+		cmdCtx.Cli.ConfigDir, cmdCtx.Integrity)
+	if err != nil {
+		return running.InstanceCtx{},
+			fmt.Errorf("cannot collect application instances info: %s", err)
+	}
+	for _, instCtx := range apps[ctx.AppName] {
+		if instCtx.InstName == ctx.InstName {
+			return instCtx, nil
+		}
+	}
+	return running.InstanceCtx{}, fmt.Errorf("instance %q is not found", ctx.InstName)
+}
+
+// missingInstanceDirs returns the data and run directories of the instance
+// which do not exist yet. They are created on start.
+func missingInstanceDirs(instCtx running.InstanceCtx) ([]string, error) {
+	var dirs []string
+	for _, dir := range []string{instCtx.WalDir, instCtx.MemtxDir, instCtx.VinylDir,
+		instCtx.RunDir} {
+		if dir == "" || slices.Contains(dirs, dir) {
+			continue
+		}
+		_, err := os.Stat(dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			dirs = append(dirs, dir)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to check the instance directory: %w", err)
+		}
+	}
+	return dirs, nil
+}
+
+// startJoinedInstance starts the new instance under a watchdog. The instance
+// directories are created on start.
+func startJoinedInstance(cmdCtx cmdcontext.CmdCtx, instCtx running.InstanceCtx) error {
+	log.Infof("Start the instance %q", instCtx.InstName)
+	ttBin, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if err := running.StartWatchdog(&cmdCtx, ttBin, instCtx, []string{}); err != nil {
+		return fmt.Errorf("failed to start the instance: %s", err)
+	}
+	return nil
+}
+
+// getInstanceUUID returns a UUID of the instance.
+func getInstanceUUID(evaler connector.Evaler) (string, error) {
+	data, err := evaler.Eval(getInstanceUUIDBody, []any{}, connector.RequestOpts{})
+	if err != nil {
+		return "", err
+	}
+	if len(data) != 1 {
+		return "", fmt.Errorf("unexpected response: %v", data)
+	}
+	uuid, ok := data[0].(string)
+	if !ok || uuid == "" {
+		return "", fmt.Errorf("unexpected response: %v", data)
+	}
+	return uuid, nil
+}
+
+// waitCConfigJoined waits until the instance is running and follows an
+// upstream.
+func waitCConfigJoined(evaler connector.Evaler, timeout int) error {
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		data, err := evaler.Eval(cconfigGetJoinedBody, []any{}, connector.RequestOpts{})
+		if err == nil {
+			if len(data) != 1 {
+				return fmt.Errorf("unexpected response: %v", data)
+			}
+			if joined, ok := data[0].(bool); ok && joined {
+				return nil
+			}
+			err = errors.New("the instance is not running or follows no upstream")
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout: %w", err)
+		}
+		time.Sleep(joinPollInterval)
+	}
+}
+
+// appendInstancesConfig adds an instance into the instances config.
+func appendInstancesConfig(path, instName string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the instances config: %w", err)
+	}
+	entry := instName + ":\n"
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		entry = "\n" + entry
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open the instances config: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.WriteString(entry); err != nil {
+		return fmt.Errorf("failed to update the instances config: %w", err)
+	}
+	return nil
+}
+
+// diffLocalCConfig returns a unified diff between the local cluster config
+// and the patched one.
+func diffLocalCConfig(path string, collectors libcluster.DataCollectorFactory,
+	patchFunc func(*libcluster.Config) (*libcluster.Config, error)) (string, error) {
+	collector, err := libcluster.NewCollectorFactory(collectors).NewFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create a configuration collector: %w", err)
+	}
+	config, err := collector.Collect()
+	if err != nil {
+		return "", fmt.Errorf("failed to collect a configuration to update: %w", err)
+	}
+	original := config.String()
+	patched, err := patchFunc(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to patch config: %w", err)
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(strings.TrimSuffix(original, "\n")),
+		B:        difflib.SplitLines(strings.TrimSuffix(patched.String(), "\n")),
+		FromFile: path,
+		ToFile:   path,
+		Context:  3,
+	})
+}
+
+// patchCConfigJoin patches the config to add a new instance into a
+// replicaset. It sets up the iproto listen URI if it is specified:
+// instance.iproto.listen = [{uri: URI}]
+func patchCConfigJoin(config *libcluster.Config,
+	inst cconfigInstance, uri string) (*libcluster.Config, error) {
+	path := []string{"groups", inst.groupName, "replicasets", inst.replicasetName,
+		"instances", inst.name}
+	var value any = map[any]any{}
+	if uri != "" {
+		value = map[any]any{
+			"iproto": map[any]any{
+				"listen": []any{map[any]any{"uri": uri}},
+			},
+		}
+	}
+	if err := config.Set(path, value); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
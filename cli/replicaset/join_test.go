@@ -0,0 +1,248 @@
+package replicaset
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/connector"
+	"github.com/tarantool/tt/cli/running"
+	libcluster "github.com/tarantool/tt/lib/cluster"
+)
+
+const joinTestConfig = `groups:
+  g:
+    replicasets:
+      r:
+        instances:
+          a:
+            database:
+              mode: rw
+`
+
+func TestPatchCConfigJoin(t *testing.T) {
+	inst := cconfigInstance{groupName: "g", replicasetName: "r", name: "b"}
+	cases := []struct {
+		name     string
+		uri      string
+		expected string
+	}{
+		{
+			name: "no uri",
+			expected: `groups:
+  g:
+    replicasets:
+      r:
+        instances:
+          a:
+            database:
+              mode: rw
+          b: {}
+`,
+		},
+		{
+			name: "uri",
+			uri:  "localhost:3302",
+			expected: `groups:
+  g:
+    replicasets:
+      r:
+        instances:
+          a:
+            database:
+              mode: rw
+          b:
+            iproto:
+              listen:
+                - uri: localhost:3302
+`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config, err := libcluster.NewYamlCollector([]byte(joinTestConfig)).Collect()
+			require.NoError(t, err)
+			patched, err := patchCConfigJoin(config, inst, c.uri)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, patched.String())
+		})
+	}
+}
+
+func TestDiffLocalCConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(joinTestConfig), 0644))
+	inst := cconfigInstance{groupName: "g", replicasetName: "r", name: "b"}
+
+	diff, err := diffLocalCConfig(path, libcluster.NewDataCollectorFactory(),
+		func(config *libcluster.Config) (*libcluster.Config, error) {
+			return patchCConfigJoin(config, inst, "")
+		})
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf(`--- %[1]s
++++ %[1]s
+@@ -6,3 +6,4 @@
+           a:
+             database:
+               mode: rw
++          b: {}
+`, path), diff)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, joinTestConfig, string(content))
+}
+
+func TestHasRemoteCConfigSource(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   string
+		expected bool
+	}{
+		{"local", joinTestConfig, false},
+		{"etcd", "config:\n  etcd:\n    endpoints:\n    - http://localhost:2379\n", true},
+		{"storage", "config:\n  storage:\n    endpoints:\n    - uri: localhost:3301\n", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(c.config), 0644))
+			remote, err := hasRemoteCConfigSource(libcluster.NewDataCollectorFactory(), path)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, remote)
+		})
+	}
+}
+
+func TestJoinChanges_rollback(t *testing.T) {
+	dir := t.TempDir()
+	clusterCfgPath := filepath.Join(dir, "config.yaml")
+	instancesCfgPath := filepath.Join(dir, "instances.yml")
+	require.NoError(t, os.WriteFile(clusterCfgPath, []byte(joinTestConfig), 0644))
+	require.NoError(t, os.WriteFile(instancesCfgPath, []byte("a:\n"), 0644))
+
+	changes := joinChanges{
+		clusterCfgPath:   clusterCfgPath,
+		instancesCfgPath: instancesCfgPath,
+		collectors:       libcluster.NewDataCollectorFactory(),
+		publishers:       libcluster.NewDataPublisherFactory(),
+	}
+	inst := cconfigInstance{groupName: "g", replicasetName: "r", name: "b"}
+	require.NoError(t, changes.apply("b",
+		func(config *libcluster.Config) (*libcluster.Config, error) {
+			return patchCConfigJoin(config, inst, "")
+		}))
+	content, err := os.ReadFile(instancesCfgPath)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\nb:\n", string(content))
+	content, err = os.ReadFile(clusterCfgPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "b: {}")
+
+	// The instance has joined and created its directories.
+	dataDir := filepath.Join(dir, "var", "lib", "b")
+	runDir := filepath.Join(dir, "var", "run", "b")
+	changes.dirs, err = missingInstanceDirs(running.InstanceCtx{
+		WalDir: dataDir, MemtxDir: dataDir, VinylDir: dataDir, RunDir: runDir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{dataDir, runDir}, changes.dirs)
+	require.NoError(t, os.MkdirAll(dataDir, 0755))
+	require.NoError(t, os.MkdirAll(runDir, 0755))
+	changes.uuid = "uuid-b"
+	var deleted []any
+	changes.master = EvalFunc(func(expr string, args []any,
+		opts connector.RequestOpts) ([]any, error) {
+		require.Equal(t, deleteClusterMemberBody, expr)
+		deleted = args
+		return []any{}, nil
+	})
+
+	err = changes.rollback(fmt.Errorf("join failed"))
+	assert.EqualError(t, err, "join failed")
+	assert.Equal(t, []any{"uuid-b"}, deleted)
+	assert.NoDirExists(t, dataDir)
+	assert.NoDirExists(t, runDir)
+	content, err = os.ReadFile(instancesCfgPath)
+	require.NoError(t, err)
+	assert.Equal(t, "a:\n", string(content))
+	content, err = os.ReadFile(clusterCfgPath)
+	require.NoError(t, err)
+	assert.Equal(t, joinTestConfig, string(content))
+}
+
+func TestJoinChanges_rollbackClusterError(t *testing.T) {
+	changes := joinChanges{
+		uuid: "uuid-b",
+		master: EvalFunc(func(expr string, args []any,
+			opts connector.RequestOpts) ([]any, error) {
+			return nil, fmt.Errorf("read-only")
+		}),
+	}
+	err := changes.rollback(fmt.Errorf("join failed"))
+	assert.EqualError(t, err, "join failed\nfailed to remove the instance from the"+
+		` replicaset, run on the master: box.space._cluster.index.uuid:delete({"uuid-b"}):`+
+		" read-only")
+}
+
+func TestMissingInstanceDirs(t *testing.T) {
+	dir := t.TempDir()
+	dirs, err := missingInstanceDirs(running.InstanceCtx{
+		WalDir:   filepath.Join(dir, "wal"),
+		MemtxDir: dir,
+		RunDir:   filepath.Join(dir, "wal"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "wal")}, dirs)
+}
+
+func TestAppendInstancesConfig(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"empty", "", "b:\n"},
+		{"newline", "a:\n", "a:\nb:\n"},
+		{"no newline", "a:", "a:\nb:\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "instances.yml")
+			require.NoError(t, os.WriteFile(path, []byte(c.content), 0644))
+			require.NoError(t, appendInstancesConfig(path, "b"))
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, string(content))
+		})
+	}
+}
+
+func TestWaitCConfigJoined(t *testing.T) {
+	joinPollInterval = time.Millisecond
+	responses := []any{fmt.Errorf("connection refused"), false, true}
+	evaler := EvalFunc(func(expr string, args []any, opts connector.RequestOpts) ([]any, error) {
+		require.Equal(t, cconfigGetJoinedBody, expr)
+		response := responses[0]
+		responses = responses[1:]
+		if err, ok := response.(error); ok {
+			return nil, err
+		}
+		return []any{response}, nil
+	})
+	require.NoError(t, waitCConfigJoined(evaler, 10))
+	assert.Empty(t, responses)
+
+	evaler = EvalFunc(func(string, []any, connector.RequestOpts) ([]any, error) {
+		return []any{false}, nil
+	})
+	assert.EqualError(t, waitCConfigJoined(evaler, 0),
+		"timeout: the instance is not running or follows no upstream")
+}
@@ -1,5 +1,6 @@
 local rw = false
 local uuid = '00000000-0000-0000-0000-000000000000'
+local replication = nil
 
 if type(box.cfg) ~= 'function' then
     local ok, is_rw = pcall(function()
@@ -8,11 +9,15 @@ if type(box.cfg) ~= 'function' then
     if ok then
         rw = is_rw
     end
-    uuid = box.info().uuid
+    local box_info = box.info()
+    uuid = box_info.uuid
+
+    replication = get_replication_info(box_info)
 end
 
 
 return {
     uuid = uuid,
     rw   = rw,
+    replication = replication,
 }
@@ -46,4 +46,6 @@ for _, instance in ipairs(box_info.replication) do
     })
 end
 
+replicaset.replication = get_replication_info(box_info)
+
 return replicaset
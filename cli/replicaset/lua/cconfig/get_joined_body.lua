@@ -0,0 +1,11 @@
+local box_info = box.info
+if box_info.status ~= 'running' then
+    return false
+end
+for _, replica in pairs(box_info.replication) do
+    if replica.uuid ~= box_info.uuid and replica.upstream ~= nil and
+        replica.upstream.status == 'follow' then
+        return true
+    end
+end
+return false
@@ -0,0 +1,10 @@
+local election_mode = box.cfg.election_mode
+if election_mode ~= 'candidate' then
+    error(('unexpected election_mode: %q, "candidate" expected'):format(election_mode))
+end
+
+if box.info.election.state ~= 'leader' then
+    error('an instance must be the leader of the replicaset to demote it')
+end
+
+box.cfg{election_mode = 'voter'}
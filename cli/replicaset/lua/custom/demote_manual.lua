@@ -0,0 +1,5 @@
+if box.info.election.state ~= 'leader' then
+    error('an instance must be the leader of the replicaset to demote it')
+end
+
+box.ctl.demote()
@@ -51,4 +51,6 @@ for _, instance in ipairs(box_info.replication) do
     })
 end
 
+replicaset.replication = get_replication_info(box_info)
+
 return replicaset
@@ -0,0 +1,6 @@
+local election_mode = box.cfg.election_mode
+if election_mode ~= 'candidate' and election_mode ~= 'manual' then
+    error(('unexpected election_mode: %q, ' .. '"candidate" or "manual" expected'):format(election_mode))
+end
+
+box.ctl.promote()
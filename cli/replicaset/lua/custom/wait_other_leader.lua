@@ -0,0 +1,10 @@
+local timeout = ...
+local fiber = require('fiber')
+
+local deadline = fiber.clock() + timeout
+while box.info.election.leader == 0 or box.info.election.leader == box.info.id do
+    if fiber.clock() > deadline then
+        error('timeout: no other leader is elected')
+    end
+    fiber.sleep(0.1)
+end
@@ -0,0 +1,29 @@
+local function get_replication_info(box_info)
+    local replication = {
+        vclock = setmetatable({}, {__serialize = 'map'}),
+        upstreams = {},
+        downstreams = {},
+    }
+    if box_info.election ~= nil then
+        replication.electionterm = box_info.election.term
+        replication.electionstate = box_info.election.state
+    end
+    for id, lsn in pairs(box_info.vclock) do
+        replication.vclock[id] = lsn
+    end
+    for _, instance in pairs(box_info.replication) do
+        for _, direction in ipairs({'upstream', 'downstream'}) do
+            local link = instance[direction]
+            if link ~= nil then
+                table.insert(replication[direction .. 's'], {
+                    uuid    = instance.uuid,
+                    status  = link.status,
+                    lag     = link.lag,
+                    idle    = link.idle,
+                    message = link.message,
+                })
+            end
+        end
+    end
+    return replication
+end
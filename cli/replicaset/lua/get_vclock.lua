@@ -0,0 +1,7 @@
+local vclock = setmetatable({}, {__serialize = 'map'})
+for id, lsn in pairs(box.info.vclock) do
+    if id ~= 0 then
+        vclock[id] = lsn
+    end
+end
+return vclock
@@ -0,0 +1,66 @@
+local ok, vshard = pcall(require, 'vshard')
+if not ok then
+    return {}
+end
+
+local box_info = box.info()
+local replicaset_info = box_info.replicaset
+if replicaset_info == nil then
+    replicaset_info = box_info.cluster
+end
+local info = {
+    uuid = box_info.uuid,
+    replicasetuuid = replicaset_info.uuid,
+    rw = box_info.ro == false,
+}
+
+local function get_alerts(alerts)
+    local ret = {}
+    for _, alert in ipairs(alerts or {}) do
+        table.insert(ret, {code = alert[1], message = alert[2]})
+    end
+    return ret
+end
+
+local ok, storage_info = pcall(vshard.storage.info, {with_services = true})
+if ok and storage_info ~= nil then
+    local internal = vshard.storage.internal
+    local storage = {
+        bucket = storage_info.bucket,
+        alerts = get_alerts(storage_info.alerts),
+        rebalancer = internal.rebalancer_fiber ~= nil,
+        bucketcount = internal.total_bucket_count,
+        threshold = internal.rebalancer_disbalance_threshold,
+    }
+    if internal.this_replicaset ~= nil then
+        storage.weight = internal.this_replicaset.weight
+    end
+    if storage_info.services ~= nil and storage_info.services.rebalancer ~= nil then
+        local service = storage_info.services.rebalancer
+        storage.rebalancerstate = service.activity
+        storage.rebalancererror = service.error
+    end
+    info.storage = storage
+end
+
+local router = vshard.router.static
+if router == nil then
+    local ok, cartridge = pcall(require, 'cartridge')
+    if ok and cartridge.service_get ~= nil then
+        local service = cartridge.service_get('vshard-router')
+        if service ~= nil then
+            router = service.get()
+        end
+    end
+end
+if router ~= nil then
+    local ok, router_info = pcall(router.info, router)
+    if ok then
+        info.router = {
+            bucket = router_info.bucket,
+            alerts = get_alerts(router_info.alerts),
+        }
+    end
+end
+
+return info
@@ -0,0 +1,2 @@
+local read_only = ...
+box.cfg{read_only = read_only}
@@ -12,6 +12,9 @@ type PromoteCtx struct {
 	// Timeout is a timeout for promoting waitings in seconds.
 	// Keep int, because it can be passed to the target instance.
 	Timeout int
+	// Switchover is true when the current master should be made read-only
+	// and caught up by the instance before the promotion.
+	Switchover bool
 }
 
 // Promoter is an interface to promote an instance in the replicaset.
@@ -33,3 +36,16 @@ func newErrPromoteByAppNotSupported(orchestrator Orchestrator) error {
 	return fmt.Errorf("promote is not supported for an application by %q orchestrator",
 		orchestrator)
 }
+
+// newErrSwitchoverByInstanceNotSupported creates a new error that a guarded
+// switchover is not supported by the orchestrator for a single instance.
+func newErrSwitchoverByInstanceNotSupported(orchestrator Orchestrator) error {
+	return fmt.Errorf("switchover is not supported for a single instance by %q orchestrator,"+
+		" specify an instance of an application", orchestrator)
+}
+
+// newErrSwitchoverByFailoverNotSupported creates a new error that a guarded
+// switchover is not supported for the failover.
+func newErrSwitchoverByFailoverNotSupported(failover Failover) error {
+	return fmt.Errorf("switchover is not supported for %q failover", failover)
+}
@@ -0,0 +1,66 @@
+package replicaset
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Replication describes a replication state of an instance as it is seen by
+// the instance itself.
+type Replication struct {
+	// VClock is a vector clock of the instance: a LSN per a replica id.
+	VClock map[uint64]uint64
+	// ElectionTerm is a current election term.
+	ElectionTerm uint64
+	// ElectionState is an election state: leader, follower, candidate or
+	// none. It is empty if elections are not supported.
+	ElectionState string
+	// Upstreams is a list of links the instance replicates from.
+	Upstreams []ReplicationLink
+	// Downstreams is a list of links the instance replicates to.
+	Downstreams []ReplicationLink
+}
+
+// ReplicationLink describes a replication link between an instance and a
+// peer.
+type ReplicationLink struct {
+	// UUID of the peer instance.
+	UUID string
+	// Status of the link: follow, sync, stopped, disconnected etc.
+	Status string
+	// Lag is a replication lag in seconds.
+	Lag float64
+	// Idle is a time in seconds since the last event on the link.
+	Idle float64
+	// Message is an error message of the link.
+	Message string
+}
+
+// IsBroken returns true if the link does not replicate data.
+func (link ReplicationLink) IsBroken() bool {
+	return link.Status != "follow" && link.Status != "sync"
+}
+
+// IsLagging returns true if the link lag exceeds the threshold in seconds.
+func (link ReplicationLink) IsLagging(threshold float64) bool {
+	return link.Lag > threshold
+}
+
+// FormatVClock returns a string representation of a vclock sorted by replica
+// ids.
+func FormatVClock(vclock map[uint64]uint64) string {
+	ids := make([]uint64, 0, len(vclock))
+	for id := range vclock {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	components := make([]string, 0, len(ids))
+	for _, id := range ids {
+		components = append(components, fmt.Sprintf("%d: %d", id, vclock[id]))
+	}
+	return "{" + strings.Join(components, ", ") + "}"
+}
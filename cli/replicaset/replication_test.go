@@ -0,0 +1,37 @@
+package replicaset_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tarantool/tt/cli/replicaset"
+)
+
+func TestReplicationLink_IsBroken(t *testing.T) {
+	cases := []struct {
+		Status   string
+		Expected bool
+	}{
+		{"follow", false},
+		{"sync", false},
+		{"stopped", true},
+		{"disconnected", true},
+		{"connect", true},
+		{"", true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.Status, func(t *testing.T) {
+			link := replicaset.ReplicationLink{Status: tc.Status}
+			assert.Equal(t, tc.Expected, link.IsBroken())
+		})
+	}
+}
+
+func TestReplicationLink_IsLagging(t *testing.T) {
+	link := replicaset.ReplicationLink{Status: "follow", Lag: 1.5}
+	assert.True(t, link.IsLagging(1))
+	assert.False(t, link.IsLagging(1.5))
+	assert.False(t, link.IsLagging(2))
+}
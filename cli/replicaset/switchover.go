@@ -0,0 +1,241 @@
+package replicaset
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apex/log"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+var (
+	//go:embed lua/set_read_only.lua
+	setReadOnlyBody string
+
+	//go:embed lua/get_vclock.lua
+	getVClockBody string
+
+	getReadOnlyBody = "return box.info.ro"
+)
+
+// switchoverPollInterval is an interval between checks of the candidate
+// vclock.
+var switchoverPollInterval = 100 * time.Millisecond
+
+// switchoverCtx describes a guarded switchover in a replicaset.
+type switchoverCtx struct {
+	// Replicaset is the replicaset of the candidate.
+	Replicaset Replicaset
+	// Candidate is an instance to promote.
+	Candidate Instance
+	// Timeout is a timeout of each step in seconds.
+	Timeout int
+	// Evaler returns an evaler for the instance.
+	Evaler func(Instance) connector.Evaler
+	// Promote promotes the candidate.
+	Promote func() error
+	// Rollback restores the previous masters after a failed promotion.
+	Rollback func(masters []Instance) error
+}
+
+// switchover makes the current masters read-only, waits until the candidate
+// vclock catches up with the masters vclocks, promotes the candidate and
+// verifies that it is the only writable instance in the replicaset. Any
+// failure rolls back the masters.
+func switchover(ctx switchoverCtx) error {
+	var masters []Instance
+	for _, instance := range ctx.Replicaset.Instances {
+		if instance.UUID != ctx.Candidate.UUID && instance.Mode == ModeRW {
+			if !instance.InstanceCtxFound {
+				return fmt.Errorf("master %q should be online", instance.Alias)
+			}
+			masters = append(masters, instance)
+		}
+	}
+	if len(masters) == 0 {
+		log.Warn("No other writable instance found, nothing to catch up")
+	}
+
+	for _, master := range masters {
+		log.Infof("Make the master %q read-only", master.Alias)
+		err := setReadOnly(ctx.Evaler(master), true)
+		if err == nil {
+			err = waitRO(ctx.Evaler(master), ctx.Timeout)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to make %q read-only: %w", master.Alias, err)
+			return errors.Join(err, restoreMasters(ctx, masters))
+		}
+	}
+
+	if err := catchUpMasters(ctx, masters); err != nil {
+		return errors.Join(err, restoreMasters(ctx, masters))
+	}
+
+	log.Infof("Promote %q", ctx.Candidate.Alias)
+	err := ctx.Promote()
+	if err == nil {
+		err = waitRW(ctx.Evaler(ctx.Candidate), ctx.Timeout)
+	}
+	if err == nil {
+		err = checkSingleRW(ctx)
+	}
+	if err != nil {
+		log.Warnf("Switchover failed, roll back: %s", err)
+		return errors.Join(err, ctx.Rollback(masters), restoreMasters(ctx, masters))
+	}
+	return nil
+}
+
+// catchUpMasters waits until the candidate vclock catches up with the masters
+// vclocks. Transactions started before the masters became read-only could
+// still be committed, so the masters vclocks are read again after the catch
+// up until they stop advancing.
+func catchUpMasters(ctx switchoverCtx, masters []Instance) error {
+	deadline := time.Now().Add(time.Duration(ctx.Timeout) * time.Second)
+	vclock, err := getMastersVClock(ctx, masters)
+	if err != nil {
+		return err
+	}
+	for {
+		log.Infof("Wait until %q catches up with vclock %s", ctx.Candidate.Alias,
+			FormatVClock(vclock))
+		err := waitVClock(ctx.Evaler(ctx.Candidate), vclock, ctx.Timeout)
+		if err != nil {
+			return fmt.Errorf("instance %q has not caught up with the masters: %w",
+				ctx.Candidate.Alias, err)
+		}
+
+		next, err := getMastersVClock(ctx, masters)
+		if err != nil {
+			return err
+		}
+		if !isVClockAdvanced(vclock, next) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the masters vclock keeps advancing: %s", FormatVClock(next))
+		}
+		log.Infof("The masters vclock has advanced after the catch up")
+		vclock = next
+	}
+}
+
+// getMastersVClock returns the maximum vclock of the masters.
+func getMastersVClock(ctx switchoverCtx, masters []Instance) (map[uint64]uint64, error) {
+	vclock := map[uint64]uint64{}
+	for _, master := range masters {
+		masterVClock, err := getVClock(ctx.Evaler(master))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get vclock of %q: %w", master.Alias, err)
+		}
+		for id, lsn := range masterVClock {
+			if lsn > vclock[id] {
+				vclock[id] = lsn
+			}
+		}
+	}
+	return vclock, nil
+}
+
+// isVClockAdvanced returns true if any component of the next vclock is
+// greater than the component of the vclock.
+func isVClockAdvanced(vclock, next map[uint64]uint64) bool {
+	for id, lsn := range next {
+		if lsn > vclock[id] {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreMasters makes the masters writable back.
+func restoreMasters(ctx switchoverCtx, masters []Instance) error {
+	var errs []error
+	for _, master := range masters {
+		if err := setReadOnly(ctx.Evaler(master), false); err != nil {
+			errs = append(errs, fmt.Errorf("failed to make %q writable back: %w",
+				master.Alias, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkSingleRW checks that the candidate is the only writable instance
+// among the online instances of the replicaset.
+func checkSingleRW(ctx switchoverCtx) error {
+	var writable []string
+	for _, instance := range ctx.Replicaset.Instances {
+		if instance.UUID == ctx.Candidate.UUID || !instance.InstanceCtxFound {
+			continue
+		}
+		data, err := ctx.Evaler(instance).Eval(getReadOnlyBody, []any{},
+			connector.RequestOpts{})
+		if err != nil {
+			return fmt.Errorf("failed to check mode of %q: %w", instance.Alias, err)
+		}
+		if len(data) != 1 {
+			return fmt.Errorf("unexpected response: %v", data)
+		}
+		if ro, ok := data[0].(bool); !ok || !ro {
+			writable = append(writable, instance.Alias)
+		}
+	}
+	if len(writable) > 0 {
+		return fmt.Errorf("other instances are writable: %s", strings.Join(writable, ", "))
+	}
+	return nil
+}
+
+// setReadOnly sets box.cfg.read_only on the instance.
+func setReadOnly(evaler connector.Evaler, readOnly bool) error {
+	_, err := evaler.Eval(setReadOnlyBody, []any{readOnly}, connector.RequestOpts{})
+	return err
+}
+
+// getVClock returns a vclock of the instance without the local component.
+func getVClock(evaler connector.Evaler) (map[uint64]uint64, error) {
+	data, err := evaler.Eval(getVClockBody, []any{}, connector.RequestOpts{})
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 1 {
+		return nil, fmt.Errorf("unexpected response: %v", data)
+	}
+	vclock := map[uint64]uint64{}
+	if err := mapstructure.Decode(data[0], &vclock); err != nil {
+		return nil, fmt.Errorf("failed to parse a response: %w", err)
+	}
+	return vclock, nil
+}
+
+// waitVClock waits until the instance vclock is greater than or equal to
+// the vclock.
+func waitVClock(evaler connector.Evaler, vclock map[uint64]uint64, timeout int) error {
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		current, err := getVClock(evaler)
+		if err != nil {
+			return err
+		}
+		caughtUp := true
+		for id, lsn := range vclock {
+			if current[id] < lsn {
+				caughtUp = false
+				break
+			}
+		}
+		if caughtUp {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout, the vclock is %s", FormatVClock(current))
+		}
+		time.Sleep(switchoverPollInterval)
+	}
+}
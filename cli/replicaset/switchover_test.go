@@ -0,0 +1,216 @@
+package replicaset
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// switchoverMock simulates instances of a replicaset.
+type switchoverMock struct {
+	ro     map[string]bool
+	vclock map[string]map[uint64]uint64
+	// lagging is a number of vclock requests before the instance catches up.
+	lagging map[string]int
+	master  string
+	// advances is a number of master vclock requests after the first one
+	// which return an advanced vclock.
+	advances int
+	// masterReads is a number of master vclock requests.
+	masterReads int
+}
+
+func (m *switchoverMock) evaler(instance Instance) connector.Evaler {
+	alias := instance.Alias
+	return EvalFunc(func(expr string, args []any, opts connector.RequestOpts) ([]any, error) {
+		switch expr {
+		case setReadOnlyBody:
+			m.ro[alias] = args[0].(bool)
+		case waitROBody:
+			if !m.ro[alias] {
+				return nil, fmt.Errorf("timed out")
+			}
+		case waitRWBody:
+			if m.ro[alias] {
+				return nil, fmt.Errorf("timed out")
+			}
+		case getReadOnlyBody:
+			return []any{m.ro[alias]}, nil
+		case getVClockBody:
+			if alias == m.master {
+				m.masterReads++
+				if m.masterReads > 1 && m.advances > 0 {
+					m.advances--
+					m.vclock[alias][1]++
+				}
+			}
+			if m.lagging[alias] > 0 {
+				m.lagging[alias]--
+			} else {
+				for id, lsn := range m.vclock[m.master] {
+					m.vclock[alias][id] = lsn
+				}
+			}
+			vclock := map[any]any{}
+			for id, lsn := range m.vclock[alias] {
+				vclock[id] = lsn
+			}
+			return []any{vclock}, nil
+		default:
+			return nil, fmt.Errorf("unexpected expression: %s", expr)
+		}
+		return []any{}, nil
+	})
+}
+
+func newSwitchoverMock(lagging int) *switchoverMock {
+	return &switchoverMock{
+		ro: map[string]bool{"a": false, "b": true, "c": true},
+		vclock: map[string]map[uint64]uint64{
+			"a": {1: 10, 2: 5},
+			"b": {1: 8, 2: 5},
+			"c": {1: 10, 2: 5},
+		},
+		lagging: map[string]int{"b": lagging},
+		master:  "a",
+	}
+}
+
+func TestSwitchover(t *testing.T) {
+	switchoverPollInterval = time.Millisecond
+	replicaset := Replicaset{
+		Instances: []Instance{
+			{Alias: "a", UUID: "a", Mode: ModeRW, InstanceCtxFound: true},
+			{Alias: "b", UUID: "b", Mode: ModeRead, InstanceCtxFound: true},
+			{Alias: "c", UUID: "c", Mode: ModeRead, InstanceCtxFound: true},
+		},
+	}
+
+	cases := []struct {
+		name       string
+		lagging    int
+		advances   int
+		timeout    int
+		promote    func(m *switchoverMock) error
+		err        string
+		promoted   bool
+		rolledBack bool
+		ro         map[string]bool
+	}{
+		{
+			name:    "success",
+			lagging: 2,
+			timeout: 10,
+			promote: func(m *switchoverMock) error {
+				m.ro["b"] = false
+				return nil
+			},
+			promoted: true,
+			ro:       map[string]bool{"a": true, "b": false, "c": true},
+		},
+		{
+			name:     "master advances",
+			advances: 2,
+			timeout:  10,
+			promote: func(m *switchoverMock) error {
+				// The candidate has the transactions committed after the
+				// first vclock read.
+				assert.Equal(t, map[uint64]uint64{1: 12, 2: 5}, m.vclock["b"])
+				assert.Equal(t, 4, m.masterReads)
+				m.ro["b"] = false
+				return nil
+			},
+			promoted: true,
+			ro:       map[string]bool{"a": true, "b": false, "c": true},
+		},
+		{
+			name:    "catch up timeout",
+			lagging: 1000,
+			timeout: 0,
+			err: `instance "b" has not caught up with the masters:` +
+				` timeout, the vclock is {1: 8, 2: 5}`,
+			ro: map[string]bool{"a": false, "b": true, "c": true},
+		},
+		{
+			name:    "promote error",
+			timeout: 10,
+			promote: func(m *switchoverMock) error {
+				return fmt.Errorf("failed to publish")
+			},
+			err:        "failed to publish",
+			promoted:   true,
+			rolledBack: true,
+			ro:         map[string]bool{"a": false, "b": true, "c": true},
+		},
+		{
+			name:    "several writable",
+			timeout: 10,
+			promote: func(m *switchoverMock) error {
+				m.ro["b"] = false
+				m.ro["c"] = false
+				return nil
+			},
+			err:        "other instances are writable: c",
+			promoted:   true,
+			rolledBack: true,
+			ro:         map[string]bool{"a": false, "b": true, "c": true},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newSwitchoverMock(c.lagging)
+			m.advances = c.advances
+			promoted, rolledBack := false, false
+			err := switchover(switchoverCtx{
+				Replicaset: replicaset,
+				Candidate:  replicaset.Instances[1],
+				Timeout:    c.timeout,
+				Evaler:     m.evaler,
+				Promote: func() error {
+					promoted = true
+					return c.promote(m)
+				},
+				Rollback: func(masters []Instance) error {
+					rolledBack = true
+					require.Len(t, masters, 1)
+					assert.Equal(t, "a", masters[0].Alias)
+					m.ro["b"], m.ro["c"] = true, true
+					return nil
+				},
+			})
+			if c.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, c.err)
+			}
+			assert.Equal(t, c.promoted, promoted)
+			assert.Equal(t, c.rolledBack, rolledBack)
+			assert.Equal(t, c.ro, m.ro)
+		})
+	}
+}
+
+func TestSwitchover_offlineMaster(t *testing.T) {
+	replicaset := Replicaset{
+		Instances: []Instance{
+			{Alias: "a", UUID: "a", Mode: ModeRW},
+			{Alias: "b", UUID: "b", Mode: ModeRead, InstanceCtxFound: true},
+		},
+	}
+	err := switchover(switchoverCtx{
+		Replicaset: replicaset,
+		Candidate:  replicaset.Instances[1],
+	})
+	assert.EqualError(t, err, `master "a" should be online`)
+}
+
+func TestFormatVClock(t *testing.T) {
+	assert.Equal(t, "{}", FormatVClock(nil))
+	assert.Equal(t, "{1: 10, 2: 5, 10: 1}", FormatVClock(map[uint64]uint64{10: 1, 2: 5, 1: 10}))
+}
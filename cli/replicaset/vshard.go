@@ -1,6 +1,18 @@
 package replicaset
 
-import "fmt"
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+//go:embed lua/get_vshard_info_body.lua
+var getVShardInfoBody string
 
 // VShardBootstrapCtx describes context to bootstrap vshard.
 type VShardBootstrapCtx struct {
@@ -28,3 +40,332 @@ func newErrBootstrapVShardByAppNotSupported(orchestrator Orchestrator) error {
 	return fmt.Errorf("bootstrap vshard is not supported for an application by %q orchestrator",
 		orchestrator)
 }
+
+// VShardBuckets describes bucket counters of a storage.
+type VShardBuckets struct {
+	// Active is a number of active buckets.
+	Active uint64
+	// Pinned is a number of pinned buckets.
+	Pinned uint64
+	// Sending is a number of buckets being sent to another storage.
+	Sending uint64
+	// Receiving is a number of buckets being received from another storage.
+	Receiving uint64
+	// Garbage is a number of buckets waiting for the garbage collection.
+	Garbage uint64
+	// Total is a total number of buckets on the storage.
+	Total uint64
+}
+
+// VShardRouterBuckets describes bucket counters as they are seen by a router.
+type VShardRouterBuckets struct {
+	// AvailableRW is a number of buckets available for read and write requests.
+	AvailableRW uint64 `mapstructure:"available_rw"`
+	// AvailableRO is a number of buckets available for read requests only.
+	AvailableRO uint64 `mapstructure:"available_ro"`
+	// Unreachable is a number of buckets with unavailable replicasets.
+	Unreachable uint64
+	// Unknown is a number of buckets with unknown locations.
+	Unknown uint64
+}
+
+// VShardAlert describes a vshard alert.
+type VShardAlert struct {
+	// Code is a code of the alert, for example, UNREACHABLE_MASTER.
+	Code string
+	// Message is a human-readable description of the alert.
+	Message string
+}
+
+// VShardStorage describes a storage replicaset.
+type VShardStorage struct {
+	// Alias is a replicaset alias.
+	Alias string
+	// UUID is a replicaset UUID.
+	UUID string
+	// Master is an alias of the instance that reports the buckets.
+	Master string
+	// Weight is a replicaset weight.
+	Weight float64
+	// Buckets are bucket counters of the replicaset.
+	Buckets VShardBuckets
+	// Alerts is a list of alerts of the replicaset instances.
+	Alerts []VShardAlert
+}
+
+// VShardRouter describes a router.
+type VShardRouter struct {
+	// Alias is an instance alias.
+	Alias string
+	// Buckets are bucket counters seen by the router.
+	Buckets VShardRouterBuckets
+	// Alerts is a list of alerts of the router.
+	Alerts []VShardAlert
+}
+
+// VShardStatus describes a vshard state of a cluster.
+type VShardStatus struct {
+	// Storages is a list of storage replicasets.
+	Storages []VShardStorage
+	// Routers is a list of routers.
+	Routers []VShardRouter
+	// BucketCount is a configured total number of buckets.
+	BucketCount uint64
+	// Rebalancer is an alias of the instance running the rebalancer. It is
+	// empty if the rebalancer is not found.
+	Rebalancer string
+	// RebalancerState is a current activity of the rebalancer. It is empty
+	// if vshard does not report the activity.
+	RebalancerState string
+	// RebalancerError is a last error of the rebalancer.
+	RebalancerError string
+	// Balanced is true if the rebalancer is idle, there are no buckets in
+	// transfer and the buckets are distributed according to the replicaset
+	// weights.
+	Balanced bool
+}
+
+// VShardStatusGetter gets a vshard status.
+type VShardStatusGetter interface {
+	// GetVShardStatus returns a vshard status of the cluster.
+	GetVShardStatus() (VShardStatus, error)
+}
+
+// vshardInstanceInfo describes a vshard state of an instance.
+type vshardInstanceInfo struct {
+	// UUID of the instance.
+	UUID string
+	// ReplicasetUUID is an UUID of the instance replicaset.
+	ReplicasetUUID string
+	// RW is true when the instance is in RW mode.
+	RW bool
+	// Storage is a storage state. It is nil if the instance is not a storage.
+	Storage *struct {
+		Bucket          VShardBuckets
+		Alerts          []VShardAlert
+		Weight          float64
+		BucketCount     uint64
+		Threshold       float64
+		Rebalancer      bool
+		RebalancerState string
+		RebalancerError string
+	}
+	// Router is a router state. It is nil if the instance is not a router.
+	Router *struct {
+		Bucket VShardRouterBuckets
+		Alerts []VShardAlert
+	}
+}
+
+// getVShardInstanceInfo returns a vshard state of an instance.
+func getVShardInstanceInfo(evaler connector.Evaler) (vshardInstanceInfo, error) {
+	var info vshardInstanceInfo
+	data, err := evaler.Eval(getVShardInfoBody, []any{}, connector.RequestOpts{})
+	if err != nil {
+		return info, err
+	}
+	if len(data) != 1 {
+		return info, fmt.Errorf("unexpected response: %v", data)
+	}
+	if err := mapstructure.Decode(data[0], &info); err != nil {
+		return info, fmt.Errorf("failed to parse a response: %w", err)
+	}
+	return info, nil
+}
+
+// getVShardStatus collects vshard states of the instances and aggregates
+// them into a vshard status of the cluster. The replicasets are used to
+// resolve aliases.
+func getVShardStatus(replicasets Replicasets,
+	evalers []connector.Evaler) (VShardStatus, error) {
+	replicasetAliases := map[string]string{}
+	instanceAliases := map[string]string{}
+	for _, replicaset := range replicasets.Replicasets {
+		replicasetAliases[replicaset.UUID] = replicaset.Alias
+		for _, instance := range replicaset.Instances {
+			instanceAliases[instance.UUID] = instance.Alias
+		}
+	}
+	aliasOrUUID := func(aliases map[string]string, uuid string) string {
+		if alias := aliases[uuid]; alias != "" {
+			return alias
+		}
+		return uuid
+	}
+
+	var status VShardStatus
+	var threshold float64
+	storages := map[string]*VShardStorage{}
+	for _, evaler := range evalers {
+		info, err := getVShardInstanceInfo(evaler)
+		if err != nil {
+			return status, fmt.Errorf("failed to get vshard info: %w", err)
+		}
+		alias := aliasOrUUID(instanceAliases, info.UUID)
+		if router := info.Router; router != nil {
+			status.Routers = append(status.Routers, VShardRouter{
+				Alias:   alias,
+				Buckets: router.Bucket,
+				Alerts:  router.Alerts,
+			})
+		}
+		if info.Storage == nil {
+			continue
+		}
+		storage := info.Storage
+		if storage.BucketCount > status.BucketCount {
+			status.BucketCount = storage.BucketCount
+		}
+		if storage.Threshold > threshold {
+			threshold = storage.Threshold
+		}
+		if storage.Rebalancer {
+			status.Rebalancer = alias
+			status.RebalancerState = storage.RebalancerState
+			status.RebalancerError = storage.RebalancerError
+		}
+
+		replicaset, ok := storages[info.ReplicasetUUID]
+		if !ok {
+			replicaset = &VShardStorage{
+				Alias: aliasOrUUID(replicasetAliases, info.ReplicasetUUID),
+				UUID:  info.ReplicasetUUID,
+			}
+			storages[info.ReplicasetUUID] = replicaset
+		}
+		replicaset.Alerts = append(replicaset.Alerts, storage.Alerts...)
+		// The buckets are reported by the master, a replica is used only
+		// until the master is found.
+		if replicaset.Master == "" || info.RW {
+			replicaset.Master = alias
+			replicaset.Weight = storage.Weight
+			replicaset.Buckets = storage.Bucket
+		}
+	}
+
+	for _, storage := range storages {
+		status.Storages = append(status.Storages, *storage)
+	}
+	sort.Slice(status.Storages, func(i, j int) bool {
+		return status.Storages[i].Alias < status.Storages[j].Alias
+	})
+	sort.Slice(status.Routers, func(i, j int) bool {
+		return status.Routers[i].Alias < status.Routers[j].Alias
+	})
+	status.Balanced = isVShardBalanced(status, threshold)
+	return status, nil
+}
+
+// getDiscoveredEvalers returns evalers for the connectable discovered
+// instances.
+func getDiscoveredEvalers(replicasets Replicasets) []connector.Evaler {
+	var evalers []connector.Evaler
+	for _, replicaset := range replicasets.Replicasets {
+		for _, instance := range replicaset.Instances {
+			if instance.InstanceCtxFound {
+				evalers = append(evalers, MakeInstanceEvalFunc(instance.InstanceCtx))
+			}
+		}
+	}
+	return evalers
+}
+
+// vshardRebalancerIdle is an activity of the rebalancer without work.
+const vshardRebalancerIdle = "idling"
+
+// isVShardBalanced returns true if the rebalancer is idle without errors,
+// there are no buckets in transfer, all the buckets are active or pinned and
+// each storage deviates from its ideal bucket count not more than by
+// the threshold in percent or by a single bucket. The rebalancer state is
+// not checked if it is unknown.
+func isVShardBalanced(status VShardStatus, threshold float64) bool {
+	if status.RebalancerError != "" {
+		return false
+	}
+	if status.RebalancerState != "" && status.RebalancerState != vshardRebalancerIdle {
+		return false
+	}
+
+	storages, bucketCount := status.Storages, status.BucketCount
+	if len(storages) == 0 || bucketCount == 0 {
+		return false
+	}
+	var active uint64
+	for _, storage := range storages {
+		buckets := storage.Buckets
+		if buckets.Sending != 0 || buckets.Receiving != 0 || buckets.Garbage != 0 {
+			return false
+		}
+		active += buckets.Active + buckets.Pinned
+	}
+	if active != bucketCount {
+		return false
+	}
+	ideals, ok := vshardIdealCounts(storages, bucketCount)
+	if !ok {
+		return false
+	}
+	for i, storage := range storages {
+		buckets := storage.Buckets.Active + storage.Buckets.Pinned
+		deviation := math.Abs(float64(buckets) - ideals[i])
+		if deviation > 1 && deviation > ideals[i]*threshold/100 {
+			return false
+		}
+	}
+	return true
+}
+
+// vshardIdealCounts returns ideal bucket counts of the storages according to
+// the weights. Pinned buckets are not moved, so a storage with more pinned
+// buckets than its ideal count keeps only them and the other buckets are
+// distributed between the rest storages as the rebalancer does. It returns
+// false if there are no weights to distribute the buckets.
+func vshardIdealCounts(storages []VShardStorage, bucketCount uint64) ([]float64, bool) {
+	ideals := make([]float64, len(storages))
+	excluded := make([]bool, len(storages))
+	for {
+		var weights float64
+		count := float64(bucketCount)
+		for i, storage := range storages {
+			if excluded[i] {
+				count -= float64(storage.Buckets.Pinned)
+			} else {
+				weights += storage.Weight
+			}
+		}
+		if weights == 0 {
+			return nil, false
+		}
+
+		changed := false
+		for i, storage := range storages {
+			pinned := float64(storage.Buckets.Pinned)
+			if excluded[i] {
+				ideals[i] = pinned
+				continue
+			}
+			ideals[i] = count * storage.Weight / weights
+			if pinned > ideals[i] {
+				excluded[i] = true
+				changed = true
+			}
+		}
+		if !changed {
+			return ideals, true
+		}
+	}
+}
+
+// newErrVShardStatusByInstanceNotSupported creates a new error that vshard status is not
+// supported by the orchestrator for a single instance.
+func newErrVShardStatusByInstanceNotSupported(orchestrator Orchestrator) error {
+	return fmt.Errorf("vshard status is not supported for a single instance by %q orchestrator",
+		orchestrator)
+}
+
+// newErrVShardStatusByAppNotSupported creates a new error that vshard status is not
+// supported by the orchestrator for an application.
+func newErrVShardStatusByAppNotSupported(orchestrator Orchestrator) error {
+	return fmt.Errorf("vshard status is not supported for an application by %q orchestrator",
+		orchestrator)
+}
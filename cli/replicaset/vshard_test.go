@@ -0,0 +1,305 @@
+package replicaset
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// vshardInfoEvaler returns an evaler that responds with the vshard info.
+func vshardInfoEvaler(info map[any]any) connector.Evaler {
+	return EvalFunc(func(expr string, args []any, opts connector.RequestOpts) ([]any, error) {
+		if expr != getVShardInfoBody {
+			return nil, fmt.Errorf("unexpected expression: %s", expr)
+		}
+		return []any{info}, nil
+	})
+}
+
+// vshardStorageInfo returns a vshard info of a storage instance.
+func vshardStorageInfo(uuid, replicasetUUID string, rw bool, active uint64) map[any]any {
+	return map[any]any{
+		"uuid":           uuid,
+		"replicasetuuid": replicasetUUID,
+		"rw":             rw,
+		"storage": map[any]any{
+			"bucket": map[any]any{
+				"active": active,
+				"total":  active,
+			},
+			"weight":      1,
+			"bucketcount": 3000,
+			"threshold":   1,
+			"rebalancer":  false,
+		},
+	}
+}
+
+func TestGetVShardStatus(t *testing.T) {
+	replicasets := Replicasets{
+		Replicasets: []Replicaset{
+			{
+				UUID:  "rs1",
+				Alias: "storage-001",
+				Instances: []Instance{
+					{UUID: "a1", Alias: "storage-001-a"},
+					{UUID: "b1", Alias: "storage-001-b"},
+				},
+			},
+			{
+				UUID:  "rs2",
+				Alias: "storage-002",
+				Instances: []Instance{
+					{UUID: "a2", Alias: "storage-002-a"},
+				},
+			},
+			{
+				UUID:      "rs3",
+				Alias:     "router-001",
+				Instances: []Instance{{UUID: "r", Alias: "router-001-a"}},
+			},
+		},
+	}
+
+	rebalancer := vshardStorageInfo("a2", "rs2", true, 1500)
+	storage := rebalancer["storage"].(map[any]any)
+	storage["rebalancer"] = true
+	storage["rebalancerstate"] = "idling"
+	replica := vshardStorageInfo("b1", "rs1", false, 0)
+	replica["storage"].(map[any]any)["alerts"] = []any{
+		map[any]any{"code": "MISSING_MASTER", "message": "Master is not configured"},
+	}
+	router := map[any]any{
+		"uuid":           "r",
+		"replicasetuuid": "rs3",
+		"rw":             true,
+		"router": map[any]any{
+			"bucket": map[any]any{
+				"available_rw": 3000,
+				"available_ro": 0,
+				"unreachable":  0,
+				"unknown":      0,
+			},
+		},
+	}
+
+	status, err := getVShardStatus(replicasets, []connector.Evaler{
+		vshardInfoEvaler(router),
+		vshardInfoEvaler(replica),
+		vshardInfoEvaler(vshardStorageInfo("a1", "rs1", true, 1500)),
+		vshardInfoEvaler(rebalancer),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, VShardStatus{
+		Storages: []VShardStorage{
+			{
+				Alias:   "storage-001",
+				UUID:    "rs1",
+				Master:  "storage-001-a",
+				Weight:  1,
+				Buckets: VShardBuckets{Active: 1500, Total: 1500},
+				Alerts: []VShardAlert{
+					{Code: "MISSING_MASTER", Message: "Master is not configured"},
+				},
+			},
+			{
+				Alias:   "storage-002",
+				UUID:    "rs2",
+				Master:  "storage-002-a",
+				Weight:  1,
+				Buckets: VShardBuckets{Active: 1500, Total: 1500},
+			},
+		},
+		Routers: []VShardRouter{
+			{
+				Alias:   "router-001-a",
+				Buckets: VShardRouterBuckets{AvailableRW: 3000},
+			},
+		},
+		BucketCount:     3000,
+		Rebalancer:      "storage-002-a",
+		RebalancerState: "idling",
+		Balanced:        true,
+	}, status)
+}
+
+func TestGetVShardStatus_error(t *testing.T) {
+	evaler := EvalFunc(func(string, []any, connector.RequestOpts) ([]any, error) {
+		return nil, fmt.Errorf("connection lost")
+	})
+	_, err := getVShardStatus(Replicasets{}, []connector.Evaler{evaler})
+	assert.EqualError(t, err, "failed to get vshard info: connection lost")
+}
+
+func TestIsVShardBalanced(t *testing.T) {
+	storage := func(weight float64, buckets VShardBuckets) VShardStorage {
+		return VShardStorage{Weight: weight, Buckets: buckets}
+	}
+
+	cases := []struct {
+		name       string
+		storages   []VShardStorage
+		count      uint64
+		threshold  float64
+		rebalancer string
+		rebError   string
+		balanced   bool
+	}{
+		{
+			name: "balanced",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1500}),
+				storage(1, VShardBuckets{Active: 1490, Pinned: 10}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  true,
+		},
+		{
+			name: "weights",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1000}),
+				storage(2, VShardBuckets{Active: 2000}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  true,
+		},
+		{
+			name: "odd",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 2}),
+				storage(1, VShardBuckets{Active: 1}),
+			},
+			count:     3,
+			threshold: 1,
+			balanced:  true,
+		},
+		{
+			name: "within threshold",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1510}),
+				storage(1, VShardBuckets{Active: 1490}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  true,
+		},
+		{
+			name: "disbalanced",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1600}),
+				storage(1, VShardBuckets{Active: 1400}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  false,
+		},
+		{
+			name: "sending",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1499, Sending: 1}),
+				storage(1, VShardBuckets{Active: 1500, Receiving: 1}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  false,
+		},
+		{
+			name: "garbage",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1500, Garbage: 1}),
+				storage(1, VShardBuckets{Active: 1500}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  false,
+		},
+		{
+			name: "not bootstrapped",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{}),
+				storage(1, VShardBuckets{}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  false,
+		},
+		{
+			name:     "no storages",
+			count:    3000,
+			balanced: false,
+		},
+		{
+			name: "pinned above ideal",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Pinned: 2000}),
+				storage(1, VShardBuckets{Active: 1000}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  true,
+		},
+		{
+			name: "pinned above ideal disbalanced",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 200, Pinned: 1800}),
+				storage(1, VShardBuckets{Active: 800}),
+				storage(1, VShardBuckets{Active: 200}),
+			},
+			count:     3000,
+			threshold: 1,
+			balanced:  false,
+		},
+		{
+			name: "rebalancer idle",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1500}),
+				storage(1, VShardBuckets{Active: 1500}),
+			},
+			count:      3000,
+			threshold:  1,
+			rebalancer: "idling",
+			balanced:   true,
+		},
+		{
+			name: "rebalancer busy",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1500}),
+				storage(1, VShardBuckets{Active: 1500}),
+			},
+			count:      3000,
+			threshold:  1,
+			rebalancer: "applying routes",
+			balanced:   false,
+		},
+		{
+			name: "rebalancer error",
+			storages: []VShardStorage{
+				storage(1, VShardBuckets{Active: 1500}),
+				storage(1, VShardBuckets{Active: 1500}),
+			},
+			count:      3000,
+			threshold:  1,
+			rebalancer: "idling",
+			rebError:   "replicaset is unreachable",
+			balanced:   false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			status := VShardStatus{
+				Storages:        c.storages,
+				BucketCount:     c.count,
+				RebalancerState: c.rebalancer,
+				RebalancerError: c.rebError,
+			}
+			assert.Equal(t, c.balanced, isVShardBalanced(status, c.threshold))
+		})
+	}
+}
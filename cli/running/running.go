@@ -679,7 +679,8 @@ func FillCtx(cliOpts *config.CliOpts, cmdCtx *cmdcontext.CmdCtx,
 	runningCtx *RunningCtx, args []string) error {
 	var err error
 
-	if len(args) > 1 && cmdCtx.CommandName != "run" && cmdCtx.CommandName != "connect" {
+	if len(args) > 1 && cmdCtx.CommandName != "run" && cmdCtx.CommandName != "connect" &&
+		cmdCtx.CommandName != "call" && cmdCtx.CommandName != "watch" {
 		return util.NewArgError("currently, you can specify only one instance at a time")
 	}
 
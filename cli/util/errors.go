@@ -6,3 +6,27 @@ var (
 	// ErrCmdAbort is reported when user aborts the program.
 	ErrCmdAbort = errors.New("aborted by user")
 )
+
+// ExitCodeError is an error that requires to exit the program with the
+// specific exit code.
+type ExitCodeError struct {
+	// Code is the exit code of the program.
+	Code int
+	// Err is the original error.
+	Err error
+}
+
+// Error returns error message.
+func (e ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the original error.
+func (e ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
+// NewExitCodeError creates and returns a new error with the exit code.
+func NewExitCodeError(code int, err error) error {
+	return &ExitCodeError{Code: code, Err: err}
+}
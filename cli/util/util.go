@@ -926,6 +926,11 @@ func HandleCmdErr(cmd *cobra.Command, err error) {
 		if errors.Is(err, ErrCmdAbort) {
 			os.Exit(1)
 		}
+		var exitCodeError *ExitCodeError
+		if errors.As(err, &exitCodeError) {
+			log.Error(exitCodeError.Error())
+			os.Exit(exitCodeError.Code)
+		}
 		log.Fatalf(err.Error())
 	}
 }
@@ -0,0 +1,96 @@
+package watch
+
+import (
+	"fmt"
+	"reflect"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+// Condition is a Lua expression that is evaluated for each notification.
+// The expression has access to the `key` and `value` global variables.
+type Condition struct {
+	state *lua.LState
+	fn    *lua.LFunction
+}
+
+// NewCondition compiles the Lua expression into a condition.
+func NewCondition(expr string) (*Condition, error) {
+	state := lua.NewState()
+	fn, err := state.LoadString("return " + expr)
+	if err != nil {
+		state.Close()
+		return nil, fmt.Errorf("failed to parse the condition %q: %w", expr, err)
+	}
+	return &Condition{
+		state: state,
+		fn:    fn,
+	}, nil
+}
+
+// Match returns true if the condition is truthy for the notification.
+func (cond *Condition) Match(event Event) (bool, error) {
+	cond.state.SetGlobal("key", lua.LString(event.Key))
+	cond.state.SetGlobal("value", toLuaValue(cond.state, event.Value))
+
+	cond.state.Push(cond.fn)
+	if err := cond.state.PCall(0, 1, nil); err != nil {
+		return false, fmt.Errorf("failed to evaluate the condition: %w", err)
+	}
+	ret := cond.state.Get(-1)
+	cond.state.Pop(1)
+	return lua.LVAsBool(ret), nil
+}
+
+// Close closes the condition. It is safe to call it multiple times.
+func (cond *Condition) Close() {
+	if cond.state != nil {
+		cond.state.Close()
+		cond.state = nil
+	}
+}
+
+// toLuaValue converts a decoded MessagePack value into a Lua value.
+func toLuaValue(state *lua.LState, value interface{}) lua.LValue {
+	if value == nil {
+		return lua.LNil
+	}
+
+	switch v := value.(type) {
+	case bool:
+		return lua.LBool(v)
+	case string:
+		return lua.LString(v)
+	case []byte:
+		return lua.LString(v)
+	case []interface{}:
+		table := state.NewTable()
+		for _, item := range v {
+			table.Append(toLuaValue(state, item))
+		}
+		return table
+	case map[interface{}]interface{}:
+		table := state.NewTable()
+		for key, item := range v {
+			table.RawSet(toLuaValue(state, key), toLuaValue(state, item))
+		}
+		return table
+	case map[string]interface{}:
+		table := state.NewTable()
+		for key, item := range v {
+			table.RawSetString(key, toLuaValue(state, item))
+		}
+		return table
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return lua.LNumber(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return lua.LNumber(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return lua.LNumber(rv.Float())
+	}
+	return lua.LString(fmt.Sprint(value))
+}
@@ -0,0 +1,47 @@
+package watch
+
+import (
+	"strings"
+)
+
+const (
+	yamlFormatStr = "yaml"
+	jsonFormatStr = "json"
+)
+
+// Format defines a set of supported output formats of notifications.
+type Format int
+
+const (
+	YamlFormat Format = iota
+	JsonFormat
+)
+
+const (
+	// DefaultFormat is a default format.
+	DefaultFormat Format = YamlFormat
+)
+
+// ParseFormat parses an output format string representation. It supports
+// mixed case letters.
+func ParseFormat(str string) (Format, bool) {
+	switch strings.ToLower(str) {
+	case yamlFormatStr:
+		return YamlFormat, true
+	case jsonFormatStr:
+		return JsonFormat, true
+	}
+	return DefaultFormat, false
+}
+
+// String returns a string representation of the output format.
+func (f Format) String() string {
+	switch f {
+	case YamlFormat:
+		return yamlFormatStr
+	case JsonFormat:
+		return jsonFormatStr
+	default:
+		panic("Unknown output format")
+	}
+}
@@ -0,0 +1,176 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/tarantool/tt/cli/connector"
+)
+
+// connCheckInterval is an interval between checks of the connection.
+const connCheckInterval = time.Second
+
+// ErrConnectionLost is reported when the connection to the instance is lost.
+var ErrConnectionLost = errors.New("connection to the instance is lost")
+
+// WatchCtx contains information for watching keys.
+type WatchCtx struct {
+	// Keys is a list of keys to watch.
+	Keys []string
+	// Format is an output format of notifications.
+	Format Format
+	// Until is a Lua expression to stop watching when it becomes truthy.
+	Until string
+}
+
+// Event is a notification about a key value.
+type Event struct {
+	// Time is a time when the notification has been received.
+	Time time.Time
+	// Key is a watched key.
+	Key string
+	// Value is a current value of the key.
+	Value interface{}
+}
+
+// eventDoc is a representation of an event for encoding.
+type eventDoc struct {
+	Time  string      `yaml:"time" json:"time"`
+	Key   string      `yaml:"key" json:"key"`
+	Value interface{} `yaml:"value" json:"value"`
+}
+
+// FormatEvent returns the event encoded in the format. A YAML event is
+// a document, a JSON event is a single line.
+func FormatEvent(event Event, format Format) (string, error) {
+	doc := eventDoc{
+		Time:  event.Time.Format(time.RFC3339Nano),
+		Key:   event.Key,
+		Value: event.Value,
+	}
+
+	switch format {
+	case YamlFormat:
+		encoded, err := yaml.Marshal(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode the notification: %w", err)
+		}
+		return "---\n" + string(encoded) + "...\n", nil
+	case JsonFormat:
+		doc.Value = toJsonValue(doc.Value)
+		encoded, err := json.Marshal(doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode the notification: %w", err)
+		}
+		return string(encoded) + "\n", nil
+	default:
+		panic("Unknown output format")
+	}
+}
+
+// Watch subscribes to the keys and writes notifications to the writer until
+// the context is done, the condition matches or the connection is lost.
+func Watch(ctx context.Context, watchCtx WatchCtx, watcher connector.Watcher,
+	writer io.Writer) error {
+	if len(watchCtx.Keys) == 0 {
+		return errors.New("no keys to watch")
+	}
+
+	var until *Condition
+	if watchCtx.Until != "" {
+		var err error
+		if until, err = NewCondition(watchCtx.Until); err != nil {
+			return err
+		}
+		defer until.Close()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	events := make(chan Event)
+	var unwatchers []func()
+	defer func() {
+		// Callbacks could be blocked on sending to the channel, so they need
+		// to be released first.
+		cancel()
+		for _, unwatch := range unwatchers {
+			unwatch()
+		}
+	}()
+
+	callback := func(key string, value interface{}) {
+		select {
+		case events <- Event{Time: time.Now(), Key: key, Value: value}:
+		case <-ctx.Done():
+		}
+	}
+	for _, key := range watchCtx.Keys {
+		unwatch, err := watcher.Watch(key, callback)
+		if err != nil {
+			return fmt.Errorf("failed to watch %q: %w", key, err)
+		}
+		unwatchers = append(unwatchers, unwatch)
+	}
+
+	ticker := time.NewTicker(connCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if !watcher.ConnectedNow() {
+				return ErrConnectionLost
+			}
+		case event := <-events:
+			output, err := FormatEvent(event, watchCtx.Format)
+			if err != nil {
+				return err
+			}
+			if _, err := io.WriteString(writer, output); err != nil {
+				return err
+			}
+
+			if until != nil {
+				matched, err := until.Match(event)
+				if err != nil {
+					return err
+				}
+				if matched {
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// toJsonValue converts maps with non-string keys from a decoded MessagePack
+// value into maps with string keys.
+func toJsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		converted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			converted = append(converted, toJsonValue(item))
+		}
+		return converted
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = toJsonValue(item)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = toJsonValue(item)
+		}
+		return converted
+	}
+	return value
+}
@@ -0,0 +1,218 @@
+package watch_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	. "github.com/tarantool/tt/cli/watch"
+)
+
+type watcherMock struct {
+	values     map[string][]interface{}
+	err        error
+	connected  bool
+	unwatched  int
+	subscribed []string
+}
+
+func (watcher *watcherMock) Watch(key string,
+	callback func(key string, value interface{})) (func(), error) {
+	if watcher.err != nil {
+		return nil, watcher.err
+	}
+	watcher.subscribed = append(watcher.subscribed, key)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, value := range watcher.values[key] {
+			callback(key, value)
+		}
+	}()
+	return func() {
+		<-done
+		watcher.unwatched++
+	}, nil
+}
+
+func (watcher *watcherMock) ConnectedNow() bool {
+	return watcher.connected
+}
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		str      string
+		expected Format
+		ok       bool
+	}{
+		{"yaml", YamlFormat, true},
+		{"YaMl", YamlFormat, true},
+		{"json", JsonFormat, true},
+		{"JSON", JsonFormat, true},
+		{"lua", DefaultFormat, false},
+		{"", DefaultFormat, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			format, ok := ParseFormat(c.str)
+			assert.Equal(t, c.ok, ok)
+			assert.Equal(t, c.expected, format)
+		})
+	}
+}
+
+func TestFormat_String(t *testing.T) {
+	assert.Equal(t, "yaml", YamlFormat.String())
+	assert.Equal(t, "json", JsonFormat.String())
+	assert.PanicsWithValue(t, "Unknown output format", func() { _ = Format(666).String() })
+}
+
+func TestFormatEvent(t *testing.T) {
+	event := Event{
+		Time: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		Key:  "box.status",
+		Value: map[interface{}]interface{}{
+			"is_ro":  false,
+			"status": "running",
+		},
+	}
+
+	output, err := FormatEvent(event, YamlFormat)
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntime: \"2023-01-02T03:04:05Z\"\nkey: box.status\n"+
+		"value:\n  is_ro: false\n  status: running\n...\n", output)
+
+	output, err = FormatEvent(event, JsonFormat)
+	require.NoError(t, err)
+	assert.Equal(t, `{"time":"2023-01-02T03:04:05Z","key":"box.status",`+
+		`"value":{"is_ro":false,"status":"running"}}`+"\n", output)
+
+	event.Value = nil
+	output, err = FormatEvent(event, JsonFormat)
+	require.NoError(t, err)
+	assert.Equal(t, `{"time":"2023-01-02T03:04:05Z","key":"box.status","value":null}`+"\n",
+		output)
+}
+
+func TestCondition(t *testing.T) {
+	cases := []struct {
+		expr     string
+		event    Event
+		expected bool
+	}{
+		{"true", Event{}, true},
+		{"value == nil", Event{}, true},
+		{"key == 'box.id'", Event{Key: "box.id"}, true},
+		{"key == 'box.id'", Event{Key: "box.status"}, false},
+		{"value.state == 'leader'", Event{
+			Value: map[interface{}]interface{}{"state": "leader"},
+		}, true},
+		{"value.is_ro == false and value.id > 1", Event{
+			Value: map[interface{}]interface{}{"is_ro": false, "id": uint64(2)},
+		}, true},
+		{"value[2] == 'b'", Event{Value: []interface{}{"a", "b"}}, true},
+		{"value", Event{Value: false}, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.expr, func(t *testing.T) {
+			cond, err := NewCondition(c.expr)
+			require.NoError(t, err)
+			defer cond.Close()
+
+			matched, err := cond.Match(c.event)
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, matched)
+		})
+	}
+}
+
+func TestCondition_error(t *testing.T) {
+	_, err := NewCondition("value ==")
+	assert.ErrorContains(t, err, `failed to parse the condition "value =="`)
+
+	cond, err := NewCondition("value.field == 1")
+	require.NoError(t, err)
+	defer cond.Close()
+	_, err = cond.Match(Event{})
+	assert.ErrorContains(t, err, "failed to evaluate the condition")
+}
+
+func TestWatch_until(t *testing.T) {
+	watcher := &watcherMock{
+		values: map[string][]interface{}{
+			"box.election": {
+				map[interface{}]interface{}{"state": "follower"},
+				map[interface{}]interface{}{"state": "leader"},
+				map[interface{}]interface{}{"state": "follower"},
+			},
+		},
+		connected: true,
+	}
+	watchCtx := WatchCtx{
+		Keys:   []string{"box.election"},
+		Format: JsonFormat,
+		Until:  "value.state == 'leader'",
+	}
+
+	var buf bytes.Buffer
+	err := Watch(context.Background(), watchCtx, watcher, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(buf.Bytes(), []byte("\n")))
+	assert.Contains(t, buf.String(), `"value":{"state":"leader"}`)
+	assert.Equal(t, []string{"box.election"}, watcher.subscribed)
+	assert.Equal(t, 1, watcher.unwatched)
+}
+
+func TestWatch_cancel(t *testing.T) {
+	watcher := &watcherMock{
+		values: map[string][]interface{}{
+			"a": {1},
+			"b": {2},
+		},
+		connected: true,
+	}
+	watchCtx := WatchCtx{
+		Keys:   []string{"a", "b"},
+		Format: YamlFormat,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var buf bytes.Buffer
+	err := Watch(ctx, watchCtx, watcher, &buf)
+	require.NoError(t, err)
+	assert.Contains(t, buf.String(), "key: a\nvalue: 1\n")
+	assert.Contains(t, buf.String(), "key: b\nvalue: 2\n")
+	assert.Equal(t, 2, watcher.unwatched)
+}
+
+func TestWatch_connectionLost(t *testing.T) {
+	watcher := &watcherMock{connected: false}
+	watchCtx := WatchCtx{Keys: []string{"a"}}
+
+	err := Watch(context.Background(), watchCtx, watcher, &bytes.Buffer{})
+	assert.ErrorIs(t, err, ErrConnectionLost)
+}
+
+func TestWatch_error(t *testing.T) {
+	watcher := &watcherMock{connected: true}
+	err := Watch(context.Background(), WatchCtx{}, watcher, &bytes.Buffer{})
+	assert.EqualError(t, err, "no keys to watch")
+
+	err = Watch(context.Background(), WatchCtx{Keys: []string{"a"}, Until: "=="},
+		watcher, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "failed to parse the condition")
+
+	watcher.err = errors.New("the feature WatchersFeature must be required")
+	err = Watch(context.Background(), WatchCtx{Keys: []string{"a"}}, watcher, &bytes.Buffer{})
+	assert.EqualError(t, err,
+		`failed to watch "a": the feature WatchersFeature must be required`)
+}
@@ -18,6 +18,9 @@ const (
 
 	// systemPathPrefixRe is a regexp for a path prefix to use without scheme.
 	systemPathPrefixRe = `(([\.~]?/+)|((../+)+))`
+
+	// profileRe is a regexp for a reference to a connection profile.
+	profileRe = `^@([\w\.-]+)$`
 )
 
 // IsBaseURI returns true if a string is a valid URI.
@@ -114,3 +117,20 @@ func ParseCredentialsURI(str string) (string, string, string) {
 
 	return newStr, credentialsSlice[0], credentialsSlice[1]
 }
+
+// IsProfileURI returns true if a string is a reference to a connection
+// profile: @name.
+func IsProfileURI(str string) bool {
+	return regexp.MustCompile(profileRe).MatchString(str)
+}
+
+// ParseProfileURI parses a reference to a connection profile and returns
+// a name of the profile or an empty string if the string is not
+// a reference.
+func ParseProfileURI(str string) string {
+	matches := regexp.MustCompile(profileRe).FindStringSubmatch(str)
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
@@ -222,3 +222,31 @@ func TestParseBaseURI(t *testing.T) {
 		assert.Equal(t, homeDir+"/a/b", address)
 	})
 }
+
+func TestParseProfileURI(t *testing.T) {
+	cases := []struct {
+		str  string
+		name string
+	}{
+		{"@prod", "prod"},
+		{"@prod-1.eu_west", "prod-1.eu_west"},
+		{"@", ""},
+		{"prod", ""},
+		{"@prod@localhost:3013", ""},
+		{"@/path", ""},
+		{testUserPass + "@localhost:3013", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.str, func(t *testing.T) {
+			assert.Equal(t, c.name != "", connect.IsProfileURI(c.str))
+			assert.Equal(t, c.name, connect.ParseProfileURI(c.str))
+		})
+	}
+
+	for _, uri := range append(validBaseUris, validCredentialsUris...) {
+		t.Run(uri, func(t *testing.T) {
+			assert.False(t, connect.IsProfileURI(uri), "URI must not be a profile")
+		})
+	}
+}
@@ -0,0 +1,21 @@
+local fio = require('fio')
+
+box.cfg({listen = 'localhost:3013'})
+
+box.schema.user.create('test', { password = 'password' , if_not_exists = true })
+
+function echo(...)
+    return ...
+end
+
+function raise(msg)
+    error(msg, 0)
+end
+
+box.schema.func.create('echo', { if_not_exists = true })
+box.schema.func.create('raise', { if_not_exists = true })
+box.schema.user.grant('test', 'execute', 'function', 'echo', { if_not_exists = true })
+box.schema.user.grant('test', 'execute', 'function', 'raise', { if_not_exists = true })
+
+fh = fio.open('ready', {'O_WRONLY', 'O_CREAT'}, tonumber('644',8))
+fh:close()
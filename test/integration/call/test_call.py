@@ -0,0 +1,86 @@
+import os
+import shutil
+import subprocess
+
+import pytest
+
+from utils import run_command_and_get_output, wait_file
+
+
+@pytest.fixture
+def call_app(tt_cmd, tmpdir_with_cfg):
+    tmpdir = tmpdir_with_cfg
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_app", "test_app.lua")
+    shutil.copy(test_app_path, tmpdir)
+
+    # Start an instance.
+    start_cmd = [tt_cmd, "start", "test_app"]
+    rc, output = run_command_and_get_output(start_cmd, cwd=tmpdir)
+    assert rc == 0
+    file = wait_file(tmpdir, 'ready', [])
+    assert file != ""
+
+    yield tmpdir
+
+    stop_cmd = [tt_cmd, "stop", "test_app"]
+    run_command_and_get_output(stop_cmd, cwd=tmpdir)
+
+
+def run_call(tt_cmd, cwd, *args):
+    call_cmd = [tt_cmd, "call", "test:password@localhost:3013", *args]
+    process = subprocess.run(
+        call_cmd,
+        cwd=cwd,
+        stderr=subprocess.STDOUT,
+        stdout=subprocess.PIPE,
+        text=True,
+    )
+    return process.returncode, process.stdout
+
+
+def test_call_args(tt_cmd, call_app):
+    rc, output = run_call(tt_cmd, call_app, "echo", "1", "foo", '{"a": [1, 2]}', "null")
+    assert rc == 0
+    assert output == "---\n- 1\n- foo\n- a:\n  - 1\n  - 2\n- null\n...\n\n"
+
+
+def test_call_no_result(tt_cmd, call_app):
+    rc, output = run_call(tt_cmd, call_app, "echo")
+    assert rc == 0
+    assert output == "---\n...\n\n"
+
+
+def test_call_format(tt_cmd, call_app):
+    rc, output = run_call(tt_cmd, call_app, "echo", "1", "foo", "-x", "lua")
+    assert rc == 0
+    assert output == '1, "foo";\n'
+
+
+def test_call_error(tt_cmd, call_app):
+    rc, output = run_call(tt_cmd, call_app, "raise", "boom")
+    assert rc == 1
+    assert 'failed to call "raise": boom' in output
+
+
+def test_call_access_denied(tt_cmd, call_app):
+    rc, output = run_call(tt_cmd, call_app, "box.space._space:select")
+    assert rc == 1
+    assert "Execute access to function 'box.space._space:select' is denied" in output
+
+
+def test_call_invalid_arg(tt_cmd, call_app):
+    rc, output = run_call(tt_cmd, call_app, "echo", "{a: 1")
+    assert rc == 1
+    assert 'failed to decode argument "{a: 1"' in output
+
+
+def test_call_mode(tt_cmd, call_app):
+    call_cmd = [tt_cmd, "call", "--mode", "rw", "test_app", "echo", "1"]
+    rc, output = run_command_and_get_output(call_cmd, cwd=call_app)
+    assert rc == 0
+    assert output == "---\n- 1\n...\n\n"
+
+    call_cmd = [tt_cmd, "call", "--mode", "ro", "test_app", "echo", "1"]
+    rc, output = run_command_and_get_output(call_cmd, cwd=call_app)
+    assert rc == 1
+    assert 'no available instance in "ro" mode' in output
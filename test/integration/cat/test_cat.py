@@ -2,6 +2,8 @@ import os
 import re
 import shutil
 
+import pytest
+
 from utils import run_command_and_get_output
 
 
@@ -18,7 +20,7 @@ def test_cat_non_existent_file(tt_cmd, tmp_path):
     cmd = [tt_cmd, "cat", "path-to-non-existent-file"]
     rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
     assert rc == 1
-    assert re.search(r"No such file or directory", output)
+    assert re.search(r"no such file or directory", output, re.IGNORECASE)
 
 
 def test_cat_snap_file(tt_cmd, tmp_path):
@@ -39,6 +41,25 @@ def test_cat_snap_file(tt_cmd, tmp_path):
     assert re.search(r"space_id: 296", output)
 
 
+@pytest.mark.parametrize("flags", [[], ["--use-tarantool"]])
+def test_cat_xlog_file_formats(tt_cmd, tmp_path, flags):
+    # Copy the .xlog file to the "run" directory.
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_file", "test.xlog")
+    shutil.copy(test_app_path, tmp_path)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--from=2", "--format=json"] + flags
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r'"space_id":280', output)
+    assert not re.search(r'"space_id":272', output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--format=lua"] + flags
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert "box.space[272]:update(" in output
+    assert "box.space[280]:insert(" in output
+
+
 def test_cat_xlog_file(tt_cmd, tmp_path):
     # Copy the .xlog file to the "run" directory.
     test_app_path = os.path.join(os.path.dirname(__file__), "test_file", "test.xlog")
@@ -49,3 +70,88 @@ def test_cat_xlog_file(tt_cmd, tmp_path):
     rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
     assert rc == 0
     assert re.search(r"replica_id: 1", output)
+
+
+def test_cat_filters(tt_cmd, tmp_path):
+    # Copy the .xlog file to the "run" directory.
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_file", "test.xlog")
+    shutil.copy(test_app_path, tmp_path)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--type=insert", "--format=json"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r'"type":"INSERT"', output)
+    assert not re.search(r'"type":"UPDATE"', output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--key=max_id", "--format=json"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r'"key":\["max_id"\]', output)
+    assert not re.search(r'"type":"INSERT"', output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--since=2022-04-15T14:46:30Z",
+           "--until=2022-04-15T14:46:31Z"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"lsn: 1", output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--since=2030-01-01"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert not re.search(r"lsn:", output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--type=select"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r'unknown request type "select"', output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--type=insert", "--use-tarantool"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"not supported with --use-tarantool", output)
+
+
+def test_cat_decode(tt_cmd, tmp_path):
+    # Copy the .snap file to the "run" directory.
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_file", "test.snap")
+    shutil.copy(test_app_path, tmp_path)
+
+    cmd = [tt_cmd, "cat", "test.snap", "--space=280", "--key=280", "--decode", "--format=json"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r'"tuple":\{"id":280,"owner":1,"name":"_space","engine":"memtx"', output)
+
+    cmd = [tt_cmd, "cat", "test.snap", "--decode", "--format=lua"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"tuples decoding is not supported for lua format", output)
+
+
+def test_cat_stats(tt_cmd, tmp_path):
+    # Copy the .xlog and .snap files to the "run" directory.
+    for file in ["test.xlog", "test.snap"]:
+        test_app_path = os.path.join(os.path.dirname(__file__), "test_file", file)
+        shutil.copy(test_app_path, tmp_path)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "test.snap", "--show-system", "--stats"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"test\.xlog\s+XLOG\s+0\.13", output)
+    assert re.search(r"test\.snap\s+SNAP\s+0\.13", output)
+    assert re.search(r"272\s+UPDATE\s+1\s+23\s+23\.0\s+1 - 1", output)
+    assert re.search(r"VClock: \{1: 2\}", output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--show-system", "--stats", "--format=json"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r'"vclock": "\{1: 2\}"', output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--stats", "--format=lua"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r'unknown statistics format "lua"', output)
+
+    cmd = [tt_cmd, "cat", "test.xlog", "--stats", "--format=yaml"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r'unknown statistics format "yaml"', output)
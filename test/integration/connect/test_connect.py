@@ -234,10 +234,18 @@ def test_connect_and_get_commands_outputs(tt_cmd, tmpdir_with_cfg):
   \\set graphics <false/true>      -- disables/enables pseudographics for table modes
   \\set table_column_width <width> -- set max column width for table/ttable
   \\xw <width>                     -- set max column width for table/ttable
+  \\set table_flatten <on/off>     -- expand nested maps and arrays into columns for table/ttable
+  \\set color <on/off/theme>       -- set syntax highlighting on/off or theme dark or light
+  \\set pager <command/on/off>     -- set pager command for a long output or disable it
   \\x                              -- switches output format cyclically
   \\x[l,t,T,y]                     -- set output format lua, table, ttable or yaml
   \\x[g,G]                         -- disables/enables pseudographics for table modes
   \\shortcuts                      -- show available hotkeys and shortcuts
+  \\connect <URI/instance>         -- connect to another instance
+  \\reconnect                      -- reconnect to the current instance
+  \\begin [isolation]              -- begin a transaction in the binary port session
+  \\commit                         -- commit the transaction
+  \\rollback                       -- rollback the transaction
   \\quit, \\q                       -- quit from the console
 
 """
@@ -254,6 +262,11 @@ def test_connect_and_get_commands_outputs(tt_cmd, tmpdir_with_cfg):
     commands["\\set graphics true"] = ""
     commands["\\set table_column_width 1"] = ""
     commands["\\xw 1"] = ""
+    commands["\\set table_flatten on"] = ""
+    commands["\\set table_flatten OFF"] = ""
+    commands["\\set color on"] = ""
+    commands["\\set color light"] = ""
+    commands["\\set color off"] = ""
     commands["\\x"] = ""
     commands["\\xl"] = ""
     commands["\\xt"] = ""
@@ -333,6 +346,10 @@ def test_connect_and_get_commands_errors(tt_cmd, tmpdir_with_cfg):
     commands["\\set table_column_width arg"] = "⨯ the command expects one unsigned number"
     commands["\\xw"] = "⨯ the command expects one unsigned number"
     commands["\\xw arg"] = "⨯ the command expects one unsigned number"
+    commands["\\set table_flatten"] = "⨯ the command expects one of: off, on"
+    commands["\\set table_flatten true"] = "⨯ the command expects one of: off, on"
+    commands["\\set color"] = "⨯ the command expects one of: dark, light, off, on"
+    commands["\\set color blue"] = "⨯ the command expects one of: dark, light, off, on"
     commands["\\x arg"] = "⨯ the command does not expect arguments"
     commands["\\xl arg"] = "⨯ the command does not expect arguments"
     commands["\\xt arg"] = "⨯ the command does not expect arguments"
@@ -341,6 +358,9 @@ def test_connect_and_get_commands_errors(tt_cmd, tmpdir_with_cfg):
     commands["\\xg arg"] = "⨯ the command does not expect arguments"
     commands["\\xG arg"] = "⨯ the command does not expect arguments"
     commands["\\shortcuts arg"] = "⨯ the command does not expect arguments"
+    commands["\\reconnect arg"] = "⨯ the command does not expect arguments"
+    commands["\\commit arg"] = "⨯ the command does not expect arguments"
+    commands["\\rollback arg"] = "⨯ the command does not expect arguments"
     commands["\\quit arg"] = "⨯ the command does not expect arguments"
     commands["\\q arg"] = "⨯ the command does not expect arguments"
 
@@ -399,6 +419,81 @@ def test_connect_to_localhost_app(tt_cmd, tmpdir_with_cfg):
     stop_app(tt_cmd, tmpdir, "test_app")
 
 
+def test_connect_script_args_and_errors(tt_cmd, tmpdir_with_cfg):
+    tmpdir = tmpdir_with_cfg
+    # The test application file.
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_localhost_app", "test_app.lua")
+    # Copy test data into temporary directory.
+    copy_data(tmpdir, [test_app_path])
+
+    # Start an instance.
+    start_app(tt_cmd, tmpdir, "test_app")
+    try:
+        # Check for start.
+        file = wait_file(os.path.join(tmpdir, 'test_app'), 'ready', [])
+        assert file != ""
+
+        def run_script(script, *args):
+            connect_cmd = [tt_cmd, "connect", "localhost:3013", "-f-", *args]
+            return subprocess.run(
+                connect_cmd,
+                cwd=tmpdir,
+                input=script,
+                stderr=subprocess.STDOUT,
+                stdout=subprocess.PIPE,
+                text=True,
+            )
+
+        # Named and positional arguments.
+        process = run_script("return arg[1], arg.mode, ...",
+                             "--arg", "mode=fast", "Hello")
+        assert process.returncode == 0
+        assert process.stdout == "---\n- Hello\n- fast\n- Hello\n...\n\n"
+
+        # An invalid named argument.
+        process = run_script("return 1", "--arg", "mode")
+        assert process.returncode == 1
+        assert re.search(r"expected key=value", process.stdout)
+
+        # An error in the whole script.
+        process = run_script("error('boom', 0)")
+        assert process.returncode == 3
+        assert "- error: boom" in process.stdout
+
+        # A syntax error in the whole script.
+        process = run_script("return 1 +")
+        assert process.returncode == 3
+        assert "- error:" in process.stdout
+        assert "null" not in process.stdout
+
+        # Stop on the first syntax error.
+        process = run_script("a = 1\nlocal = 1\nreturn 2\n", "--on-error", "stop")
+        assert process.returncode == 3
+        assert "- error:" in process.stdout
+        assert "- 2" not in process.stdout
+
+        # Stop on the first error.
+        script = "a = 1\nerror('boom', 0)\nreturn 2\n"
+        process = run_script(script, "--on-error", "stop")
+        assert process.returncode == 3
+        assert "- error: boom" in process.stdout
+        assert "- 2" not in process.stdout
+
+        # Continue after an error.
+        process = run_script(script, "--on-error", "continue")
+        assert process.returncode == 3
+        assert "- error: boom" in process.stdout
+        assert "- 2" in process.stdout
+
+        # No errors in statements.
+        process = run_script("a = 1\nreturn a + 1\n", "--on-error", "stop")
+        assert process.returncode == 0
+        assert process.stdout == "---\n...\n---\n- 2\n...\n\n"
+    finally:
+        # Stop the Instance.
+        stop_app(tt_cmd, tmpdir, "test_app")
+
+
 def test_connect_to_ssl_app(tt_cmd, tmpdir_with_cfg):
     skip_if_tarantool_ce()
 
@@ -2280,6 +2375,49 @@ def test_output_format_tables_width_option(tt_cmd, tmpdir_with_cfg):
     stop_app(tt_cmd, tmpdir, "test_app")
 
 
+def test_output_format_tables_flatten(tt_cmd, tmpdir_with_cfg):
+    tmpdir = tmpdir_with_cfg
+    # The test application file.
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_output_format_app",
+                                                            "test_app.lua")
+    # Copy test data into temporary directory.
+    copy_data(tmpdir, [test_app_path])
+
+    # Start an instance.
+    start_app(tt_cmd, tmpdir, "test_app")
+
+    # Check for start.
+    file = wait_file(os.path.join(tmpdir, 'test_app'), 'ready', [])
+    assert file != ""
+
+    # Execute stdin.
+    ret, output = try_execute_on_instance(
+        tt_cmd, tmpdir, "localhost:3013",
+        stdin=(
+            '\\set table_flatten on\n'
+            '{id = 1, address = {city = "Moscow"}}, {id = 2, tags = {"a", "b"}}\n'
+            '\\set table_flatten off\n'
+            '{id = 1, address = {city = "Moscow"}}\n'
+            ), opts={'-x': 'table'}
+        )
+    assert ret
+    assert output == ("+--------------+----+--------+--------+\n"
+                      "| address.city | id | tags.1 | tags.2 |\n"
+                      "+--------------+----+--------+--------+\n"
+                      "| Moscow       | 1  |        |        |\n"
+                      "+--------------+----+--------+--------+\n"
+                      "|              | 2  | a      | b      |\n"
+                      "+--------------+----+--------+--------+\n"
+                      "+-------------------+----+\n"
+                      "| address           | id |\n"
+                      "+-------------------+----+\n"
+                      "| {\"city\":\"Moscow\"} | 1  |\n"
+                      "+-------------------+----+\n")
+
+    # Stop the Instance.
+    stop_app(tt_cmd, tmpdir, "test_app")
+
+
 def test_output_format_tables_dialects(tt_cmd, tmpdir_with_cfg):
     tmpdir = tmpdir_with_cfg
     # The test application file.
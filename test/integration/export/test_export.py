@@ -0,0 +1,85 @@
+import glob
+import json
+import os
+import re
+
+import pytest
+
+from utils import TarantoolTestInstance, run_command_and_get_output
+
+# The name of instance config file within this integration tests.
+# This file should be in /test/integration/export/test_file/.
+INSTANCE_NAME = "remote_instance_cfg.lua"
+
+
+@pytest.fixture
+def test_instance(request, tmp_path):
+    dir = os.path.dirname(__file__)
+    test_app_path = os.path.join(dir, "test_file")
+    lua_utils_path = os.path.join(dir, "..", "..")
+    inst = TarantoolTestInstance(INSTANCE_NAME, test_app_path, lua_utils_path, tmp_path)
+    inst.start(use_lua=True)
+    request.addfinalizer(lambda: inst.stop())
+    return inst
+
+
+def instance_uri(test_instance):
+    return "test_user:secret@127.0.0.1:" + test_instance.port
+
+
+def test_export_instance_jsonl(tt_cmd, test_instance):
+    cmd = [tt_cmd, "export", instance_uri(test_instance), "--space", "customers",
+           "--page-size", "2", "-o", "customers.jsonl"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(r'Exported 3 tuples of space "customers"', output)
+
+    with open(os.path.join(test_instance._tmpdir, "customers.jsonl")) as f:
+        lines = [json.loads(line) for line in f]
+    assert lines == [
+        {"id": 1, "name": "Alice", "age": 30, "tags": ["a", "b"]},
+        {"id": 2, "name": "Bob, Jr.", "age": 25, "tags": None},
+        {"id": 3, "name": "Carol", "age": 41, "tags": []},
+    ]
+
+
+def test_export_instance_csv_where(tt_cmd, test_instance):
+    cmd = [tt_cmd, "export", instance_uri(test_instance), "--space", "customers",
+           "--format", "csv", "--where", "age>=30"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert "id,name,age,tags\n1,Alice,30,\"[\"\"a\"\",\"\"b\"\"]\"\n3,Carol,41,[]\n" in output
+
+    cmd = [tt_cmd, "export", instance_uri(test_instance), "--space", "customers",
+           "--format", "csv", "--where", "primary=2"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert "id,name,age,tags\n2,\"Bob, Jr.\",25,\n" in output
+
+
+def test_export_snapshot(tt_cmd, test_instance):
+    snap = sorted(glob.glob(os.path.join(test_instance._tmpdir, "*.snap")))[-1]
+    cmd = [tt_cmd, "export", snap, "--space", "customers", "--where", "age<30"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert '{"id":2,"name":"Bob, Jr.","age":25,"tags":null}\n' in output
+    assert re.search(r'Exported 1 tuples of space "customers"', output)
+
+
+def test_export_errors(tt_cmd, test_instance):
+    uri = instance_uri(test_instance)
+    cases = [
+        ([uri], r"the space to export must be specified with --space"),
+        ([uri, "--space", "unknown"], r'space "unknown" is not found'),
+        ([uri, "--space", "customers", "--where", "name=a"],
+         r'index "name" is not found in space "customers"'),
+        ([uri, "--space", "customers", "--where", "age"],
+         r"<index><op><key> is expected"),
+        ([uri, "--space", "customers", "--format", "xml"],
+         r'unknown export format "xml"'),
+    ]
+    for args, error in cases:
+        rc, output = run_command_and_get_output([tt_cmd, "export"] + args,
+                                                cwd=test_instance._tmpdir)
+        assert rc == 1
+        assert re.search(error, output)
@@ -0,0 +1,32 @@
+-- This is a script that configures the instance for testing tt export
+-- command. A test space 'customers' with a secondary index is filled
+-- and a snapshot is made to export the space from the file.
+-- Call require('utils').bind_free_port(arg[0]) is required for using
+-- TarantoolTestInstance class of test/utils.py.
+
+local box = require('box')
+-- The module below should be in a pytest temporary directory.
+local testutils = require('utils')
+
+local function configure_instance()
+    testutils.bind_free_port(arg[0]) -- arg[0] is 'remote_instance_cfg.lua'
+    local customers = box.schema.space.create('customers')
+    customers:format(
+        {
+            {name = 'id', type = 'unsigned'},
+            {name = 'name', type = 'string'},
+            {name = 'age', type = 'unsigned'},
+            {name = 'tags', type = 'array', is_nullable = true},
+        }
+    )
+    customers:create_index('primary', {type = 'tree', parts = {'id'}})
+    customers:create_index('age', {type = 'tree', parts = {'age'}, unique = false})
+    customers:insert({1, 'Alice', 30, {'a', 'b'}})
+    customers:insert({2, 'Bob, Jr.', 25, box.NULL})
+    customers:insert({3, 'Carol', 41, {}})
+    box.snapshot()
+    box.schema.user.create('test_user', { password = 'secret' })
+    box.schema.user.grant('test_user', 'super')
+end
+
+configure_instance()
@@ -0,0 +1,28 @@
+-- This is a script that configures the instance for testing tt import
+-- command. An empty test space 'customers' is created.
+-- Call require('utils').bind_free_port(arg[0]) is required for using
+-- TarantoolTestInstance class of test/utils.py.
+
+local box = require('box')
+-- The module below should be in a pytest temporary directory.
+local testutils = require('utils')
+
+local function configure_instance()
+    testutils.bind_free_port(arg[0]) -- arg[0] is 'remote_instance_cfg.lua'
+    local customers = box.schema.space.create('customers')
+    customers:format(
+        {
+            {name = 'id', type = 'unsigned'},
+            {name = 'name', type = 'string'},
+            {name = 'balance', type = 'decimal', is_nullable = true},
+            {name = 'uuid', type = 'uuid', is_nullable = true},
+            {name = 'created', type = 'datetime', is_nullable = true},
+        }
+    )
+    customers:create_index('primary', {type = 'tree', parts = {'id'}})
+    customers:insert({1, 'Existing'})
+    box.schema.user.create('test_user', { password = 'secret' })
+    box.schema.user.grant('test_user', 'super')
+end
+
+configure_instance()
@@ -0,0 +1,120 @@
+import json
+import os
+import re
+
+import pytest
+
+from utils import TarantoolTestInstance, run_command_and_get_output
+
+# The name of instance config file within this integration tests.
+# This file should be in /test/integration/import/test_file/.
+INSTANCE_NAME = "remote_instance_cfg.lua"
+
+
+@pytest.fixture
+def test_instance(request, tmp_path):
+    dir = os.path.dirname(__file__)
+    test_app_path = os.path.join(dir, "test_file")
+    lua_utils_path = os.path.join(dir, "..", "..")
+    inst = TarantoolTestInstance(INSTANCE_NAME, test_app_path, lua_utils_path, tmp_path)
+    inst.start(use_lua=True)
+    request.addfinalizer(lambda: inst.stop())
+    return inst
+
+
+def instance_uri(test_instance):
+    return "test_user:secret@127.0.0.1:" + test_instance.port
+
+
+def export_customers(tt_cmd, test_instance):
+    cmd = [tt_cmd, "export", instance_uri(test_instance), "--space", "customers"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    return [json.loads(line) for line in output.splitlines() if line.startswith("{")]
+
+
+@pytest.mark.parametrize("on_conflict, imported, skipped, name", [
+    ("skip", 2, 1, "Existing"),
+    ("replace", 3, 0, "Alice"),
+])
+def test_import_csv(tt_cmd, test_instance, on_conflict, imported, skipped, name):
+    cmd = [tt_cmd, "import", instance_uri(test_instance), "customers.csv",
+           "--space", "customers", "--on-conflict", on_conflict, "--batch-size", "2"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(rf"Imported {imported} tuples, skipped {skipped} duplicates,"
+                     r" rejected 2 rows", output)
+    assert re.search(r"The rejected rows are written to \"customers.csv.errors.jsonl\"",
+                     output)
+
+    tuples = export_customers(tt_cmd, test_instance)
+    assert [t["id"] for t in tuples] == [1, 2, 5]
+    assert tuples[0]["name"] == name
+    assert tuples[1] == {"id": 2, "name": "Bob"}
+    assert tuples[2]["balance"] == "-0.01"
+    assert tuples[2]["created"].startswith("2024-04-15T14:46:30")
+
+    with open(os.path.join(test_instance._tmpdir, "customers.csv.errors.jsonl")) as f:
+        errors = [json.loads(line) for line in f]
+    assert [e["line"] for e in errors] == [4, 5]
+    assert errors[0]["row"] == ["three", "Carol", "", "", ""]
+    assert errors[0]["error"] == 'field "id": invalid unsigned value "three"'
+    assert errors[1]["error"] == 'field "balance": invalid decimal value "1e"'
+
+
+def test_import_fail_on_conflict(tt_cmd, test_instance):
+    cmd = [tt_cmd, "import", instance_uri(test_instance), "customers.csv",
+           "--space", "customers", "--batch-size", "1"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 1
+    assert re.search(r"Imported 0 tuples, skipped 0 duplicates, rejected 0 rows", output)
+    assert re.search(r"line 2: Duplicate key exists", output)
+    assert not os.path.exists(os.path.join(test_instance._tmpdir,
+                                           "customers.csv.errors.jsonl"))
+
+
+def test_import_existing_report(tt_cmd, test_instance):
+    report = os.path.join(test_instance._tmpdir, "report.jsonl")
+    with open(report, "w") as f:
+        f.write("keep me\n")
+    cmd = [tt_cmd, "import", instance_uri(test_instance), "customers.csv",
+           "--space", "customers", "--errors", report]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 1
+    assert re.search(r"the error report .*report.jsonl\" already exists", output)
+    with open(report) as f:
+        assert f.read() == "keep me\n"
+
+
+def test_import_jsonl_fields(tt_cmd, test_instance, tmp_path):
+    data = os.path.join(tmp_path, "data.jsonl")
+    with open(data, "w") as f:
+        f.write('{"key": 10, "title": "Frank", "extra": true}\n')
+        f.write('[11, "Grace"]\n')
+    cmd = [tt_cmd, "import", instance_uri(test_instance), data, "--space", "customers",
+           "--fields", "key:id,title:name,1:id,2:name"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(r"Imported 2 tuples", output)
+
+    tuples = export_customers(tt_cmd, test_instance)
+    assert tuples[1:] == [{"id": 10, "name": "Frank"}, {"id": 11, "name": "Grace"}]
+
+
+def test_import_errors(tt_cmd, test_instance):
+    uri = instance_uri(test_instance)
+    cases = [
+        ([uri, "customers.csv"], r"the space to import into must be specified with --space"),
+        ([uri, "customers.csv", "--space", "unknown"], r'space "unknown" is not found'),
+        ([uri, "customers.csv", "--space", "customers", "--on-conflict", "ignore"],
+         r'unknown conflict action "ignore"'),
+        ([uri, "customers.csv", "--space", "customers", "--format", "xml"],
+         r'unknown import format "xml"'),
+        ([uri, "customers.csv", "--space", "customers", "--fields", "id:unknown"],
+         r'field "unknown" is not found in space "customers"'),
+    ]
+    for args, error in cases:
+        rc, output = run_command_and_get_output([tt_cmd, "import"] + args,
+                                                cwd=test_instance._tmpdir)
+        assert rc == 1
+        assert re.search(error, output)
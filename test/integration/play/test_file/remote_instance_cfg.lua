@@ -21,6 +21,11 @@ local function configure_instance()
     )
     tester:create_index('primary', {type = 'tree', parts = {'id'}})
     box.schema.user.grant('guest', 'read,write', 'space', 'tester')
+    -- A renamed copy of 'tester' space to test --space-map flag.
+    local tester_v2 = box.schema.space.create('tester_v2', {id = 1000})
+    tester_v2:format(tester:format())
+    tester_v2:create_index('primary', {type = 'tree', parts = {'id'}})
+    box.schema.user.grant('guest', 'read,write', 'space', 'tester_v2')
     box.schema.user.create('test_user', { password = 'secret' })
     box.schema.user.grant('test_user', 'super')
 end
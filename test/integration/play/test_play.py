@@ -42,12 +42,14 @@ def test_play_non_existent_file(tt_cmd, tmp_path, test_instance):
     cmd = [tt_cmd, "play", "127.0.0.1:" + test_instance.port, "path-to-non-existent-file"]
     rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
     assert rc == 1
-    assert re.search(r"No such file or directory", output)
+    assert re.search(r"no such file or directory", output, re.IGNORECASE)
 
 
-def test_play_test_remote_instance(tt_cmd, test_instance):
+@pytest.mark.parametrize("flags", [[], ["--use-tarantool"]])
+def test_play_test_remote_instance(tt_cmd, test_instance, flags):
     # Play .xlog file to the remote instance.
     cmd = [tt_cmd, "play", "127.0.0.1:" + test_instance.port, "test.xlog", "--space=999"]
+    cmd.extend(flags)
     rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
     assert rc == 0
     assert re.search(r"Play result: completed successfully", output)
@@ -110,3 +112,61 @@ def test_play_creds(tt_cmd, tmp_path, opts, test_instance):
 
     rc, output = run_command_and_get_output(cmd, cwd=tmp_path, env=env)
     assert rc == 0
+
+
+def test_play_dry_run(tt_cmd, tmp_path):
+    test_file_path = os.path.join(os.path.dirname(__file__), "test_file", "test.xlog")
+
+    # No connection is required for a dry run.
+    cmd = [tt_cmd, "play", "127.0.0.1:0", test_file_path, "--dry-run",
+           "--space-map=999:tester_v2"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"Play dry run: records are not sent", output)
+    assert re.search(r"space 999 -> tester_v2: 3", output)
+    assert re.search(r"Total records: 3", output)
+
+    cmd = [tt_cmd, "play", "127.0.0.1:0", test_file_path, "--dry-run", "--space-map=tester"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r'invalid space mapping "tester": src:dst is expected', output)
+
+    cmd = [tt_cmd, "play", "127.0.0.1:0", test_file_path, "--dry-run", "--use-tarantool"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"not supported with --use-tarantool", output)
+
+
+def test_play_space_map(tt_cmd, test_instance):
+    cmd = [tt_cmd, "play", "127.0.0.1:" + test_instance.port, "test.xlog", "--space=999",
+           "--space-map=999:tester_v2", "--rate=100"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(r"Play result: completed successfully", output)
+
+    cmd = [tt_cmd, "cat", "00000000000000000000.xlog", "--space=1000"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(r"space_id: 1000", output)
+    assert re.search(r"Ace of Base", output)
+
+
+def test_play_resume_file(tt_cmd, test_instance):
+    resume_file = os.path.join(test_instance._tmpdir, "play.resume")
+    cmd = [tt_cmd, "play", "127.0.0.1:" + test_instance.port, "test.xlog", "--space=999",
+           "--resume-file", resume_file]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    with open(resume_file) as f:
+        assert f.read() == "{1: 6}\n"
+
+    # The applied records are skipped, so there are no duplicate key errors.
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(r"Play result: completed successfully", output)
+
+    cmd = [tt_cmd, "play", "127.0.0.1:" + test_instance.port, "test.xlog", "--space=999",
+           "--resume-file", resume_file, "--dry-run"]
+    rc, output = run_command_and_get_output(cmd, cwd=test_instance._tmpdir)
+    assert rc == 0
+    assert re.search(r"Total records: 0", output)
@@ -0,0 +1,64 @@
+import os
+import re
+import shutil
+
+from utils import run_command_and_get_output
+
+
+def copy_src(tmp_path):
+    src = os.path.join(tmp_path, "src")
+    shutil.copytree(os.path.join(os.path.dirname(__file__), "test_file"), src)
+    return src
+
+
+def test_recover_to_lsn(tt_cmd, tmp_path):
+    src = copy_src(tmp_path)
+    dst = os.path.join(tmp_path, "dst")
+
+    cmd = [tt_cmd, "recover", "--to-lsn", "{1: 1}", "--src", src, "--dst", dst]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"Xlog .* is truncated to the target", output)
+    assert re.search(r"Recovered to vclock \{1: 1\}", output)
+    assert os.path.exists(os.path.join(dst, "00000000000000000000.snap"))
+
+    cmd = [tt_cmd, "cat", "00000000000000000000.xlog", "--show-system"]
+    rc, output = run_command_and_get_output(cmd, cwd=dst)
+    assert rc == 0
+    assert re.search(r"lsn: 1", output)
+    assert not re.search(r"lsn: 2", output)
+
+    # The destination directory must not contain .snap/.xlog files.
+    cmd = [tt_cmd, "recover", "--to-lsn", "{1: 1}", "--src", src, "--dst", dst]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"already contains .snap/.xlog files", output)
+
+
+def test_recover_to_time(tt_cmd, tmp_path):
+    src = copy_src(tmp_path)
+    dst = os.path.join(tmp_path, "dst")
+
+    cmd = [tt_cmd, "recover", "--to-time", "2030-01-01", "--src", src, "--dst", dst]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"Xlog .* is copied", output)
+    assert re.search(r"Recovered to vclock \{1: 2\}", output)
+
+    # The snapshot is made after the target.
+    cmd = [tt_cmd, "recover", "--to-time", "2022-04-15", "--src", src, "--dst", dst]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"no snapshot at or before the target", output)
+
+
+def test_recover_invalid_args(tt_cmd, tmp_path):
+    cmd = [tt_cmd, "recover", "--src", "src", "--dst", "dst"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"exactly one of --to-lsn and --to-time must be specified", output)
+
+    cmd = [tt_cmd, "recover", "--to-lsn", "{1: 1}"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"--src and --dst directories must be specified", output)
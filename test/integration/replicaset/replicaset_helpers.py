@@ -55,6 +55,13 @@ def box_ctl_promote(tt_cmd, app_name, inst_name, workdir):
     assert wait_event(10, is_leader_elected)
 
 
+def enable_custom_election(tt_cmd, app_name, instances, workdir, leader):
+    for inst in instances:
+        eval_on_instance(tt_cmd, app_name, inst, workdir,
+                         "box.cfg{election_mode = 'candidate'}")
+    box_ctl_promote(tt_cmd, app_name, leader, workdir)
+
+
 def parse_status(buf: io.StringIO):
     def next():
         return buf.readline().rstrip("\n")
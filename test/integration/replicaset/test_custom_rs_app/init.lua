@@ -0,0 +1,43 @@
+local fiber = require('fiber')
+local fio = require('fio')
+
+local name = os.getenv('TARANTOOL_INSTANCE_NAME')
+local uris = {
+    ['instance-001'] = 'localhost:3411',
+    ['instance-002'] = 'localhost:3412',
+}
+
+box.cfg({
+    listen = uris[name],
+    replication = {
+        'replicator:password@' .. uris['instance-001'],
+        'replicator:password@' .. uris['instance-002'],
+    },
+    read_only = name ~= 'instance-001',
+})
+
+if not box.info.ro then
+    box.schema.user.create('replicator', {password = 'password', if_not_exists = true})
+    box.schema.user.grant('replicator', 'replication', nil, nil, {if_not_exists = true})
+end
+
+-- Wait until the instance follows the other one.
+local function is_following()
+    for _, replica in pairs(box.info.replication) do
+        if replica.upstream ~= nil and replica.upstream.status == 'follow' then
+            return true
+        end
+    end
+    return false
+end
+
+while not is_following() do
+    fiber.sleep(0.1)
+end
+
+local fh = fio.open('ready-' .. name, {'O_WRONLY', 'O_CREAT'}, tonumber('644', 8))
+fh:close()
+
+while true do
+    fiber.sleep(5)
+end
@@ -3,10 +3,11 @@ import os
 import shutil
 
 import pytest
-from replicaset_helpers import (box_ctl_promote, parse_status,
+from replicaset_helpers import (box_ctl_promote, enable_custom_election,
+                                eval_on_instance, parse_status,
                                 start_application, stop_application)
 
-from utils import get_tarantool_version, run_command_and_get_output
+from utils import get_tarantool_version, run_command_and_get_output, wait_event
 
 tarantool_major_version, tarantool_minor_version = get_tarantool_version()
 
@@ -173,3 +174,44 @@ def test_demote_cconfig_errors(
 
     finally:
         stop_application(tt_cmd, app_name, tmpdir, instances)
+
+
+@pytest.mark.skipif(tarantool_major_version != 2,
+                    reason="skip custom test for Tarantool != 2")
+@pytest.mark.parametrize("election", [False, True])
+def test_demote_custom(tt_cmd, tmpdir_with_cfg, election):
+    tmpdir = tmpdir_with_cfg
+    app_name = "test_custom_rs_app"
+    app_path = os.path.join(tmpdir, app_name)
+    shutil.copytree(os.path.join(os.path.dirname(__file__), app_name), app_path)
+    instances = ["instance-001", "instance-002"]
+
+    try:
+        start_application(tt_cmd, tmpdir, app_name, instances)
+        if election:
+            enable_custom_election(tt_cmd, app_name, instances, tmpdir, "instance-001")
+
+            demote_cmd = [tt_cmd, "rs", "demote", f"{app_name}:instance-002"]
+            rc, out = run_command_and_get_output(demote_cmd, cwd=tmpdir)
+            assert rc != 0
+            assert "an instance must be the leader of the replicaset to demote it" in out
+
+        demote_cmd = [tt_cmd, "rs", "demote", f"{app_name}:instance-001"]
+        rc, out = run_command_and_get_output(demote_cmd, cwd=tmpdir)
+        assert rc == 0
+        assert "Done." in out
+
+        out = eval_on_instance(tt_cmd, app_name, "instance-001", tmpdir, "box.info.ro")
+        assert out.find("true") != -1
+        if election:
+            out = eval_on_instance(tt_cmd, app_name, "instance-001", tmpdir,
+                                   "box.cfg.election_mode")
+            assert out.find("candidate") != -1
+
+            def is_elected():
+                out = eval_on_instance(tt_cmd, app_name, "instance-002", tmpdir,
+                                       "box.info.ro")
+                return out.find("false") != -1
+            assert wait_event(10, is_elected)
+    finally:
+        stop_application(tt_cmd, app_name, tmpdir, instances)
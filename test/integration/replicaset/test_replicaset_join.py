@@ -0,0 +1,101 @@
+import os
+import re
+import shutil
+
+import pytest
+import yaml
+from replicaset_helpers import start_application, stop_application
+
+from utils import get_tarantool_version, run_command_and_get_output, wait_file
+
+tarantool_major_version, tarantool_minor_version = get_tarantool_version()
+
+app_name = "test_ccluster_app"
+instances = ["instance-001", "instance-002", "instance-003", "instance-004", "instance-005"]
+
+
+@pytest.fixture
+def ccluster_app(request, tt_cmd, tmpdir_with_cfg):
+    tmpdir = tmpdir_with_cfg
+    app_path = os.path.join(tmpdir, app_name)
+    shutil.copytree(os.path.join(os.path.dirname(__file__), app_name), app_path)
+    start_application(tt_cmd, tmpdir, app_name, instances)
+    request.addfinalizer(lambda: run_command_and_get_output([tt_cmd, "stop", app_name],
+                                                            cwd=tmpdir))
+    return tmpdir
+
+
+def test_join_invalid_argument(tt_cmd, tmpdir_with_cfg):
+    cmd = [tt_cmd, "replicaset", "join", "--replicaset", "replicaset-001", "app"]
+    rc, out = run_command_and_get_output(cmd, cwd=tmpdir_with_cfg)
+    assert rc == 1
+    assert re.search(r"   ⨯ an instance name is not specified", out)
+
+
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+def test_join_cconfig_errors(tt_cmd, ccluster_app):
+    tmpdir = ccluster_app
+    cases = [
+        (["--replicaset", "replicaset-001", f"{app_name}:instance-002"],
+         r'instance "instance-002" already exists in the application'),
+        (["--replicaset", "unknown", f"{app_name}:instance-006"],
+         r'replicaset "unknown" not found in the cluster configuration'),
+    ]
+    for args, err in cases:
+        rc, out = run_command_and_get_output([tt_cmd, "replicaset", "join"] + args, cwd=tmpdir)
+        assert rc == 1
+        assert re.search(err, out)
+
+
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+def test_join_cconfig_dry_run(tt_cmd, ccluster_app):
+    tmpdir = ccluster_app
+    config_path = os.path.join(tmpdir, app_name, "config.yaml")
+    with open(config_path) as f:
+        config = f.read()
+
+    cmd = [tt_cmd, "replicaset", "join", "--dry-run", "--replicaset", "replicaset-001",
+           "--uri", "localhost:3406", f"{app_name}:instance-006"]
+    rc, out = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+    assert "+          instance-006:\n" in out
+    assert "+                - uri: localhost:3406\n" in out
+    assert 'The instance "instance-006" would be added to' in out
+
+    # Nothing is changed.
+    with open(config_path) as f:
+        assert f.read() == config
+    with open(os.path.join(tmpdir, app_name, "instances.yml")) as f:
+        assert "instance-006" not in f.read()
+
+
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+def test_join_cconfig(tt_cmd, ccluster_app):
+    tmpdir = ccluster_app
+    cmd = [tt_cmd, "replicaset", "join", "--replicaset", "replicaset-001",
+           f"{app_name}:instance-006"]
+    rc, out = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0, out
+    assert re.search(r'Wait until "instance-006" joins the replicaset "replicaset-001"', out)
+    assert re.search(r'Wait until "instance-006" catches up with vclock', out)
+    assert "Done." in out
+
+    with open(os.path.join(tmpdir, app_name, "config.yaml")) as f:
+        config = yaml.safe_load(f)
+    replicaset = config["groups"]["group-001"]["replicasets"]["replicaset-001"]
+    assert "instance-006" in replicaset["instances"]
+    with open(os.path.join(tmpdir, app_name, "instances.yml")) as f:
+        assert "instance-006" in yaml.safe_load(f)
+
+    file = wait_file(os.path.join(tmpdir, app_name), "ready-instance-006", [])
+    assert file != ""
+
+    status_cmd = [tt_cmd, "replicaset", "status", app_name]
+    rc, out = run_command_and_get_output(status_cmd, cwd=tmpdir)
+    assert rc == 0
+    assert re.search(r"• instance-006 unix/:./instance-006.iproto read", out)
+
+    stop_application(tt_cmd, app_name, tmpdir, instances + ["instance-006"])
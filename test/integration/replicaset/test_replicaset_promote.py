@@ -5,9 +5,9 @@ import shutil
 import pytest
 from cartridge_helper import (cartridge_name, cartridge_password,
                               cartridge_username)
-from replicaset_helpers import (box_ctl_promote, eval_on_instance,
-                                parse_status, parse_yml, start_application,
-                                stop_application)
+from replicaset_helpers import (box_ctl_promote, enable_custom_election,
+                                eval_on_instance, parse_status, parse_yml,
+                                start_application, stop_application)
 
 from utils import (get_tarantool_version, read_kv, run_command_and_get_output,
                    wait_event)
@@ -235,6 +235,69 @@ def test_promote_cconfig_election_errors(
         stop_application(tt_cmd, app_name, tmpdir, instances)
 
 
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+@pytest.mark.parametrize("key, replicaset, master, inst, err_text", [
+    pytest.param("off_switchover", "off-failover", "off-failover-1", "off-failover-2", "",
+                 id="failover = off"),
+    pytest.param("manual", "manual-failover", "manual-failover-1", "manual-failover-2", "",
+                 id="failover = manual"),
+    pytest.param(None, "election-failover", "election-failover-1", "election-failover-2",
+                 'switchover is not supported for "election" failover', id="election"),
+])
+def test_promote_cconfig_switchover(
+    tt_cmd,
+    tmpdir_with_cfg,
+    key,
+    replicaset,
+    master,
+    inst,
+    err_text,
+):
+    test_data_dir = os.path.join(os.path.dirname(__file__), "testdata", "promote",
+                                 "cconfig_failovers")
+    kv = read_kv(test_data_dir)
+
+    tmpdir = tmpdir_with_cfg
+    app_name = "cluster_app_failovers"
+    app_path = os.path.join(tmpdir, app_name)
+    shutil.copytree(os.path.join(os.path.dirname(__file__), app_name), app_path)
+
+    instances = [master, inst]
+    # Replace instances.yml to start only necessary replicaset.
+    with open(os.path.join(app_path, "instances.yml"), "w") as f:
+        f.write("\n".join(list(map(lambda x: f"{x}:", instances))))
+
+    try:
+        start_application(tt_cmd, tmpdir, app_name, instances)
+
+        if replicaset == "election-failover":
+            box_ctl_promote(tt_cmd, app_name, master, tmpdir)
+
+        promote_cmd = [tt_cmd, "rs", "promote", "--switchover", f"{app_name}:{inst}"]
+        rc, out = run_command_and_get_output(promote_cmd, cwd=tmpdir)
+        if err_text:
+            assert rc != 0
+            assert err_text in out
+            return
+        assert rc == 0
+        assert f'Make the master "{master}" read-only' in out
+        assert f'Wait until "{inst}" catches up with vclock' in out
+        assert f'Promote "{inst}"' in out
+        assert "Done." in out
+
+        # Check status.
+        status_cmd = [tt_cmd, "rs", "status", app_name]
+        rc, out = run_command_and_get_output(status_cmd, cwd=tmpdir)
+        assert rc == 0
+
+        expected = parse_yml(kv[key])
+        actual = parse_status(io.StringIO(out))["replicasets"][replicaset]
+        assert expected == actual
+    finally:
+        stop_application(tt_cmd, app_name, tmpdir, instances)
+
+
 @pytest.mark.skipif(tarantool_major_version >= 3,
                     reason="skip cartridge tests for Tarantool 3.0")
 def test_promote_cartridge_no_instance(tt_cmd, cartridge_app):
@@ -364,3 +427,46 @@ def test_promote_cartridge_remote(tt_cmd, cartridge_app):
     assert rc == 0
     actual = parse_status(io.StringIO(out))["replicasets"][replicaset]["instances"][inst]
     assert actual["mode"] == "rw"
+
+
+@pytest.mark.skipif(tarantool_major_version != 2,
+                    reason="skip custom test for Tarantool != 2")
+@pytest.mark.parametrize("election", [False, True])
+def test_promote_custom(tt_cmd, tmpdir_with_cfg, election):
+    tmpdir = tmpdir_with_cfg
+    app_name = "test_custom_rs_app"
+    app_path = os.path.join(tmpdir, app_name)
+    shutil.copytree(os.path.join(os.path.dirname(__file__), app_name), app_path)
+    instances = ["instance-001", "instance-002"]
+
+    try:
+        start_application(tt_cmd, tmpdir, app_name, instances)
+        if election:
+            enable_custom_election(tt_cmd, app_name, instances, tmpdir, "instance-001")
+
+        promote_cmd = [tt_cmd, "rs", "promote", f"{app_name}:instance-002"]
+        rc, out = run_command_and_get_output(promote_cmd, cwd=tmpdir)
+        assert rc == 0
+        if not election:
+            assert 'Make the master "instance-001" read-only' in out
+            assert 'Wait until "instance-002" catches up with vclock' in out
+        assert "Done." in out
+
+        def is_promoted():
+            out = eval_on_instance(tt_cmd, app_name, "instance-002", tmpdir, "box.info.ro")
+            return out.find("false") != -1
+        assert wait_event(10, is_promoted)
+        out = eval_on_instance(tt_cmd, app_name, "instance-001", tmpdir, "box.info.ro")
+        assert out.find("true") != -1
+
+        promote_cmd = [tt_cmd, "rs", "promote", "--switchover", f"{app_name}:instance-001"]
+        rc, out = run_command_and_get_output(promote_cmd, cwd=tmpdir)
+        if election:
+            assert rc != 0
+            assert 'switchover is not supported for "election" failover' in out
+        else:
+            assert rc == 0
+            out = eval_on_instance(tt_cmd, app_name, "instance-002", tmpdir, "box.info.ro")
+            assert out.find("true") != -1
+    finally:
+        stop_application(tt_cmd, app_name, tmpdir, instances)
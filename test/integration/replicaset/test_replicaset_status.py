@@ -1,9 +1,11 @@
+import json
 import os
 import re
 import shutil
 import time
 
 import pytest
+import yaml
 from cartridge_helper import cartridge_name
 from replicaset_helpers import stop_application
 
@@ -180,6 +182,92 @@ Replicasets state: bootstrapped
         stop_application(tt_cmd, app_name, tmpdir, [])
 
 
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+def test_status_cconfig_replication(tt_cmd, tmpdir_with_cfg):
+    tmpdir = tmpdir_with_cfg
+    app_name = "test_ccluster_app"
+    app_path = os.path.join(tmpdir, app_name)
+    shutil.copytree(os.path.join(os.path.dirname(__file__), app_name), app_path)
+    try:
+        # Start a cluster.
+        start_cmd = [tt_cmd, "start", app_name]
+        rc, out = run_command_and_get_output(start_cmd, cwd=tmpdir)
+        assert rc == 0
+
+        for i in range(1, 6):
+            file = wait_file(os.path.join(tmpdir, app_name), f'ready-instance-00{i}', [])
+            assert file != ""
+
+        status_cmd = [tt_cmd, "replicaset", "status", "--replication", app_name]
+        rc, out = run_command_and_get_output(status_cmd, cwd=tmpdir)
+        assert rc == 0
+        assert "⚠" not in out
+        for i in range(1, 6):
+            assert re.search(rf"• instance-00{i} unix/:./instance-00{i}.iproto (rw|read)\n"
+                             r"      Election: \w+, term \d+\n"
+                             r"      VClock:   \{1: \d+.*\}\n"
+                             r"      LINK +PEER +STATUS +LAG +IDLE\n", out)
+        assert re.search(r"      upstream +instance-001 +follow +\d+\.\d{3}s +\d+\.\d{3}s\n",
+                         out)
+        assert re.search(r"      downstream +instance-002 +follow +\d+\.\d{3}s +\d+\.\d{3}s\n",
+                         out)
+    finally:
+        stop_application(tt_cmd, app_name, tmpdir, [])
+
+
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+@pytest.mark.parametrize("fmt", ["json", "yaml"])
+def test_status_cconfig_format(tt_cmd, tmpdir_with_cfg, fmt):
+    tmpdir = tmpdir_with_cfg
+    app_name = "test_ccluster_app"
+    app_path = os.path.join(tmpdir, app_name)
+    shutil.copytree(os.path.join(os.path.dirname(__file__), app_name), app_path)
+    try:
+        # Start a cluster.
+        start_cmd = [tt_cmd, "start", app_name]
+        rc, out = run_command_and_get_output(start_cmd, cwd=tmpdir)
+        assert rc == 0
+
+        for i in range(1, 6):
+            file = wait_file(os.path.join(tmpdir, app_name), f'ready-instance-00{i}', [])
+            assert file != ""
+
+        status_cmd = [tt_cmd, "replicaset", "status", "--format", fmt, app_name]
+        rc, out = run_command_and_get_output(status_cmd, cwd=tmpdir)
+        assert rc == 0
+        if fmt == "json":
+            doc = json.loads(out)
+        else:
+            doc = yaml.safe_load(out)
+
+        assert doc["orchestrator"] == "centralized config"
+        assert doc["state"] == "bootstrapped"
+        assert [rs["alias"] for rs in doc["replicasets"]] == ["replicaset-001",
+                                                              "replicaset-002"]
+        rs = doc["replicasets"][0]
+        assert rs["failover"] == "off"
+        assert rs["master"] == "single"
+        assert [(inst["alias"], inst["uri"], inst["mode"]) for inst in rs["instances"]] == [
+            ("instance-001", "unix/:./instance-001.iproto", "rw"),
+            ("instance-002", "unix/:./instance-002.iproto", "read"),
+            ("instance-003", "unix/:./instance-003.iproto", "read"),
+        ]
+        replication = rs["instances"][0]["replication"]
+        assert len(replication["vclock"]) > 0
+        assert [link["status"] for link in replication["upstreams"]] == ["follow", "follow"]
+    finally:
+        stop_application(tt_cmd, app_name, tmpdir, [])
+
+
+def test_status_invalid_format(tt_cmd, tmpdir_with_cfg):
+    status_cmd = [tt_cmd, "replicaset", "status", "--format", "xml", "localhost:3013"]
+    rc, out = run_command_and_get_output(status_cmd, cwd=tmpdir_with_cfg)
+    assert rc == 1
+    assert re.search(r"   ⨯ unsupported output format: xml", out)
+
+
 @pytest.mark.skipif(tarantool_major_version > 2,
                     reason="skip custom test for Tarantool > 2")
 @pytest.mark.parametrize("flag", [None, "--custom"])
@@ -234,3 +234,55 @@ def test_vshard_bootstrap_cconfig(tt_cmd, vshard_cconfig_app_tt_env, flag):
         return out.find("false") != -1
 
     assert wait_event(10, have_buckets_created)
+
+
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+def test_vshard_status_cconfig(tt_cmd, vshard_cconfig_app_tt_env):
+    tmpdir = vshard_cconfig_app_tt_env
+    cmd = [tt_cmd, "rs", "vs", "status", vshard_cconfig_app_name]
+    rc, out = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+    assert re.search(r"storage-001\s+storage-001-a\s+1\s+0\s+0\s+0\s+0\s+0\s+0", out)
+    assert "Bucket count: 3000" in out
+    assert "Balanced: no" in out
+
+    cmd = [tt_cmd, "rs", "vs", "bootstrap", vshard_cconfig_app_name]
+    rc, _ = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+
+    cmd = [tt_cmd, "rs", "vs", "status", "--wait-balanced", "--timeout", "30",
+           vshard_cconfig_app_name]
+    rc, out = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc == 0
+    for storage in ["storage-001", "storage-002"]:
+        assert re.search(storage + r"\s+" + storage + r"-a\s+1\s+1500\s+0\s+0\s+0\s+0\s+1500",
+                         out)
+    assert re.search(r"router-001-a\s+3000\s+0\s+0\s+0", out)
+    assert re.search(r"Rebalancer: storage-00[12]-a", out)
+    assert "Balanced: yes" in out
+
+
+@pytest.mark.skipif(tarantool_major_version > 2,
+                    reason="skip cartridge test for Tarantool > 2")
+def test_vshard_status_cartridge(cartridge_app, tt_cmd):
+    cmd = [tt_cmd, "rs", "vs", "status", "--wait-balanced", "--timeout", "30",
+           cartridge_name]
+    rc, out = run_command_and_get_output(cmd, cwd=cartridge_app.workdir)
+    assert rc == 0
+    assert "Storages:" in out
+    assert "Routers:" in out
+    assert "Rebalancer: " in out
+    assert "Balanced: yes" in out
+
+
+@pytest.mark.skipif(tarantool_major_version < 3,
+                    reason="skip centralized config test for Tarantool < 3")
+def test_vshard_status_via_uri(tt_cmd, vshard_cconfig_app_tt_env):
+    tmpdir = vshard_cconfig_app_tt_env
+    cmd = [tt_cmd, "rs", "vs", "status",
+           "--username", "client", "--password", "secret",
+           os.path.join(tmpdir, vshard_cconfig_app_name, "storage-001-a.iproto")]
+    rc, out = run_command_and_get_output(cmd, cwd=tmpdir)
+    assert rc != 0
+    assert "vshard status is not supported for a single instance" in out
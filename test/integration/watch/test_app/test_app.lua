@@ -0,0 +1,17 @@
+local fio = require('fio')
+local fiber = require('fiber')
+
+box.cfg({listen = 'localhost:3013'})
+
+box.schema.user.create('test', { password = 'password' , if_not_exists = true })
+
+-- Broadcast a counter value until it reaches the limit.
+fiber.create(function()
+    for i = 1, 100 do
+        box.broadcast('test_counter', {value = i})
+        fiber.sleep(0.1)
+    end
+end)
+
+fh = fio.open('ready', {'O_WRONLY', 'O_CREAT'}, tonumber('644',8))
+fh:close()
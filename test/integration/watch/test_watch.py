@@ -0,0 +1,84 @@
+import json
+import os
+import shutil
+import subprocess
+
+import pytest
+
+from utils import get_tarantool_version, run_command_and_get_output, wait_file
+
+tarantool_major_version, tarantool_minor_version = get_tarantool_version()
+
+
+@pytest.fixture
+def watch_app(tt_cmd, tmpdir_with_cfg):
+    tmpdir = tmpdir_with_cfg
+    test_app_path = os.path.join(os.path.dirname(__file__), "test_app", "test_app.lua")
+    shutil.copy(test_app_path, tmpdir)
+
+    # Start an instance.
+    start_cmd = [tt_cmd, "start", "test_app"]
+    rc, output = run_command_and_get_output(start_cmd, cwd=tmpdir)
+    assert rc == 0
+    file = wait_file(tmpdir, 'ready', [])
+    assert file != ""
+
+    yield tmpdir
+
+    stop_cmd = [tt_cmd, "stop", "test_app"]
+    run_command_and_get_output(stop_cmd, cwd=tmpdir)
+
+
+def run_watch(tt_cmd, cwd, *args):
+    watch_cmd = [tt_cmd, "watch", "test:password@localhost:3013", *args]
+    process = subprocess.run(
+        watch_cmd,
+        cwd=cwd,
+        stderr=subprocess.STDOUT,
+        stdout=subprocess.PIPE,
+        text=True,
+        timeout=30,
+    )
+    return process.returncode, process.stdout
+
+
+@pytest.mark.skipif((tarantool_major_version, tarantool_minor_version) < (2, 10),
+                    reason="skip watchers test for Tarantool < 2.10")
+def test_watch_until_json(tt_cmd, watch_app):
+    rc, output = run_watch(tt_cmd, watch_app, "test_counter", "box.id",
+                           "--format", "json", "--until",
+                           "key == 'test_counter' and value.value >= 5")
+    assert rc == 0
+
+    events = [json.loads(line) for line in output.splitlines()]
+    assert len(events) > 1
+    assert {event["key"] for event in events} == {"test_counter", "box.id"}
+    assert all(event["time"] for event in events)
+    assert events[-1]["key"] == "test_counter"
+    assert events[-1]["value"]["value"] >= 5
+
+
+@pytest.mark.skipif((tarantool_major_version, tarantool_minor_version) < (2, 10),
+                    reason="skip watchers test for Tarantool < 2.10")
+def test_watch_until_yaml(tt_cmd, watch_app):
+    rc, output = run_watch(tt_cmd, watch_app, "box.status",
+                           "--until", "value.status == 'running'")
+    assert rc == 0
+    assert output.startswith("---\ntime: ")
+    assert "key: box.status\n" in output
+    assert "  status: running\n" in output
+    assert output.endswith("...\n")
+
+
+def test_watch_invalid_format(tt_cmd, watch_app):
+    rc, output = run_watch(tt_cmd, watch_app, "box.status", "--format", "lua")
+    assert rc == 1
+    assert "unsupported output format: lua" in output
+
+
+@pytest.mark.skipif((tarantool_major_version, tarantool_minor_version) < (2, 10),
+                    reason="skip watchers test for Tarantool < 2.10")
+def test_watch_invalid_until(tt_cmd, watch_app):
+    rc, output = run_watch(tt_cmd, watch_app, "box.status", "--until", "value ==")
+    assert rc == 1
+    assert "failed to parse the condition" in output
@@ -0,0 +1,67 @@
+import json
+import os
+import re
+import shutil
+import subprocess
+
+from utils import run_command_and_get_output
+
+
+def copy_data(tmp_path):
+    data = os.path.join(tmp_path, "data")
+    shutil.copytree(os.path.join(os.path.dirname(__file__), "test_file"), data)
+    return data
+
+
+def test_xlog_diff(tt_cmd, tmp_path):
+    data = copy_data(tmp_path)
+    snap = os.path.join(data, "00000000000000000000.snap")
+
+    cmd = [tt_cmd, "xlog", "diff", snap, data]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"The data are identical", output)
+
+    dump = os.path.join(tmp_path, "diff.jsonl")
+    cmd = [tt_cmd, "xlog", "diff", snap, data, "--show-system", "--dump", dump]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r"272\s+_schema\s+2\s+0\s+0\s+1", output)
+    assert re.search(r"280\s+_space\s+25\s+0\s+1\s+0", output)
+    assert re.search(r"Differing tuples: 2", output)
+
+    with open(dump) as f:
+        lines = [json.loads(line) for line in f]
+    assert lines == [
+        {"space_id": 272, "space": "_schema", "status": "different", "key": ["max_id"],
+         "a": ["max_id", 511], "b": ["max_id", 512]},
+        {"space_id": 280, "space": "_space", "status": "only_b", "key": [512],
+         "b": [512, 1, "MY_TEST_SPACE", "memtx", 0, {}, []]},
+    ]
+
+
+def test_xlog_diff_space(tt_cmd, tmp_path):
+    data = copy_data(tmp_path)
+    snap = os.path.join(data, "00000000000000000000.snap")
+
+    cmd = [tt_cmd, "xlog", "diff", data, snap, "--space", "272", "--dump", "-"]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 0
+    assert re.search(r'"status":"different","key":\["max_id"\],"a":\["max_id",512\]', output)
+    assert not re.search(r'"space_id":280', output)
+
+    # The summary is written to stderr with the dump to stdout.
+    process = subprocess.run(cmd, cwd=tmp_path, capture_output=True, text=True)
+    assert process.returncode == 0
+    assert not re.search(r"Differing tuples", process.stdout)
+    assert re.search(r"Differing tuples: 1", process.stderr)
+
+
+def test_xlog_diff_invalid_args(tt_cmd, tmp_path):
+    data = copy_data(tmp_path)
+    xlog = os.path.join(data, "00000000000000000000.xlog")
+
+    cmd = [tt_cmd, "xlog", "diff", xlog, data]
+    rc, output = run_command_and_get_output(cmd, cwd=tmp_path)
+    assert rc == 1
+    assert re.search(r"is not a .snap file or a directory", output)